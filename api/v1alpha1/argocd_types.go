@@ -22,9 +22,12 @@ import (
 	"github.com/argoproj-labs/argocd-operator/common"
 
 	autoscaling "k8s.io/api/autoscaling/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 func init() {
@@ -39,6 +42,7 @@ func init() {
 // ArgoCD is the Schema for the argocds API
 // +k8s:openapi-gen=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 //+operator-sdk:csv:customresourcedefinitions:resources={{ArgoCD,v1alpha1,""}}
 //+operator-sdk:csv:customresourcedefinitions:resources={{ArgoCDExport,v1alpha1,""}}
 //+operator-sdk:csv:customresourcedefinitions:resources={{ConfigMap,v1,""}}
@@ -105,6 +109,28 @@ type ArgoCDApplicationControllerSpec struct {
 
 	// Env lets you specify environment for application controller pods
 	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// PodTemplateOverride is a partial pod template applied as a strategic merge patch to the pod template of the Application Controller StatefulSet, after the operator has built it.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	PodTemplateOverride *runtime.RawExtension `json:"podTemplateOverride,omitempty"`
+
+	// PDB configures the PodDisruptionBudget for the pods of the Application Controller StatefulSet.
+	PDB *ArgoCDPodDisruptionBudgetSpec `json:"pdb,omitempty"`
+
+	// Affinity defines the scheduling constraints of the Application Controller pods. It replaces the affinity set by the operator.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// TopologySpreadConstraints describes how the Application Controller pods are spread across topology domains.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// PriorityClassName is the name of the PriorityClass of the Application Controller pods.
+	PriorityClassName string `json:"priorityClassName,omitempty"`
 }
 
 // ArgoCDApplicationControllerShardSpec defines the options available for enabling sharding for the Application Controller component.
@@ -114,7 +140,30 @@ type ArgoCDApplicationControllerShardSpec struct {
 	Enabled bool `json:"enabled,omitempty"`
 
 	// Replicas defines the number of replicas to run in the Application controller shard.
+	// +kubebuilder:validation:Minimum=0
 	Replicas int32 `json:"replicas,omitempty"`
+
+	// DynamicScalingEnabled defines whether the number of shards is derived from the number of clusters registered with Argo CD, instead of Replicas.
+	DynamicScalingEnabled bool `json:"dynamicScalingEnabled,omitempty"`
+
+	// MinShards is the minimum number of shards when dynamic scaling is enabled. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	MinShards int32 `json:"minShards,omitempty"`
+
+	// MaxShards is the maximum number of shards when dynamic scaling is enabled. The number of shards is not limited if unset.
+	// +kubebuilder:validation:Minimum=1
+	MaxShards int32 `json:"maxShards,omitempty"`
+
+	// ClustersPerShard is the number of clusters managed by each shard when dynamic scaling is enabled. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	ClustersPerShard int32 `json:"clustersPerShard,omitempty"`
+
+	// BalancedAssignment defines whether the operator assigns the shard of each cluster Secret, balancing the clusters by weight across the shards instead of letting Argo CD hash cluster IDs to shards.
+	BalancedAssignment bool `json:"balancedAssignment,omitempty"`
+
+	// WeightAnnotation is the cluster Secret annotation holding the weight of the cluster for the balanced assignment, e.g. its number of applications or resources.
+	// Clusters without a valid weight have a weight of 1. Defaults to argocd.argoproj.io/shard-weight.
+	WeightAnnotation string `json:"weightAnnotation,omitempty"`
 }
 
 // ArgoCDApplicationSet defines whether the Argo CD ApplicationSet controller should be installed.
@@ -131,6 +180,75 @@ type ArgoCDApplicationSet struct {
 
 	// LogLevel describes the log level that should be used by the ApplicationSet controller. Defaults to ArgoCDDefaultLogLevel if not set.  Valid options are debug,info, error, and warn.
 	LogLevel string `json:"logLevel,omitempty"`
+
+	// Autoscale defines the autoscale options for the ApplicationSet controller.
+	Autoscale ArgoCDAutoscaleSpec `json:"autoscale,omitempty"`
+
+	// PodTemplateOverride is a partial pod template applied as a strategic merge patch to the pod template of the ApplicationSet controller Deployment, after the operator has built it.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	PodTemplateOverride *runtime.RawExtension `json:"podTemplateOverride,omitempty"`
+
+	// PDB configures the PodDisruptionBudget for the pods of the ApplicationSet Controller Deployment.
+	PDB *ArgoCDPodDisruptionBudgetSpec `json:"pdb,omitempty"`
+
+	// Affinity defines the scheduling constraints of the ApplicationSet Controller pods. It replaces the affinity set by the operator.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// TopologySpreadConstraints describes how the ApplicationSet Controller pods are spread across topology domains.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// PriorityClassName is the name of the PriorityClass of the ApplicationSet Controller pods.
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// WebhookServer defines the options for exposing the webhook server of the ApplicationSet Controller.
+	WebhookServer ArgoCDApplicationSetWebhookServerSpec `json:"webhookServer,omitempty"`
+}
+
+// ArgoCDApplicationSetWebhookServerSpec defines the options for exposing the ApplicationSet Controller webhook server,
+// which Git providers call to refresh the Git generators of ApplicationSets.
+type ArgoCDApplicationSetWebhookServerSpec struct {
+
+	// Host is the hostname to use for Ingress/Route resources.
+	Host string `json:"host,omitempty"`
+
+	// Ingress defines the desired state for an Ingress for the ApplicationSet Controller webhook server.
+	Ingress ArgoCDIngressSpec `json:"ingress,omitempty"`
+
+	// Route defines the desired state for an OpenShift Route for the ApplicationSet Controller webhook server.
+	Route ArgoCDRouteSpec `json:"route,omitempty"`
+
+	// GitHubSecretRef references the key of a Secret holding the secret of the GitHub webhooks.
+	GitHubSecretRef *corev1.SecretKeySelector `json:"githubSecretRef,omitempty"`
+
+	// GitLabSecretRef references the key of a Secret holding the secret token of the GitLab webhooks.
+	GitLabSecretRef *corev1.SecretKeySelector `json:"gitlabSecretRef,omitempty"`
+}
+
+// ArgoCDAutoscaleSpec defines the desired state for autoscaling an Argo CD component with a HorizontalPodAutoscaler.
+type ArgoCDAutoscaleSpec struct {
+	// Enabled will toggle autoscaling support for the component.
+	Enabled bool `json:"enabled"`
+
+	// MinReplicas is the lower limit for the number of replicas. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// MaxReplicas is the upper limit for the number of replicas. Defaults to 3.
+	// +kubebuilder:validation:Minimum=1
+	MaxReplicas int32 `json:"maxReplicas,omitempty"`
+
+	// Metrics contains the CPU, memory or custom metric targets used to calculate the desired replica count.
+	// Defaults to an average CPU utilization of 50%.
+	Metrics []autoscalingv2beta2.MetricSpec `json:"metrics,omitempty"`
+
+	// Behavior configures the scaling behavior of the HorizontalPodAutoscaler in the up and down directions.
+	Behavior *autoscalingv2beta2.HorizontalPodAutoscalerBehavior `json:"behavior,omitempty"`
 }
 
 // ArgoCDCASpec defines the CA options for ArgCD.
@@ -142,6 +260,31 @@ type ArgoCDCASpec struct {
 	SecretName string `json:"secretName,omitempty"`
 }
 
+// ArgoCDCertManagerSpec defines the cert-manager options for the ArgoCD certificates.
+type ArgoCDCertManagerSpec struct {
+	// IssuerRef is the cert-manager Issuer or ClusterIssuer used to issue the certificates.
+	IssuerRef ArgoCDCertManagerIssuerRef `json:"issuerRef"`
+
+	// Duration is the requested lifetime of the certificates. Defaults to the duration of the issuer.
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// RenewBefore is how long before the expiry the certificates are renewed. Defaults to a third of the duration.
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+}
+
+// ArgoCDCertManagerIssuerRef references a cert-manager Issuer or ClusterIssuer.
+type ArgoCDCertManagerIssuerRef struct {
+	// Name is the name of the issuer.
+	Name string `json:"name"`
+
+	// Kind is the kind of the issuer, either Issuer or ClusterIssuer. Defaults to Issuer.
+	//+kubebuilder:validation:Enum=Issuer;ClusterIssuer
+	Kind string `json:"kind,omitempty"`
+
+	// Group is the API group of the issuer. Defaults to cert-manager.io.
+	Group string `json:"group,omitempty"`
+}
+
 // ArgoCDCertificateSpec defines the options for the ArgoCD certificates.
 type ArgoCDCertificateSpec struct {
 	// SecretName is the name of the Secret containing the Certificate and Key.
@@ -165,6 +308,11 @@ type ArgoCDDexSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="OpenShift OAuth Enabled'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Dex","urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
 	OpenShiftOAuth bool `json:"openShiftOAuth,omitempty"`
 
+	// Replicas is the replica count for the Dex Deployment.
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Replicas",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Dex","urn:alm:descriptor:com.tectonic.ui:podCount"}
+	// +kubebuilder:validation:Minimum=0
+	Replicas *int32 `json:"replicas,omitempty"`
+
 	// Resources defines the Compute Resources required by the container for Dex.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resource Requirements'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Dex","urn:alm:descriptor:com.tectonic.ui:resourceRequirements"}
 	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
@@ -172,6 +320,28 @@ type ArgoCDDexSpec struct {
 	// Version is the Dex container image tag.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Version",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Dex","urn:alm:descriptor:com.tectonic.ui:text"}
 	Version string `json:"version,omitempty"`
+
+	// PodTemplateOverride is a partial pod template applied as a strategic merge patch to the pod template of the Dex Deployment, after the operator has built it.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	PodTemplateOverride *runtime.RawExtension `json:"podTemplateOverride,omitempty"`
+
+	// PDB configures the PodDisruptionBudget for the pods of the Dex Deployment.
+	PDB *ArgoCDPodDisruptionBudgetSpec `json:"pdb,omitempty"`
+
+	// Affinity defines the scheduling constraints of the Dex pods. It replaces the affinity set by the operator.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// TopologySpreadConstraints describes how the Dex pods are spread across topology domains.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// PriorityClassName is the name of the PriorityClass of the Dex pods.
+	PriorityClassName string `json:"priorityClassName,omitempty"`
 }
 
 // ArgoCDDexOAuthSpec defines the desired state for the Dex OAuth configuration.
@@ -180,12 +350,61 @@ type ArgoCDDexOAuthSpec struct {
 	Enabled bool `json:"enabled"`
 }
 
+// ArgoCDGatewaySpec defines the desired state for the Gateway API routes of a component.
+type ArgoCDGatewaySpec struct {
+	// Annotations is the map of annotations to use for the route resources.
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Labels is the map of labels to use for the route resources.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Enabled will toggle the creation of the Gateway API routes.
+	Enabled bool `json:"enabled"`
+
+	// ParentRef references the Gateway the routes are attached to.
+	ParentRef ArgoCDGatewayParentRef `json:"parentRef,omitempty"`
+
+	// Hostnames are the hostnames matched by the routes. Defaults to the host of the component.
+	Hostnames []string `json:"hostnames,omitempty"`
+
+	// Path is the path prefix matched by the HTTPRoute. Defaults to "/".
+	Path string `json:"path,omitempty"`
+
+	// TLS defines the Gateway listener terminating TLS for the routes.
+	TLS *ArgoCDGatewayTLSSpec `json:"tls,omitempty"`
+}
+
+// ArgoCDGatewayParentRef references a Gateway and optionally one of its listeners.
+type ArgoCDGatewayParentRef struct {
+	// Name is the name of the Gateway.
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the Gateway. Defaults to the namespace of the ArgoCD.
+	Namespace string `json:"namespace,omitempty"`
+
+	// SectionName is the name of the listener of the Gateway. Defaults to all listeners allowing the routes.
+	SectionName string `json:"sectionName,omitempty"`
+}
+
+// ArgoCDGatewayTLSSpec defines the Gateway listener terminating TLS for the routes of a component.
+type ArgoCDGatewayTLSSpec struct {
+	// SectionName is the name of the HTTPS listener of the Gateway. The routes are attached to this listener instead of the section of the parent reference.
+	SectionName string `json:"sectionName"`
+
+	// InsecureRedirect will additionally attach an HTTPRoute to the section of the parent reference, redirecting plain HTTP requests to HTTPS.
+	// Not supported for GRPCRoutes.
+	InsecureRedirect bool `json:"insecureRedirect,omitempty"`
+}
+
 // ArgoCDGrafanaSpec defines the desired state for the Grafana component.
 type ArgoCDGrafanaSpec struct {
 	// Enabled will toggle Grafana support globally for ArgoCD.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enabled",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Grafana","urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
 	Enabled bool `json:"enabled"`
 
+	// Gateway defines the desired state for a Gateway API HTTPRoute for the Grafana component.
+	Gateway ArgoCDGatewaySpec `json:"gateway,omitempty"`
+
 	// Host is the hostname to use for Ingress/Route resources.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Host",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Grafana","urn:alm:descriptor:com.tectonic.ui:text"}
 	Host string `json:"host,omitempty"`
@@ -211,6 +430,28 @@ type ArgoCDGrafanaSpec struct {
 	// Version is the Grafana container image tag.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Version",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Grafana","urn:alm:descriptor:com.tectonic.ui:text"}
 	Version string `json:"version,omitempty"`
+
+	// PodTemplateOverride is a partial pod template applied as a strategic merge patch to the pod template of the Grafana Deployment, after the operator has built it.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	PodTemplateOverride *runtime.RawExtension `json:"podTemplateOverride,omitempty"`
+
+	// PDB configures the PodDisruptionBudget for the pods of the Grafana Deployment.
+	PDB *ArgoCDPodDisruptionBudgetSpec `json:"pdb,omitempty"`
+
+	// Affinity defines the scheduling constraints of the Grafana pods. It replaces the affinity set by the operator.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// TopologySpreadConstraints describes how the Grafana pods are spread across topology domains.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// PriorityClassName is the name of the PriorityClass of the Grafana pods.
+	PriorityClassName string `json:"priorityClassName,omitempty"`
 }
 
 // ArgoCDHASpec defines the desired state for High Availability support for Argo CD.
@@ -227,6 +468,50 @@ type ArgoCDHASpec struct {
 
 	// Resources defines the Compute Resources required by the container for HA.
 	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// PodTemplateOverride is a partial pod template applied as a strategic merge patch to the pod template of the Redis HAProxy Deployment, after the operator has built it.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	PodTemplateOverride *runtime.RawExtension `json:"podTemplateOverride,omitempty"`
+
+	// PDB configures the PodDisruptionBudget for the pods of the Redis HAProxy Deployment.
+	PDB *ArgoCDPodDisruptionBudgetSpec `json:"pdb,omitempty"`
+
+	// Affinity defines the scheduling constraints of the Redis HAProxy pods. It replaces the affinity set by the operator.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// TopologySpreadConstraints describes how the Redis HAProxy pods are spread across topology domains.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// PriorityClassName is the name of the PriorityClass of the Redis HAProxy pods.
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// RedisReplicas is the number of Redis and Sentinel pods. Defaults to 3.
+	// +kubebuilder:validation:Minimum=1
+	RedisReplicas *int32 `json:"redisReplicas,omitempty"`
+
+	// RedisProxyReplicas is the number of Redis HAProxy pods.
+	// +kubebuilder:validation:Minimum=0
+	RedisProxyReplicas *int32 `json:"redisProxyReplicas,omitempty"`
+
+	// SentinelQuorum is the number of Sentinels that need to agree a Redis master is down before failing over. Defaults to 2.
+	// +kubebuilder:validation:Minimum=1
+	SentinelQuorum *int32 `json:"sentinelQuorum,omitempty"`
+
+	// SentinelDownAfter is how long a Redis master is unreachable before a Sentinel considers it down. Defaults to 10s.
+	SentinelDownAfter *metav1.Duration `json:"sentinelDownAfter,omitempty"`
+
+	// SentinelFailoverTimeout is the failover timeout of the Sentinels. Defaults to 3m.
+	SentinelFailoverTimeout *metav1.Duration `json:"sentinelFailoverTimeout,omitempty"`
+
+	// RedisStorage is the PersistentVolumeClaim template of the Redis data. Redis persists snapshots of its data in the
+	// claimed volumes when set, and keeps its data in memory only otherwise.
+	RedisStorage *corev1.PersistentVolumeClaimSpec `json:"redisStorage,omitempty"`
 }
 
 // ArgoCDImportSpec defines the desired state for the ArgoCD import/restore process.
@@ -249,14 +534,29 @@ type ArgoCDIngressSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Ingress Enabled'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Grafana","urn:alm:descriptor:com.tectonic.ui:fieldGroup:Prometheus","urn:alm:descriptor:com.tectonic.ui:fieldGroup:Server","urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
 	Enabled bool `json:"enabled"`
 
+	// Hosts are the additional hostnames of the Ingress, next to the host of the component.
+	Hosts []string `json:"hosts,omitempty"`
+
+	// IngressClassName is the name of the IngressClass of the Ingress. When set, the deprecated
+	// kubernetes.io/ingress.class annotation is not added to the Ingress.
+	IngressClassName *string `json:"ingressClassName,omitempty"`
+
+	// Labels is the map of labels to apply to the Ingress.
+	Labels map[string]string `json:"labels,omitempty"`
+
 	// Path used for the Ingress resource.
 	Path string `json:"path,omitempty"`
 
+	// PathType is the type of the path of the Ingress rules. Defaults to ImplementationSpecific.
+	//+kubebuilder:validation:Enum=Exact;Prefix;ImplementationSpecific
+	PathType *networkingv1.PathType `json:"pathType,omitempty"`
+
 	// TLS configuration. Currently the Ingress only supports a single TLS
 	// port, 443. If multiple members of this list specify different hosts, they
 	// will be multiplexed on the same port according to the hostname specified
 	// through the SNI TLS extension, if the ingress controller fulfilling the
-	// ingress supports SNI.
+	// ingress supports SNI. Members without a secret name use a TLS secret
+	// generated by the operator for their hosts.
 	// +optional
 	TLS []networkingv1.IngressTLS `json:"tls,omitempty"`
 }
@@ -270,12 +570,56 @@ type ArgoCDList struct {
 	Items           []ArgoCD `json:"items"`
 }
 
+// ArgoCDNetworkPolicySpec defines the NetworkPolicies generated for the Argo CD components.
+type ArgoCDNetworkPolicySpec struct {
+	// Enabled will toggle the generation of a NetworkPolicy for each Argo CD component.
+	Enabled bool `json:"enabled,omitempty"`
+
+	// ApplicationSet lists additional sources allowed to reach the ApplicationSet controller pods, e.g. a Git provider sending webhooks.
+	ApplicationSet []networkingv1.NetworkPolicyPeer `json:"applicationSet,omitempty"`
+
+	// Controller lists additional sources allowed to reach the Application Controller pods, e.g. Prometheus.
+	Controller []networkingv1.NetworkPolicyPeer `json:"controller,omitempty"`
+
+	// Dex lists additional sources allowed to reach the Dex pods.
+	Dex []networkingv1.NetworkPolicyPeer `json:"dex,omitempty"`
+
+	// Notifications lists additional sources allowed to reach the Notifications controller pods, e.g. Prometheus.
+	Notifications []networkingv1.NetworkPolicyPeer `json:"notifications,omitempty"`
+
+	// Redis lists additional sources allowed to reach the Redis pods, or the Redis HA proxy pods when HA is enabled.
+	Redis []networkingv1.NetworkPolicyPeer `json:"redis,omitempty"`
+
+	// Repo lists additional sources allowed to reach the Repo Server pods, e.g. Prometheus.
+	Repo []networkingv1.NetworkPolicyPeer `json:"repo,omitempty"`
+
+	// Server lists additional sources allowed to reach the metrics of the Argo CD Server pods, e.g. Prometheus.
+	// The Argo CD Server API is reachable from all sources.
+	Server []networkingv1.NetworkPolicyPeer `json:"server,omitempty"`
+}
+
+// ArgoCDPodDisruptionBudgetSpec defines the desired state of the PodDisruptionBudget for an Argo CD component.
+type ArgoCDPodDisruptionBudgetSpec struct {
+	// Enabled will toggle the PodDisruptionBudget for the component. Defaults to the value of HA.Enabled.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// MinAvailable is the number or percentage of pods that must remain available during a voluntary disruption.
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+
+	// MaxUnavailable is the number or percentage of pods that can be unavailable during a voluntary disruption.
+	// Defaults to 1 when neither MinAvailable nor MaxUnavailable is set.
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
 // ArgoCDPrometheusSpec defines the desired state for the Prometheus component.
 type ArgoCDPrometheusSpec struct {
 	// Enabled will toggle Prometheus support globally for ArgoCD.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enabled",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Prometheus","urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
 	Enabled bool `json:"enabled"`
 
+	// Gateway defines the desired state for a Gateway API HTTPRoute for the Prometheus component.
+	Gateway ArgoCDGatewaySpec `json:"gateway,omitempty"`
+
 	// Host is the hostname to use for Ingress/Route resources.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Host",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Prometheus","urn:alm:descriptor:com.tectonic.ui:text"}
 	Host string `json:"host,omitempty"`
@@ -308,12 +652,63 @@ type ArgoCDRBACSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Policy",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:RBAC","urn:alm:descriptor:com.tectonic.ui:text"}
 	Policy *string `json:"policy,omitempty"`
 
+	// Roles are the custom roles to render into the RBAC policy, in addition to Policy.
+	// Each role is rendered with the "role:" prefix.
+	//+listType=map
+	//+listMapKey=name
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Roles",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:RBAC"}
+	Roles []ArgoCDRBACRole `json:"roles,omitempty"`
+
+	// Bindings assign roles to groups or users, in addition to Policy.
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Bindings",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:RBAC"}
+	Bindings []ArgoCDRBACBinding `json:"bindings,omitempty"`
+
 	// Scopes controls which OIDC scopes to examine during rbac enforcement (in addition to `sub` scope).
 	// If omitted, defaults to: '[groups]'.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Scopes",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:RBAC","urn:alm:descriptor:com.tectonic.ui:text"}
 	Scopes *string `json:"scopes,omitempty"`
 }
 
+// ArgoCDRBACRole defines a custom Argo CD RBAC role and its permissions.
+type ArgoCDRBACRole struct {
+	// Name is the name of the role, without the "role:" prefix.
+	//+kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Permissions are the policy rules granted to, or denied for, the role.
+	Permissions []ArgoCDRBACPermission `json:"permissions,omitempty"`
+}
+
+// ArgoCDRBACPermission defines a single Argo CD RBAC policy rule.
+type ArgoCDRBACPermission struct {
+	// Resource is the Argo CD resource type, e.g. applications, clusters or repositories.
+	//+kubebuilder:validation:MinLength=1
+	Resource string `json:"resource"`
+
+	// Action is the action on the resource, e.g. get, create, sync or *.
+	//+kubebuilder:validation:MinLength=1
+	Action string `json:"action"`
+
+	// Object is the object the rule applies to, e.g. my-project/* for applications. Defaults to *.
+	Object string `json:"object,omitempty"`
+
+	// Effect of the rule. Defaults to allow.
+	//+kubebuilder:validation:Enum=allow;deny
+	Effect string `json:"effect,omitempty"`
+}
+
+// ArgoCDRBACBinding assigns an Argo CD RBAC role to a group or user.
+type ArgoCDRBACBinding struct {
+	// Subject is the group or user the role is assigned to.
+	//+kubebuilder:validation:MinLength=1
+	Subject string `json:"subject"`
+
+	// Role is the name of the role to assign, without the "role:" prefix. Either one of Roles or one of
+	// the built-in roles admin and readonly.
+	//+kubebuilder:validation:MinLength=1
+	Role string `json:"role"`
+}
+
 // ArgoCDRedisSpec defines the desired state for the Redis server component.
 type ArgoCDRedisSpec struct {
 	// Image is the Redis container image.
@@ -327,11 +722,67 @@ type ArgoCDRedisSpec struct {
 	// Version is the Redis container image tag.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Version",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Redis","urn:alm:descriptor:com.tectonic.ui:text"}
 	Version string `json:"version,omitempty"`
+
+	// PodTemplateOverride is a partial pod template applied as a strategic merge patch to the pod template of the Redis Deployment, or the Redis StatefulSet when HA is enabled, after the operator has built it.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	PodTemplateOverride *runtime.RawExtension `json:"podTemplateOverride,omitempty"`
+
+	// PDB configures the PodDisruptionBudget for the pods of the Redis Deployment, or the Redis StatefulSet when HA is enabled.
+	PDB *ArgoCDPodDisruptionBudgetSpec `json:"pdb,omitempty"`
+
+	// Affinity defines the scheduling constraints of the Redis pods. It replaces the affinity set by the operator.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// TopologySpreadConstraints describes how the Redis pods are spread across topology domains.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// PriorityClassName is the name of the PriorityClass of the Redis pods.
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// AuthEnabled enables password authentication for Redis. The password is generated by the operator and stored in the `auth` key of the `<argocd-name>-redis` Secret.
+	AuthEnabled bool `json:"authEnabled,omitempty"`
+
+	// TLSEnabled enables TLS for the connections to Redis, using the certificate in the argocd-operator-redis-tls Secret.
+	// The certificate is issued by cert-manager when configured, and signed by the operator CA otherwise. Requires Redis 6 or later and Argo CD v2.3 or later.
+	TLSEnabled bool `json:"tlsEnabled,omitempty"`
+
+	// External configures the Argo CD components to use a Redis managed outside of the operator.
+	// When set, the operator does not deploy Redis, and the HA, AuthEnabled and TLSEnabled options cannot be used.
+	External *ArgoCDRedisExternalSpec `json:"external,omitempty"`
+}
+
+// ArgoCDRedisExternalSpec defines a Redis managed outside of the operator.
+type ArgoCDRedisExternalSpec struct {
+	// Address is the host:port of the external Redis, or of its Sentinel when SentinelMasterName is set.
+	// +kubebuilder:validation:MinLength=1
+	Address string `json:"address"`
+
+	// SentinelMasterName is the name of the master monitored by the Sentinel at Address. Redis is connected to directly when not set.
+	SentinelMasterName string `json:"sentinelMasterName,omitempty"`
+
+	// PasswordSecretRef selects the key of a Secret in the namespace of the ArgoCD containing the Redis password.
+	PasswordSecretRef *corev1.SecretKeySelector `json:"passwordSecretRef,omitempty"`
+
+	// TLSEnabled enables TLS for the connections to the external Redis. Requires Argo CD v2.3 or later.
+	TLSEnabled bool `json:"tlsEnabled,omitempty"`
+
+	// CASecretRef selects the key of a Secret in the namespace of the ArgoCD containing the PEM encoded CA certificate
+	// the external Redis is verified with. The system CAs are used when not set. Requires TLSEnabled.
+	CASecretRef *corev1.SecretKeySelector `json:"caSecretRef,omitempty"`
 }
 
 // ArgoCDRepoSpec defines the desired state for the Argo CD repo server component.
 type ArgoCDRepoSpec struct {
 
+	// Autoscale defines the autoscale options for the Repo Server.
+	Autoscale ArgoCDAutoscaleSpec `json:"autoscale,omitempty"`
+
 	// LogLevel describes the log level that should be used by the Repo Server. Defaults to ArgoCDDefaultLogLevel if not set.  Valid options are debug, info, error, and warn.
 	LogLevel string `json:"logLevel,omitempty"`
 
@@ -341,6 +792,11 @@ type ArgoCDRepoSpec struct {
 	// MountSAToken describes whether you would like to have the Repo server mount the service account token
 	MountSAToken bool `json:"mountsatoken,omitempty"`
 
+	// Replicas is the replica count for the Repo Server Deployment. It cannot be set when autoscaling is enabled.
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Replicas",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Repo","urn:alm:descriptor:com.tectonic.ui:podCount"}
+	// +kubebuilder:validation:Minimum=0
+	Replicas *int32 `json:"replicas,omitempty"`
+
 	// Resources defines the Compute Resources required by the container for Redis.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resource Requirements'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Repo","urn:alm:descriptor:com.tectonic.ui:resourceRequirements"}
 	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
@@ -367,6 +823,197 @@ type ArgoCDRepoSpec struct {
 
 	// Env lets you specify environment for repo server pods
 	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// Volumes adds volumes to the repo server Deployment, in addition to the volumes managed by the operator.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Volumes []corev1.Volume `json:"volumes,omitempty"`
+
+	// VolumeMounts adds volume mounts to the repo server container, in addition to the volume mounts managed by the operator.
+	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty"`
+
+	// InitContainers defines the init containers of the repo server Deployment, e.g. to copy tool binaries into a shared volume.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	InitContainers []corev1.Container `json:"initContainers,omitempty"`
+
+	// SidecarContainers adds containers to the repo server Deployment, next to the repo server container.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	SidecarContainers []corev1.Container `json:"sidecarContainers,omitempty"`
+
+	// Plugins are the Config Management Plugins run as sidecars of the repo server.
+	// +listType=map
+	// +listMapKey=name
+	Plugins []ArgoCDRepoPluginSpec `json:"plugins,omitempty"`
+
+	// PodTemplateOverride is a partial pod template applied as a strategic merge patch to the pod template of the Repo Server Deployment, after the operator has built it.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	PodTemplateOverride *runtime.RawExtension `json:"podTemplateOverride,omitempty"`
+
+	// PDB configures the PodDisruptionBudget for the pods of the Repo Server Deployment.
+	PDB *ArgoCDPodDisruptionBudgetSpec `json:"pdb,omitempty"`
+
+	// Affinity defines the scheduling constraints of the Repo Server pods. It replaces the affinity set by the operator.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// TopologySpreadConstraints describes how the Repo Server pods are spread across topology domains.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// PriorityClassName is the name of the PriorityClass of the Repo Server pods.
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+}
+
+// ResourceOverride customizes the behavior of Argo CD for a resource group/kind.
+type ResourceOverride struct {
+	// Group is the API group of the resource, empty for the core group.
+	Group string `json:"group,omitempty"`
+
+	// Kind is the kind of the resource.
+	// +kubebuilder:validation:MinLength=1
+	Kind string `json:"kind"`
+
+	// Health is the custom health check for the resource.
+	Health *ResourceHealthCheck `json:"health,omitempty"`
+
+	// Actions are the custom actions available for the resource.
+	Actions *ResourceActions `json:"actions,omitempty"`
+
+	// IgnoreDifferences lists the fields of the resource ignored when diffing.
+	IgnoreDifferences *ResourceIgnoreDifferences `json:"ignoreDifferences,omitempty"`
+
+	// KnownTypeFields maps fields of the resource to known Kubernetes types, so they can be normalized when diffing.
+	KnownTypeFields []KnownTypeField `json:"knownTypeFields,omitempty"`
+}
+
+// ResourceHealthCheck defines a custom health check for a resource.
+type ResourceHealthCheck struct {
+	// Lua is the Lua script used to assess the health of the resource.
+	Lua string `json:"lua"`
+
+	// UseOpenLibs gives the script access to the standard Lua libraries.
+	UseOpenLibs bool `json:"useOpenLibs,omitempty"`
+}
+
+// ResourceActions defines the custom actions available for a resource.
+type ResourceActions struct {
+	// DiscoveryLua is the Lua script returning the actions available for a resource.
+	DiscoveryLua string `json:"discoveryLua,omitempty"`
+
+	// Definitions are the actions that can be run on the resource.
+	Definitions []ResourceActionDefinition `json:"definitions,omitempty"`
+}
+
+// ResourceActionDefinition defines a single custom resource action.
+type ResourceActionDefinition struct {
+	// Name is the name of the action.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// ActionLua is the Lua script run by the action.
+	ActionLua string `json:"actionLua"`
+}
+
+// ResourceIgnoreDifferences defines the fields ignored when diffing a resource.
+type ResourceIgnoreDifferences struct {
+	// JSONPointers are the JSON pointers of the ignored fields.
+	JSONPointers []string `json:"jsonPointers,omitempty"`
+
+	// JQPathExpressions are the JQ path expressions of the ignored fields.
+	JQPathExpressions []string `json:"jqPathExpressions,omitempty"`
+
+	// ManagedFieldsManagers ignores the fields owned by the given field managers.
+	ManagedFieldsManagers []string `json:"managedFieldsManagers,omitempty"`
+}
+
+// KnownTypeField maps a field of a resource to a known Kubernetes type.
+type KnownTypeField struct {
+	// Field is the path of the field, for example spec.jobTemplate.spec.
+	// +kubebuilder:validation:MinLength=1
+	Field string `json:"field"`
+
+	// Type is the type of the field, for example batch/v1/JobSpec.
+	// +kubebuilder:validation:MinLength=1
+	Type string `json:"type"`
+}
+
+// ArgoCDRepoPluginSpec defines a Config Management Plugin run as a sidecar of the repo server.
+type ArgoCDRepoPluginSpec struct {
+	// Name is the name of the plugin, as referenced by Applications.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=50
+	Name string `json:"name"`
+
+	// Image is the container image of the plugin sidecar. The image must contain the tools used by the plugin commands.
+	// +kubebuilder:validation:MinLength=1
+	Image string `json:"image"`
+
+	// Version is the container image tag of the plugin sidecar.
+	Version string `json:"version,omitempty"`
+
+	// Resources defines the Compute Resources required by the plugin sidecar.
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Spec is the plugin definition, rendered as the plugin.yaml file of the plugin sidecar.
+	Spec ArgoCDRepoPluginDefinition `json:"spec"`
+}
+
+// ArgoCDRepoPluginDefinition defines the commands run by a Config Management Plugin.
+type ArgoCDRepoPluginDefinition struct {
+	// Version is the version of the plugin.
+	Version string `json:"version,omitempty"`
+
+	// Init is the command run before generating manifests.
+	Init *ArgoCDRepoPluginCommand `json:"init,omitempty"`
+
+	// Generate is the command that generates the manifests.
+	Generate ArgoCDRepoPluginCommand `json:"generate"`
+
+	// Discover defines how the plugin detects the applications it supports.
+	Discover *ArgoCDRepoPluginDiscover `json:"discover,omitempty"`
+
+	// AllowConcurrency allows concurrent manifest generation for the same repository.
+	AllowConcurrency bool `json:"allowConcurrency,omitempty"`
+
+	// LockRepo locks the repository during manifest generation.
+	LockRepo bool `json:"lockRepo,omitempty"`
+}
+
+// ArgoCDRepoPluginCommand defines a command run by a Config Management Plugin.
+type ArgoCDRepoPluginCommand struct {
+	// Command is the command to run.
+	// +kubebuilder:validation:MinItems=1
+	Command []string `json:"command"`
+
+	// Args are the arguments of the command.
+	Args []string `json:"args,omitempty"`
+}
+
+// ArgoCDRepoPluginDiscover defines how a Config Management Plugin detects the applications it supports.
+type ArgoCDRepoPluginDiscover struct {
+	// FileName is a glob matched against the files of the application directory.
+	FileName string `json:"fileName,omitempty"`
+
+	// Find defines a glob or a command used to detect the application.
+	Find *ArgoCDRepoPluginFind `json:"find,omitempty"`
+}
+
+// ArgoCDRepoPluginFind defines a glob or a command used by a Config Management Plugin to detect an application.
+type ArgoCDRepoPluginFind struct {
+	// Glob is a glob matched against the files of the repository.
+	Glob string `json:"glob,omitempty"`
+
+	// Command is the command to run, the application is supported if it has any output.
+	Command []string `json:"command,omitempty"`
+
+	// Args are the arguments of the command.
+	Args []string `json:"args,omitempty"`
 }
 
 // ArgoCDRouteSpec defines the desired state for an OpenShift Route.
@@ -403,6 +1050,9 @@ type ArgoCDServerAutoscaleSpec struct {
 
 // ArgoCDServerGRPCSpec defines the desired state for the Argo CD Server GRPC options.
 type ArgoCDServerGRPCSpec struct {
+	// Gateway defines the desired state for the Argo CD Server Gateway API GRPCRoute.
+	Gateway ArgoCDGatewaySpec `json:"gateway,omitempty"`
+
 	// Host is the hostname to use for Ingress/Route resources.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="GRPC Host",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Server","urn:alm:descriptor:com.tectonic.ui:text"}
 	Host string `json:"host,omitempty"`
@@ -420,6 +1070,9 @@ type ArgoCDServerSpec struct {
 	// GRPC defines the state for the Argo CD Server GRPC options.
 	GRPC ArgoCDServerGRPCSpec `json:"grpc,omitempty"`
 
+	// Gateway defines the desired state for a Gateway API HTTPRoute for the Argo CD Server component.
+	Gateway ArgoCDGatewaySpec `json:"gateway,omitempty"`
+
 	// Host is the hostname to use for Ingress/Route resources.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Host",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Server","urn:alm:descriptor:com.tectonic.ui:text"}
 	Host string `json:"host,omitempty"`
@@ -437,6 +1090,11 @@ type ArgoCDServerSpec struct {
 	// LogFormat refers to the log level to be used by the ArgoCD Server component. Defaults to ArgoCDDefaultLogFormat if not configured. Valid options are text or json.
 	LogFormat string `json:"logFormat,omitempty"`
 
+	// Replicas is the replica count for the Argo CD Server Deployment. It cannot be set when autoscaling is enabled.
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Replicas",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Server","urn:alm:descriptor:com.tectonic.ui:podCount"}
+	// +kubebuilder:validation:Minimum=0
+	Replicas *int32 `json:"replicas,omitempty"`
+
 	// Resources defines the Compute Resources required by the container for the Argo CD server component.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resource Requirements'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:fieldGroup:Server","urn:alm:descriptor:com.tectonic.ui:resourceRequirements"}
 	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
@@ -449,6 +1107,28 @@ type ArgoCDServerSpec struct {
 
 	// Env lets you specify environment for API server pods
 	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// PodTemplateOverride is a partial pod template applied as a strategic merge patch to the pod template of the Argo CD Server Deployment, after the operator has built it.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	PodTemplateOverride *runtime.RawExtension `json:"podTemplateOverride,omitempty"`
+
+	// PDB configures the PodDisruptionBudget for the pods of the Argo CD Server Deployment.
+	PDB *ArgoCDPodDisruptionBudgetSpec `json:"pdb,omitempty"`
+
+	// Affinity defines the scheduling constraints of the Argo CD Server pods. It replaces the affinity set by the operator.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// TopologySpreadConstraints describes how the Argo CD Server pods are spread across topology domains.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// PriorityClassName is the name of the PriorityClass of the Argo CD Server pods.
+	PriorityClassName string `json:"priorityClassName,omitempty"`
 }
 
 // ArgoCDServerServiceSpec defines the Service options for Argo CD Server component.
@@ -489,6 +1169,198 @@ type KustomizeVersionSpec struct {
 	Path string `json:"path,omitempty"`
 }
 
+// LocalUserSpec defines an Argo CD local user and the credentials generated for it.
+type LocalUserSpec struct {
+	// Name of the local user. The name admin is reserved for the built-in administrator.
+	//+kubebuilder:validation:MinLength=1
+	//+kubebuilder:validation:MaxLength=63
+	//+kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Enabled controls whether the user can authenticate. Defaults to true.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// APIKey enables the apiKey capability and a generated API token for the user. Defaults to true.
+	APIKey *bool `json:"apiKey,omitempty"`
+
+	// Login enables the login capability and a generated password for the user.
+	Login bool `json:"login,omitempty"`
+
+	// TokenLifetime is the lifetime of the generated API token, e.g. 720h. The token does not expire when omitted.
+	TokenLifetime *metav1.Duration `json:"tokenLifetime,omitempty"`
+
+	// AutoRenewToken controls whether an expiring API token is replaced with a new one once 80% of its lifetime
+	// has passed. Defaults to true.
+	AutoRenewToken *bool `json:"autoRenewToken,omitempty"`
+}
+
+// ArgoCDNotifications defines whether the Argo CD Notifications controller should be installed.
+type ArgoCDNotifications struct {
+	// Image is the Argo CD Notifications image (optional)
+	Image string `json:"image,omitempty"`
+
+	// Version is the Argo CD Notifications image tag. (optional)
+	Version string `json:"version,omitempty"`
+
+	// Resources defines the Compute Resources required by the container for Argo CD Notifications.
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// LogLevel describes the log level that should be used by the Notifications controller. Defaults to ArgoCDDefaultLogLevel if not set.  Valid options are debug,info, error, and warn.
+	LogLevel string `json:"logLevel,omitempty"`
+
+	// Context holds the variables that are available to every template, e.g. argocdUrl.
+	Context map[string]string `json:"context,omitempty"`
+
+	// Triggers define when a notification is sent and which templates are used.
+	//+listType=map
+	//+listMapKey=name
+	Triggers []NotificationTrigger `json:"triggers,omitempty"`
+
+	// Templates define the content of the notifications.
+	//+listType=map
+	//+listMapKey=name
+	Templates []NotificationTemplate `json:"templates,omitempty"`
+
+	// Services configure the notification services, e.g. slack, email or webhook.
+	Services []NotificationService `json:"services,omitempty"`
+
+	// PodTemplateOverride is a partial pod template applied as a strategic merge patch to the pod template of the Notifications controller Deployment, after the operator has built it.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	PodTemplateOverride *runtime.RawExtension `json:"podTemplateOverride,omitempty"`
+
+	// PDB configures the PodDisruptionBudget for the pods of the Notifications Controller Deployment.
+	PDB *ArgoCDPodDisruptionBudgetSpec `json:"pdb,omitempty"`
+
+	// Affinity defines the scheduling constraints of the Notifications Controller pods. It replaces the affinity set by the operator.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// TopologySpreadConstraints describes how the Notifications Controller pods are spread across topology domains.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// PriorityClassName is the name of the PriorityClass of the Notifications Controller pods.
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+}
+
+// NotificationTrigger defines a named notification trigger.
+type NotificationTrigger struct {
+	// Name of the trigger, rendered as the trigger.<name> key in argocd-notifications-cm.
+	//+kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Conditions are the conditions of the trigger and the templates to send when they are met.
+	//+kubebuilder:validation:MinItems=1
+	Conditions []NotificationTriggerCondition `json:"conditions"`
+}
+
+// NotificationTriggerCondition defines a condition of a notification trigger.
+type NotificationTriggerCondition struct {
+	// Description of the condition.
+	Description string `json:"description,omitempty"`
+
+	// When is the expression that must evaluate to true for the notification to be sent.
+	//+kubebuilder:validation:MinLength=1
+	When string `json:"when"`
+
+	// Send is the list of templates to send when the condition is met.
+	//+kubebuilder:validation:MinItems=1
+	Send []string `json:"send"`
+
+	// OncePer is the expression of a value that the notification is sent only once for, e.g. app.status.sync.revision.
+	OncePer string `json:"oncePer,omitempty"`
+}
+
+// NotificationTemplate defines a named notification template.
+type NotificationTemplate struct {
+	// Name of the template, rendered as the template.<name> key in argocd-notifications-cm.
+	//+kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	NotificationTemplateSpec `json:",inline"`
+}
+
+// NotificationTemplateSpec defines the content of a notification template.
+type NotificationTemplateSpec struct {
+	// Message is the notification message, used by every service without a service specific template.
+	Message string `json:"message,omitempty"`
+
+	// Email is the email specific part of the template.
+	Email *NotificationEmailTemplate `json:"email,omitempty"`
+
+	// Slack is the slack specific part of the template.
+	Slack *NotificationSlackTemplate `json:"slack,omitempty"`
+
+	// Webhook are the requests to send to the webhook services, keyed by service name.
+	Webhook map[string]NotificationWebhookTemplate `json:"webhook,omitempty"`
+}
+
+// NotificationEmailTemplate defines the email specific part of a notification template.
+type NotificationEmailTemplate struct {
+	// Subject of the email.
+	Subject string `json:"subject,omitempty"`
+}
+
+// NotificationSlackTemplate defines the slack specific part of a notification template.
+type NotificationSlackTemplate struct {
+	// Attachments is the JSON list of message attachments.
+	Attachments string `json:"attachments,omitempty"`
+
+	// Blocks is the JSON list of message blocks.
+	Blocks string `json:"blocks,omitempty"`
+
+	// GroupingKey groups the messages with the same key in a thread.
+	GroupingKey string `json:"groupingKey,omitempty"`
+
+	// NotifyBroadcast also sends the threaded messages to the channel.
+	NotifyBroadcast bool `json:"notifyBroadcast,omitempty"`
+}
+
+// NotificationWebhookTemplate defines a webhook request of a notification template.
+type NotificationWebhookTemplate struct {
+	// Method is the HTTP method of the request. Defaults to GET.
+	Method string `json:"method,omitempty"`
+
+	// Path is appended to the webhook service URL.
+	Path string `json:"path,omitempty"`
+
+	// Body is the request body.
+	Body string `json:"body,omitempty"`
+}
+
+// NotificationService defines the configuration of a notification service.
+type NotificationService struct {
+	// Type of the service, e.g. slack, email, teams or webhook.
+	//+kubebuilder:validation:MinLength=1
+	Type string `json:"type"`
+
+	// Name of the service, when multiple services of the same type are configured. The service is rendered as the
+	// service.<type>.<name> key in argocd-notifications-cm, or service.<type> when omitted.
+	Name string `json:"name,omitempty"`
+
+	// Config is the service specific configuration. Sensitive values are referenced as $<key> from Secrets.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	Config *runtime.RawExtension `json:"config,omitempty"`
+
+	// Secrets are copied into the argocd-notifications-secret Secret, so that Config can reference them as $<key>.
+	Secrets []NotificationServiceSecret `json:"secrets,omitempty"`
+}
+
+// NotificationServiceSecret defines a value to copy into the argocd-notifications-secret Secret.
+type NotificationServiceSecret struct {
+	// Key in the argocd-notifications-secret Secret.
+	//+kubebuilder:validation:MinLength=1
+	Key string `json:"key"`
+
+	// SecretKeyRef selects the value from a Secret in the namespace of the ArgoCD.
+	SecretKeyRef corev1.SecretKeySelector `json:"secretKeyRef"`
+}
+
 //ArgoCDNodePlacementSpec is used to specify NodeSelector and Tolerations for Argo CD workloads
 type ArgoCDNodePlacementSpec struct {
 	// NodeSelector is a field of PodSpec, it is a map of key value pairs used for node selection
@@ -564,6 +1436,16 @@ type ArgoCDSpec struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Kustomize Build Options'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
 	KustomizeVersions []KustomizeVersionSpec `json:"kustomizeVersions,omitempty"`
 
+	// LocalUsers are the Argo CD local users to manage. Each user gets an account in argocd-cm and an operator
+	// generated API token and/or password in the <argocd-name>-local-user-<user-name> Secret.
+	//+listType=map
+	//+listMapKey=name
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Local Users",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	LocalUsers []LocalUserSpec `json:"localUsers,omitempty"`
+
+	// NetworkPolicy defines the NetworkPolicies generated for the Argo CD components.
+	NetworkPolicy ArgoCDNetworkPolicySpec `json:"networkPolicy,omitempty"`
+
 	// OIDCConfig is the OIDC configuration as an alternative to dex.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="OIDC Config'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
 	OIDCConfig string `json:"oidcConfig,omitempty"`
@@ -571,6 +1453,9 @@ type ArgoCDSpec struct {
 	// NodePlacement defines NodeSelectors and Taints for Argo CD workloads
 	NodePlacement *ArgoCDNodePlacementSpec `json:"nodePlacement,omitempty"`
 
+	// Notifications defines whether the Argo CD Notifications controller should be installed.
+	Notifications *ArgoCDNotifications `json:"notifications,omitempty"`
+
 	// Prometheus defines the Prometheus server options for ArgoCD.
 	Prometheus ArgoCDPrometheusSpec `json:"prometheus,omitempty"`
 
@@ -587,9 +1472,13 @@ type ArgoCDSpec struct {
 	RepositoryCredentials string `json:"repositoryCredentials,omitempty"`
 
 	// ResourceCustomizations customizes resource behavior. Keys are in the form: group/Kind.
+	// Deprecated: use ResourceOverrides instead, which are validated before being applied.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resource Customizations'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
 	ResourceCustomizations string `json:"resourceCustomizations,omitempty"`
 
+	// ResourceOverrides customizes the health checks, actions and diffing of resources, per group/kind.
+	ResourceOverrides []ResourceOverride `json:"resourceOverrides,omitempty"`
+
 	// ResourceExclusions is used to completely ignore entire classes of resource group/kinds.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resource Exclusions'",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
 	ResourceExclusions string `json:"resourceExclusions,omitempty"`
@@ -685,8 +1574,145 @@ type ArgoCDStatus struct {
 	//+operator-sdk:csv:customresourcedefinitions:type=status,displayName="Server",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
 	Server string `json:"server,omitempty"`
 
+	// Replicas reports the desired and ready replicas of the workloads of the Argo CD components.
+	// +optional
+	// +listType=map
+	// +listMapKey=component
+	Replicas []ArgoCDComponentReplicas `json:"replicas,omitempty"`
+
+	// Shards reports the clusters assigned to each Application Controller shard when the shards are assigned by the operator.
+	// +optional
+	// +listType=map
+	// +listMapKey=shard
+	Shards []ArgoCDShardAssignment `json:"shards,omitempty"`
+
+	// Certificates reports the expiry of the CA and certificates generated by the operator.
+	// +optional
+	// +listType=map
+	// +listMapKey=secretName
+	Certificates []ArgoCDCertificateStatus `json:"certificates,omitempty"`
+
 	// RepoTLSChecksum contains the SHA256 checksum of the latest known state of tls.crt and tls.key in the argocd-repo-server-tls secret.
 	RepoTLSChecksum string `json:"repoTLSChecksum,omitempty"`
+
+	// RedisTLSChecksum contains the SHA256 checksum of the latest known state of tls.crt and tls.key in the argocd-operator-redis-tls secret.
+	RedisTLSChecksum string `json:"redisTLSChecksum,omitempty"`
+
+	// Conditions describe the observed state of the Argo CD instance and its components.
+	// The Available, Progressing, Degraded and ReconcileSucceeded conditions summarize the instance as a whole,
+	// while the conditions ending in Ready report the state of the individual components.
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+const (
+	// ArgoCDConditionAvailable indicates that all of the core Argo CD components are running.
+	ArgoCDConditionAvailable = "Available"
+
+	// ArgoCDConditionProgressing indicates that one or more Argo CD components are being rolled out.
+	ArgoCDConditionProgressing = "Progressing"
+
+	// ArgoCDConditionDegraded indicates that the last reconciliation failed or a component has failed.
+	ArgoCDConditionDegraded = "Degraded"
+
+	// ArgoCDConditionReconcileSucceeded indicates whether the last reconciliation of the ArgoCD completed successfully.
+	ArgoCDConditionReconcileSucceeded = "ReconcileSucceeded"
+
+	// ArgoCDConditionApplicationControllerReady indicates that the Argo CD application controller pods are ready.
+	ArgoCDConditionApplicationControllerReady = "ApplicationControllerReady"
+
+	// ArgoCDConditionDexReady indicates that the Argo CD Dex pods are ready.
+	ArgoCDConditionDexReady = "DexReady"
+
+	// ArgoCDConditionRedisReady indicates that the Argo CD Redis pods are ready.
+	ArgoCDConditionRedisReady = "RedisReady"
+
+	// ArgoCDConditionRepoReady indicates that the Argo CD repo server pods are ready.
+	ArgoCDConditionRepoReady = "RepoReady"
+
+	// ArgoCDConditionServerReady indicates that the Argo CD server pods are ready.
+	ArgoCDConditionServerReady = "ServerReady"
+
+	// ArgoCDConditionResourceCustomizationsValid indicates whether the resource customizations could be applied to the
+	// Argo CD configuration.
+	ArgoCDConditionResourceCustomizationsValid = "ResourceCustomizationsValid"
+
+	// ArgoCDConditionRBACPolicyValid indicates whether the RBAC policy could be applied to the argocd-rbac-cm ConfigMap.
+	ArgoCDConditionRBACPolicyValid = "RBACPolicyValid"
+)
+
+const (
+	// ArgoCDReasonAvailable is the condition reason used when all of the core components are running.
+	ArgoCDReasonAvailable = "Available"
+
+	// ArgoCDReasonComponentsNotReady is the condition reason used when one or more components are not ready.
+	ArgoCDReasonComponentsNotReady = "ComponentsNotReady"
+
+	// ArgoCDReasonComponentFailed is the condition reason used when one or more components have failed.
+	ArgoCDReasonComponentFailed = "ComponentFailed"
+
+	// ArgoCDReasonRolloutComplete is the condition reason used when no components are being rolled out.
+	ArgoCDReasonRolloutComplete = "RolloutComplete"
+
+	// ArgoCDReasonReconcileFailed is the condition reason used when the last reconciliation failed.
+	ArgoCDReasonReconcileFailed = "ReconcileFailed"
+
+	// ArgoCDReasonReconcileSucceeded is the condition reason used when the last reconciliation succeeded.
+	ArgoCDReasonReconcileSucceeded = "ReconcileSucceeded"
+
+	// ArgoCDReasonResourceCustomizationsApplied is the condition reason used when the resource customizations have
+	// been applied.
+	ArgoCDReasonResourceCustomizationsApplied = "ResourceCustomizationsApplied"
+
+	// ArgoCDReasonInvalidResourceCustomizations is the condition reason used when the resource customizations are
+	// invalid and have not been applied.
+	ArgoCDReasonInvalidResourceCustomizations = "InvalidResourceCustomizations"
+
+	// ArgoCDReasonRBACPolicyApplied is the condition reason used when the RBAC policy has been applied.
+	ArgoCDReasonRBACPolicyApplied = "RBACPolicyApplied"
+
+	// ArgoCDReasonInvalidRBACPolicy is the condition reason used when the RBAC policy is invalid and was not applied.
+	ArgoCDReasonInvalidRBACPolicy = "InvalidRBACPolicy"
+)
+
+// ArgoCDComponentReplicas reports the desired and ready replicas of the workload of an Argo CD component.
+type ArgoCDComponentReplicas struct {
+	// Component is the name of the Argo CD component, e.g. server or repo.
+	Component string `json:"component"`
+
+	// Desired is the number of replicas requested for the workload of the component.
+	Desired int32 `json:"desired"`
+
+	// Ready is the number of ready replicas of the workload of the component.
+	Ready int32 `json:"ready"`
+}
+
+// ArgoCDCertificateStatus reports the expiry of a CA or certificate generated by the operator.
+type ArgoCDCertificateStatus struct {
+	// SecretName is the name of the Secret containing the certificate.
+	SecretName string `json:"secretName"`
+
+	// NotAfter is the expiry of the certificate.
+	NotAfter metav1.Time `json:"notAfter"`
+
+	// RenewalTime is when the operator re-issues the certificate. It is not set for certificates not issued by the operator.
+	RenewalTime *metav1.Time `json:"renewalTime,omitempty"`
+}
+
+// ArgoCDShardAssignment reports the clusters assigned to an Application Controller shard.
+type ArgoCDShardAssignment struct {
+	// Shard is the index of the Application Controller shard.
+	Shard int32 `json:"shard"`
+
+	// Clusters are the names of the cluster Secrets assigned to the shard.
+	Clusters []string `json:"clusters,omitempty"`
+
+	// Weight is the sum of the weights of the clusters assigned to the shard.
+	Weight int64 `json:"weight"`
 }
 
 // ArgoCDTLSSpec defines the TLS options for ArgCD.
@@ -694,8 +1720,15 @@ type ArgoCDTLSSpec struct {
 	// CA defines the CA options.
 	CA ArgoCDCASpec `json:"ca,omitempty"`
 
+	// CertManager defines the cert-manager issuer of the Argo CD server, repo server and Redis certificates.
+	// When set, the operator creates cert-manager Certificates instead of self-signing these certificates.
+	CertManager *ArgoCDCertManagerSpec `json:"certManager,omitempty"`
+
 	// InitialCerts defines custom TLS certificates upon creation of the cluster for connecting Git repositories via HTTPS.
 	InitialCerts map[string]string `json:"initialCerts,omitempty"`
+
+	// RenewBefore is how long before the expiry the operator re-issues the CA and certificates it generated. Defaults to 30 days.
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
 }
 
 type SSHHostsSpec struct {
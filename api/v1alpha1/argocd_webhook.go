@@ -0,0 +1,418 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+// argocdlog is for logging in this package.
+var argocdlog = logf.Log.WithName("argocd-resource")
+
+var (
+	// validLogLevels are the log levels accepted by the Argo CD components.
+	validLogLevels = []string{"debug", "info", "warn", "error"}
+
+	// validLogFormats are the log formats accepted by the Argo CD components.
+	validLogFormats = []string{"text", "json"}
+
+	// validSSOProviders are the SSO providers that can be installed by the operator.
+	validSSOProviders = []string{string(SSOProviderTypeKeycloak)}
+
+	// reservedRepoVolumes are the volumes of the repo server Deployment that are managed by the operator.
+	reservedRepoVolumes = []string{"ssh-known-hosts", "tls-certs", "gpg-keys", "gpg-keyring", "argocd-repo-server-tls", "var-files", "plugins"}
+
+	// reservedRepoContainers are the containers of the repo server Deployment that are managed by the operator.
+	reservedRepoContainers = []string{"argocd-repo-server", "copyutil"}
+)
+
+// SetupWebhookWithManager registers the ArgoCD webhooks with the given manager.
+func (r *ArgoCD) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-argoproj-io-v1alpha1-argocd,mutating=false,failurePolicy=fail,sideEffects=None,groups=argoproj.io,resources=argocds,verbs=create;update,versions=v1alpha1,name=vargocd.kb.io,admissionReviewVersions={v1,v1beta1}
+
+var _ webhook.Validator = &ArgoCD{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *ArgoCD) ValidateCreate() error {
+	argocdlog.Info("validate create", "name", r.Name)
+	return r.validate()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *ArgoCD) ValidateUpdate(old runtime.Object) error {
+	argocdlog.Info("validate update", "name", r.Name)
+
+	// Updates that leave the spec unchanged, such as the finalizer updates made by the operator, are not validated,
+	// so that resources accepted before the webhook was installed can still be finalized and deleted.
+	if r.DeletionTimestamp != nil {
+		return nil
+	}
+	if previous, ok := old.(*ArgoCD); ok && reflect.DeepEqual(previous.Spec, r.Spec) {
+		return nil
+	}
+	return r.validate()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *ArgoCD) ValidateDelete() error {
+	return nil
+}
+
+// validate will return an Invalid error listing every problem found in the ArgoCD spec, or nil if there are none.
+func (r *ArgoCD) validate() error {
+	allErrs := r.Spec.validate(field.NewPath("spec"))
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(GroupVersion.WithKind("ArgoCD").GroupKind(), r.Name, allErrs)
+}
+
+// validate will return the list of problems found in the ArgoCD spec.
+func (s *ArgoCDSpec) validate(path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if s.SSO != nil {
+		ssoPath := path.Child("sso")
+		if s.SSO.Provider != SSOProviderTypeKeycloak {
+			allErrs = append(allErrs, field.NotSupported(ssoPath.Child("provider"), s.SSO.Provider, validSSOProviders))
+		}
+		if s.Dex.OpenShiftOAuth || s.Dex.Config != "" {
+			allErrs = append(allErrs, field.Forbidden(ssoPath, "multiple SSO configuration: sso cannot be combined with dex.openShiftOAuth or dex.config"))
+		}
+	}
+
+	controllerPath := path.Child("controller")
+	allErrs = append(allErrs, validateLogLevel(controllerPath.Child("logLevel"), s.Controller.LogLevel)...)
+	allErrs = append(allErrs, validateLogFormat(controllerPath.Child("logFormat"), s.Controller.LogFormat)...)
+	allErrs = append(allErrs, validateSharding(controllerPath.Child("sharding"), s.Controller.Sharding)...)
+	allErrs = append(allErrs, validatePodTemplateOverride(controllerPath.Child("podTemplateOverride"), s.Controller.PodTemplateOverride)...)
+	allErrs = append(allErrs, validatePodDisruptionBudget(controllerPath.Child("pdb"), s.Controller.PDB)...)
+
+	repoPath := path.Child("repo")
+	allErrs = append(allErrs, validateLogLevel(repoPath.Child("logLevel"), s.Repo.LogLevel)...)
+	allErrs = append(allErrs, validateLogFormat(repoPath.Child("logFormat"), s.Repo.LogFormat)...)
+	allErrs = append(allErrs, validateAutoscale(repoPath.Child("autoscale"), s.Repo.Autoscale)...)
+	if s.Repo.Autoscale.Enabled && s.Repo.Replicas != nil {
+		allErrs = append(allErrs, field.Forbidden(repoPath.Child("replicas"), "replicas cannot be set when autoscaling is enabled"))
+	}
+	allErrs = append(allErrs, validatePodTemplateOverride(repoPath.Child("podTemplateOverride"), s.Repo.PodTemplateOverride)...)
+	allErrs = append(allErrs, validatePodDisruptionBudget(repoPath.Child("pdb"), s.Repo.PDB)...)
+	for i, volume := range s.Repo.Volumes {
+		allErrs = append(allErrs, validateReservedName(repoPath.Child("volumes").Index(i).Child("name"), volume.Name, reservedRepoVolumes)...)
+	}
+	for i, container := range s.Repo.InitContainers {
+		allErrs = append(allErrs, validateReservedName(repoPath.Child("initContainers").Index(i).Child("name"), container.Name, reservedRepoContainers)...)
+	}
+	reservedSidecars := append([]string{}, reservedRepoContainers...)
+	for i, plugin := range s.Repo.Plugins {
+		allErrs = append(allErrs, validateReservedName(repoPath.Child("plugins").Index(i).Child("name"), plugin.Name, reservedRepoContainers)...)
+		reservedSidecars = append(reservedSidecars, plugin.Name)
+	}
+	if len(s.Repo.Plugins) > 0 && s.Repo.Image == "" && s.Repo.Version == "" {
+		// The default Argo CD image does not ship the argocd-cmp-server run by the plugin sidecars.
+		allErrs = append(allErrs, field.Forbidden(repoPath.Child("plugins"), "plugins require Argo CD v2.2 or later, set repo.image or repo.version"))
+	}
+	for i, container := range s.Repo.SidecarContainers {
+		allErrs = append(allErrs, validateReservedName(repoPath.Child("sidecarContainers").Index(i).Child("name"), container.Name, reservedSidecars)...)
+	}
+
+	serverPath := path.Child("server")
+	allErrs = append(allErrs, validateLogLevel(serverPath.Child("logLevel"), s.Server.LogLevel)...)
+	allErrs = append(allErrs, validateLogFormat(serverPath.Child("logFormat"), s.Server.LogFormat)...)
+	if s.Server.Autoscale.Enabled && s.Server.Replicas != nil {
+		allErrs = append(allErrs, field.Forbidden(serverPath.Child("replicas"), "replicas cannot be set when autoscaling is enabled"))
+	}
+	allErrs = append(allErrs, validatePodTemplateOverride(serverPath.Child("podTemplateOverride"), s.Server.PodTemplateOverride)...)
+	allErrs = append(allErrs, validatePodDisruptionBudget(serverPath.Child("pdb"), s.Server.PDB)...)
+	allErrs = append(allErrs, validateGateway(serverPath.Child("gateway"), s.Server.Gateway)...)
+	allErrs = append(allErrs, validateGateway(serverPath.Child("grpc", "gateway"), s.Server.GRPC.Gateway)...)
+	if tls := s.Server.GRPC.Gateway.TLS; tls != nil && tls.InsecureRedirect {
+		allErrs = append(allErrs, field.Forbidden(serverPath.Child("grpc", "gateway", "tls", "insecureRedirect"), "GRPCRoutes cannot redirect to HTTPS"))
+	}
+	// The routes forward plain HTTP and h2c, which the Argo CD server only accepts when TLS is disabled on it.
+	if s.Server.Gateway.Enabled && !s.Server.Insecure {
+		allErrs = append(allErrs, field.Forbidden(serverPath.Child("gateway", "enabled"), "gateway routes require server.insecure"))
+	}
+	if s.Server.GRPC.Gateway.Enabled && !s.Server.Insecure {
+		allErrs = append(allErrs, field.Forbidden(serverPath.Child("grpc", "gateway", "enabled"), "gateway routes require server.insecure"))
+	}
+	allErrs = append(allErrs, validateGateway(path.Child("grafana", "gateway"), s.Grafana.Gateway)...)
+	allErrs = append(allErrs, validateGateway(path.Child("prometheus", "gateway"), s.Prometheus.Gateway)...)
+
+	if s.ApplicationSet != nil {
+		allErrs = append(allErrs, validateLogLevel(path.Child("applicationSet", "logLevel"), s.ApplicationSet.LogLevel)...)
+		allErrs = append(allErrs, validateAutoscale(path.Child("applicationSet", "autoscale"), s.ApplicationSet.Autoscale)...)
+		allErrs = append(allErrs, validatePodTemplateOverride(path.Child("applicationSet", "podTemplateOverride"), s.ApplicationSet.PodTemplateOverride)...)
+		allErrs = append(allErrs, validatePodDisruptionBudget(path.Child("applicationSet", "pdb"), s.ApplicationSet.PDB)...)
+	}
+
+	allErrs = append(allErrs, validatePodTemplateOverride(path.Child("dex", "podTemplateOverride"), s.Dex.PodTemplateOverride)...)
+	allErrs = append(allErrs, validatePodDisruptionBudget(path.Child("dex", "pdb"), s.Dex.PDB)...)
+	allErrs = append(allErrs, validatePodTemplateOverride(path.Child("grafana", "podTemplateOverride"), s.Grafana.PodTemplateOverride)...)
+	allErrs = append(allErrs, validatePodDisruptionBudget(path.Child("grafana", "pdb"), s.Grafana.PDB)...)
+	allErrs = append(allErrs, validatePodTemplateOverride(path.Child("ha", "podTemplateOverride"), s.HA.PodTemplateOverride)...)
+	allErrs = append(allErrs, validatePodDisruptionBudget(path.Child("ha", "pdb"), s.HA.PDB)...)
+	allErrs = append(allErrs, validateRedisHA(path.Child("ha"), s.HA)...)
+	allErrs = append(allErrs, validatePodTemplateOverride(path.Child("redis", "podTemplateOverride"), s.Redis.PodTemplateOverride)...)
+	allErrs = append(allErrs, validatePodDisruptionBudget(path.Child("redis", "pdb"), s.Redis.PDB)...)
+	if s.Redis.External != nil {
+		allErrs = append(allErrs, validateExternalRedis(path, s)...)
+	} else if s.Redis.TLSEnabled {
+		allErrs = append(allErrs, validateRedisTLSClients(path.Child("redis", "tlsEnabled"), s)...)
+		if s.Redis.Image == "" && s.Redis.Version == "" {
+			allErrs = append(allErrs, field.Forbidden(path.Child("redis", "tlsEnabled"), "TLS requires Redis 6 or later, set redis.image or redis.version"))
+		}
+	}
+
+	if s.Notifications != nil {
+		allErrs = append(allErrs, validatePodTemplateOverride(path.Child("notifications", "podTemplateOverride"), s.Notifications.PodTemplateOverride)...)
+		allErrs = append(allErrs, validatePodDisruptionBudget(path.Child("notifications", "pdb"), s.Notifications.PDB)...)
+	}
+
+	for i, user := range s.LocalUsers {
+		userPath := path.Child("localUsers").Index(i)
+		if user.Name == "admin" {
+			allErrs = append(allErrs, field.Invalid(userPath.Child("name"), user.Name, "admin is reserved for the built-in administrator"))
+		}
+		if user.TokenLifetime != nil && user.TokenLifetime.Duration < time.Minute {
+			allErrs = append(allErrs, field.Invalid(userPath.Child("tokenLifetime"), user.TokenLifetime.Duration.String(), "must be at least one minute"))
+		}
+	}
+
+	if s.TLS.RenewBefore != nil && (s.TLS.RenewBefore.Duration <= 0 || s.TLS.RenewBefore.Duration >= common.ArgoCDDuration365Days) {
+		allErrs = append(allErrs, field.Invalid(path.Child("tls", "renewBefore"), s.TLS.RenewBefore.Duration.String(), "must be greater than zero and less than the 365 days lifetime of the certificates"))
+	}
+
+	if s.TLS.CertManager != nil {
+		allErrs = append(allErrs, validateCertManager(path.Child("tls", "certManager"), s.TLS.CertManager)...)
+		if s.Repo.WantsAutoTLS() {
+			allErrs = append(allErrs, field.Forbidden(repoPath.Child("autotls"), "autotls cannot be combined with tls.certManager"))
+		}
+		if s.Server.WantsAutoTLS() {
+			allErrs = append(allErrs, field.Forbidden(serverPath.Child("route", "tls", "termination"), "reencrypt termination cannot be combined with tls.certManager"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateCertManager will return an error if the cert-manager issuer is missing or the renewal window is inconsistent.
+func validateCertManager(path *field.Path, certManager *ArgoCDCertManagerSpec) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if certManager.IssuerRef.Name == "" {
+		allErrs = append(allErrs, field.Required(path.Child("issuerRef", "name"), "the issuer of the certificates must be set"))
+	}
+	if certManager.Duration != nil && certManager.RenewBefore != nil && certManager.RenewBefore.Duration >= certManager.Duration.Duration {
+		allErrs = append(allErrs, field.Invalid(path.Child("renewBefore"), certManager.RenewBefore.Duration.String(), fmt.Sprintf("must be less than duration (%s)", certManager.Duration.Duration)))
+	}
+	return allErrs
+}
+
+// validateGateway will return an error if the Gateway of the enabled routes of a component is missing.
+func validateGateway(path *field.Path, gateway ArgoCDGatewaySpec) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if gateway.Enabled && gateway.ParentRef.Name == "" {
+		allErrs = append(allErrs, field.Required(path.Child("parentRef", "name"), "the Gateway of the routes must be set"))
+	}
+	if gateway.TLS != nil && gateway.TLS.SectionName == "" {
+		allErrs = append(allErrs, field.Required(path.Child("tls", "sectionName"), "the HTTPS listener of the Gateway must be set"))
+	}
+	return allErrs
+}
+
+// validateRedisHA will return an error if the Sentinel quorum cannot be reached by the Redis HA pods or the Sentinel
+// timeouts are not positive.
+func validateRedisHA(path *field.Path, ha ArgoCDHASpec) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	replicas := common.ArgoCDDefaultRedisHAReplicas
+	if ha.RedisReplicas != nil {
+		replicas = *ha.RedisReplicas
+	}
+	if ha.SentinelQuorum != nil {
+		if *ha.SentinelQuorum > replicas {
+			allErrs = append(allErrs, field.Invalid(path.Child("sentinelQuorum"), *ha.SentinelQuorum, fmt.Sprintf("must be less than or equal to redisReplicas (%d)", replicas)))
+		}
+	} else if common.ArgoCDDefaultRedisSentinelQuorum > replicas {
+		// The Sentinels can never agree on a failover when there are fewer of them than the default quorum.
+		allErrs = append(allErrs, field.Invalid(path.Child("redisReplicas"), replicas, fmt.Sprintf("must be greater than or equal to the default sentinelQuorum (%d), or sentinelQuorum must be set", common.ArgoCDDefaultRedisSentinelQuorum)))
+	}
+	if ha.SentinelDownAfter != nil && ha.SentinelDownAfter.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("sentinelDownAfter"), ha.SentinelDownAfter.Duration.String(), "must be greater than zero"))
+	}
+	if ha.SentinelFailoverTimeout != nil && ha.SentinelFailoverTimeout.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("sentinelFailoverTimeout"), ha.SentinelFailoverTimeout.Duration.String(), "must be greater than zero"))
+	}
+	return allErrs
+}
+
+// validateRedisTLSClients will return an error if the Argo CD components connecting to Redis with TLS use the default
+// Argo CD images of the operator, which do not support TLS connections to Redis.
+func validateRedisTLSClients(path *field.Path, s *ArgoCDSpec) field.ErrorList {
+	if (s.Image != "" || s.Version != "") && (s.Repo.Image != "" || s.Repo.Version != "") {
+		return nil
+	}
+	return field.ErrorList{field.Forbidden(path, "TLS connections to Redis require Argo CD v2.3 or later, set image or version, and repo.image or repo.version")}
+}
+
+// validateExternalRedis will return an error if the external Redis options are incomplete or combined with options
+// of the Redis managed by the operator.
+func validateExternalRedis(path *field.Path, s *ArgoCDSpec) field.ErrorList {
+	allErrs := field.ErrorList{}
+	external := s.Redis.External
+	redisPath := path.Child("redis")
+	externalPath := redisPath.Child("external")
+
+	if external.Address == "" {
+		allErrs = append(allErrs, field.Required(externalPath.Child("address"), "the address of the external Redis must be set"))
+	}
+	if external.CASecretRef != nil && !external.TLSEnabled {
+		allErrs = append(allErrs, field.Forbidden(externalPath.Child("caSecretRef"), "caSecretRef requires tlsEnabled"))
+	}
+	if external.TLSEnabled {
+		allErrs = append(allErrs, validateRedisTLSClients(externalPath.Child("tlsEnabled"), s)...)
+	}
+	if s.Redis.AuthEnabled {
+		allErrs = append(allErrs, field.Forbidden(redisPath.Child("authEnabled"), "authEnabled cannot be combined with an external Redis, use external.passwordSecretRef instead"))
+	}
+	if s.Redis.TLSEnabled {
+		allErrs = append(allErrs, field.Forbidden(redisPath.Child("tlsEnabled"), "tlsEnabled cannot be combined with an external Redis, use external.tlsEnabled instead"))
+	}
+	if s.HA.Enabled {
+		allErrs = append(allErrs, field.Forbidden(path.Child("ha", "enabled"), "HA cannot be combined with an external Redis"))
+	}
+	return allErrs
+}
+
+// validateSharding will return an error if the Application Controller sharding options are inconsistent.
+func validateSharding(path *field.Path, sharding ArgoCDApplicationControllerShardSpec) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if sharding.DynamicScalingEnabled {
+		minShards := sharding.MinShards
+		if minShards < 1 {
+			minShards = common.ArgoCDDefaultMinShards
+		}
+		if sharding.MaxShards > 0 && sharding.MaxShards < minShards {
+			allErrs = append(allErrs, field.Invalid(path.Child("maxShards"), sharding.MaxShards, fmt.Sprintf("must be greater than or equal to minShards (%d)", minShards)))
+		}
+	} else if sharding.Enabled && sharding.Replicas < 1 {
+		allErrs = append(allErrs, field.Invalid(path.Child("replicas"), sharding.Replicas, "must be greater than zero when sharding is enabled"))
+	}
+
+	if sharding.BalancedAssignment && !sharding.Enabled && !sharding.DynamicScalingEnabled {
+		allErrs = append(allErrs, field.Forbidden(path.Child("balancedAssignment"), "balanced assignment requires sharding or dynamic scaling to be enabled"))
+	}
+
+	return allErrs
+}
+
+// validateLogLevel will return an error if the given log level is set and not supported by Argo CD.
+func validateLogLevel(path *field.Path, level string) field.ErrorList {
+	if level == "" || containsFold(validLogLevels, level) {
+		return nil
+	}
+	return field.ErrorList{field.NotSupported(path, level, validLogLevels)}
+}
+
+// validateLogFormat will return an error if the given log format is set and not supported by Argo CD.
+func validateLogFormat(path *field.Path, format string) field.ErrorList {
+	if format == "" || containsFold(validLogFormats, format) {
+		return nil
+	}
+	return field.ErrorList{field.NotSupported(path, format, validLogFormats)}
+}
+
+// validateReservedName will return an error if the given name is reserved for a resource managed by the operator.
+func validateReservedName(path *field.Path, name string, reserved []string) field.ErrorList {
+	for _, r := range reserved {
+		if name == r {
+			return field.ErrorList{field.Invalid(path, name, "name is reserved by the operator")}
+		}
+	}
+	return nil
+}
+
+// validatePodTemplateOverride will return an error if the given override cannot be applied to a pod template.
+func validatePodTemplateOverride(path *field.Path, override *runtime.RawExtension) field.ErrorList {
+	if override == nil || len(override.Raw) == 0 {
+		return nil
+	}
+	patched, err := strategicpatch.StrategicMergePatch([]byte("{}"), override.Raw, corev1.PodTemplateSpec{})
+	if err == nil {
+		err = json.Unmarshal(patched, &corev1.PodTemplateSpec{})
+	}
+	if err != nil {
+		return field.ErrorList{field.Invalid(path, string(override.Raw), err.Error())}
+	}
+	return nil
+}
+
+// validateAutoscale will return an error if the minimum number of replicas exceeds the maximum.
+func validateAutoscale(path *field.Path, autoscale ArgoCDAutoscaleSpec) field.ErrorList {
+	if autoscale.MinReplicas == nil {
+		return nil
+	}
+	maxReplicas := common.ArgoCDDefaultAutoscaleMaxReplicas
+	if autoscale.MaxReplicas > 0 {
+		maxReplicas = autoscale.MaxReplicas
+	}
+	if *autoscale.MinReplicas > maxReplicas {
+		return field.ErrorList{field.Invalid(path.Child("minReplicas"), *autoscale.MinReplicas, fmt.Sprintf("must not be greater than maxReplicas (%d)", maxReplicas))}
+	}
+	return nil
+}
+
+// validatePodDisruptionBudget will return an error if both minAvailable and maxUnavailable are set.
+func validatePodDisruptionBudget(path *field.Path, pdb *ArgoCDPodDisruptionBudgetSpec) field.ErrorList {
+	if pdb == nil || pdb.MinAvailable == nil || pdb.MaxUnavailable == nil {
+		return nil
+	}
+	return field.ErrorList{field.Forbidden(path.Child("maxUnavailable"), "minAvailable and maxUnavailable cannot be both set")}
+}
+
+// containsFold returns true if the given value is in the list, ignoring case.
+func containsFold(list []string, value string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,458 @@
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func int32Ptr(val int32) *int32 {
+	return &val
+}
+
+func intstrPtr(val intstr.IntOrString) *intstr.IntOrString {
+	return &val
+}
+
+func makeTestWebhookArgoCD(spec ArgoCDSpec) *ArgoCD {
+	return &ArgoCD{
+		ObjectMeta: metav1.ObjectMeta{Name: "argocd", Namespace: "argocd"},
+		Spec:       spec,
+	}
+}
+
+func Test_ArgoCD_ValidateCreate(t *testing.T) {
+	tests := []struct {
+		name   string
+		spec   ArgoCDSpec
+		fields []string
+	}{
+		{
+			name: "empty spec",
+			spec: ArgoCDSpec{},
+		},
+		{
+			name: "valid log levels and formats",
+			spec: ArgoCDSpec{
+				Server:     ArgoCDServerSpec{LogLevel: "debug", LogFormat: "json"},
+				Repo:       ArgoCDRepoSpec{LogLevel: "Warn", LogFormat: "text"},
+				Controller: ArgoCDApplicationControllerSpec{LogLevel: "error"},
+			},
+		},
+		{
+			name: "keycloak and dex both configured",
+			spec: ArgoCDSpec{
+				SSO: &ArgoCDSSOSpec{Provider: SSOProviderTypeKeycloak},
+				Dex: ArgoCDDexSpec{OpenShiftOAuth: true},
+			},
+			fields: []string{"spec.sso"},
+		},
+		{
+			name: "unsupported sso provider",
+			spec: ArgoCDSpec{
+				SSO: &ArgoCDSSOSpec{Provider: "okta"},
+			},
+			fields: []string{"spec.sso.provider"},
+		},
+		{
+			name: "invalid log levels and formats",
+			spec: ArgoCDSpec{
+				Server:         ArgoCDServerSpec{LogLevel: "verbose"},
+				Repo:           ArgoCDRepoSpec{LogFormat: "xml"},
+				ApplicationSet: &ArgoCDApplicationSet{LogLevel: "trace"},
+			},
+			fields: []string{"spec.repo.logFormat", "spec.server.logLevel", "spec.applicationSet.logLevel"},
+		},
+		{
+			name: "sharding enabled without replicas",
+			spec: ArgoCDSpec{
+				Controller: ArgoCDApplicationControllerSpec{
+					Sharding: ArgoCDApplicationControllerShardSpec{Enabled: true},
+				},
+			},
+			fields: []string{"spec.controller.sharding.replicas"},
+		},
+		{
+			name: "dynamic sharding without replicas",
+			spec: ArgoCDSpec{
+				Controller: ArgoCDApplicationControllerSpec{
+					Sharding: ArgoCDApplicationControllerShardSpec{Enabled: true, DynamicScalingEnabled: true, MinShards: 2, MaxShards: 4},
+				},
+			},
+		},
+		{
+			name: "dynamic sharding with maxShards below minShards",
+			spec: ArgoCDSpec{
+				Controller: ArgoCDApplicationControllerSpec{
+					Sharding: ArgoCDApplicationControllerShardSpec{DynamicScalingEnabled: true, MinShards: 3, MaxShards: 2},
+				},
+			},
+			fields: []string{"spec.controller.sharding.maxShards"},
+		},
+		{
+			name: "balanced shard assignment without sharding",
+			spec: ArgoCDSpec{
+				Controller: ArgoCDApplicationControllerSpec{
+					Sharding: ArgoCDApplicationControllerShardSpec{BalancedAssignment: true},
+				},
+			},
+			fields: []string{"spec.controller.sharding.balancedAssignment"},
+		},
+		{
+			name: "reserved local user name and short token lifetime",
+			spec: ArgoCDSpec{
+				LocalUsers: []LocalUserSpec{
+					{Name: "admin"},
+					{Name: "ci-bot", TokenLifetime: &metav1.Duration{Duration: time.Second}},
+				},
+			},
+			fields: []string{"spec.localUsers[0].name", "spec.localUsers[1].tokenLifetime"},
+		},
+		{
+			name: "valid pod template override",
+			spec: ArgoCDSpec{
+				Repo: ArgoCDRepoSpec{
+					PodTemplateOverride: &runtime.RawExtension{Raw: []byte(`{"spec":{"hostAliases":[{"ip":"10.0.0.1","hostnames":["git.example.com"]}]}}`)},
+				},
+			},
+		},
+		{
+			name: "repo volumes and sidecars with reserved names",
+			spec: ArgoCDSpec{
+				Repo: ArgoCDRepoSpec{
+					Volumes:           []corev1.Volume{{Name: "sops"}, {Name: "gpg-keys"}},
+					SidecarContainers: []corev1.Container{{Name: "argocd-repo-server"}},
+				},
+			},
+			fields: []string{"spec.repo.volumes[1].name", "spec.repo.sidecarContainers[0].name"},
+		},
+		{
+			name: "repo plugin names colliding with operator and sidecar containers",
+			spec: ArgoCDSpec{
+				Repo: ArgoCDRepoSpec{
+					Version:           "v2.2.5",
+					Plugins:           []ArgoCDRepoPluginSpec{{Name: "copyutil"}, {Name: "sops"}},
+					SidecarContainers: []corev1.Container{{Name: "sops"}},
+				},
+			},
+			fields: []string{"spec.repo.plugins[0].name", "spec.repo.sidecarContainers[0].name"},
+		},
+		{
+			name: "repo plugins with the default Argo CD image",
+			spec: ArgoCDSpec{
+				Repo: ArgoCDRepoSpec{
+					Plugins: []ArgoCDRepoPluginSpec{{Name: "sops"}},
+				},
+			},
+			fields: []string{"spec.repo.plugins"},
+		},
+		{
+			name: "invalid pod template overrides",
+			spec: ArgoCDSpec{
+				Server: ArgoCDServerSpec{
+					PodTemplateOverride: &runtime.RawExtension{Raw: []byte(`{"spec":{"containers":"argocd-server"}}`)},
+				},
+				Redis: ArgoCDRedisSpec{
+					PodTemplateOverride: &runtime.RawExtension{Raw: []byte(`["spec"]`)},
+				},
+			},
+			fields: []string{"spec.server.podTemplateOverride", "spec.redis.podTemplateOverride"},
+		},
+		{
+			name: "pod disruption budget with both minAvailable and maxUnavailable",
+			spec: ArgoCDSpec{
+				Server: ArgoCDServerSpec{
+					PDB: &ArgoCDPodDisruptionBudgetSpec{MinAvailable: intstrPtr(intstr.FromInt(1)), MaxUnavailable: intstrPtr(intstr.FromString("50%"))},
+				},
+				Redis: ArgoCDRedisSpec{
+					PDB: &ArgoCDPodDisruptionBudgetSpec{MinAvailable: intstrPtr(intstr.FromInt(2))},
+				},
+			},
+			fields: []string{"spec.server.pdb.maxUnavailable"},
+		},
+		{
+			name: "autoscale minReplicas greater than maxReplicas",
+			spec: ArgoCDSpec{
+				Repo: ArgoCDRepoSpec{
+					Autoscale: ArgoCDAutoscaleSpec{Enabled: true, MinReplicas: int32Ptr(4)},
+				},
+				ApplicationSet: &ArgoCDApplicationSet{
+					Autoscale: ArgoCDAutoscaleSpec{Enabled: true, MinReplicas: int32Ptr(4), MaxReplicas: 5},
+				},
+			},
+			fields: []string{"spec.repo.autoscale.minReplicas"},
+		},
+		{
+			name: "replicas with autoscaling enabled",
+			spec: ArgoCDSpec{
+				Repo: ArgoCDRepoSpec{
+					Autoscale: ArgoCDAutoscaleSpec{Enabled: true},
+					Replicas:  int32Ptr(2),
+				},
+				Server: ArgoCDServerSpec{
+					Autoscale: ArgoCDServerAutoscaleSpec{Enabled: true},
+					Replicas:  int32Ptr(2),
+				},
+				Dex: ArgoCDDexSpec{Replicas: int32Ptr(2)},
+			},
+			fields: []string{"spec.repo.replicas", "spec.server.replicas"},
+		},
+		{
+			name: "cert-manager without issuer and with renewBefore exceeding duration",
+			spec: ArgoCDSpec{
+				TLS: ArgoCDTLSSpec{CertManager: &ArgoCDCertManagerSpec{
+					Duration:    &metav1.Duration{Duration: 24 * time.Hour},
+					RenewBefore: &metav1.Duration{Duration: 48 * time.Hour},
+				}},
+			},
+			fields: []string{"spec.tls.certManager.issuerRef.name", "spec.tls.certManager.renewBefore"},
+		},
+		{
+			name: "certificate renewal window exceeding the certificate lifetime",
+			spec: ArgoCDSpec{
+				TLS: ArgoCDTLSSpec{RenewBefore: &metav1.Duration{Duration: 400 * 24 * time.Hour}},
+			},
+			fields: []string{"spec.tls.renewBefore"},
+		},
+		{
+			name: "cert-manager combined with repo server autotls",
+			spec: ArgoCDSpec{
+				Repo: ArgoCDRepoSpec{AutoTLS: "openshift"},
+				TLS: ArgoCDTLSSpec{CertManager: &ArgoCDCertManagerSpec{
+					IssuerRef: ArgoCDCertManagerIssuerRef{Name: "corporate-ca", Kind: "ClusterIssuer"},
+				}},
+			},
+			fields: []string{"spec.repo.autotls"},
+		},
+		{
+			name: "valid gateway routes",
+			spec: ArgoCDSpec{
+				Server: ArgoCDServerSpec{
+					Insecure: true,
+					Gateway: ArgoCDGatewaySpec{
+						Enabled:   true,
+						ParentRef: ArgoCDGatewayParentRef{Name: "shared", Namespace: "gateways", SectionName: "http"},
+						TLS:       &ArgoCDGatewayTLSSpec{SectionName: "https", InsecureRedirect: true},
+					},
+					GRPC: ArgoCDServerGRPCSpec{Gateway: ArgoCDGatewaySpec{Enabled: true, ParentRef: ArgoCDGatewayParentRef{Name: "shared"}}},
+				},
+			},
+		},
+		{
+			name: "gateway routes without gateway or HTTPS listener",
+			spec: ArgoCDSpec{
+				Server: ArgoCDServerSpec{
+					Insecure: true,
+					GRPC: ArgoCDServerGRPCSpec{Gateway: ArgoCDGatewaySpec{
+						Enabled:   true,
+						ParentRef: ArgoCDGatewayParentRef{Name: "shared"},
+						TLS:       &ArgoCDGatewayTLSSpec{SectionName: "https", InsecureRedirect: true},
+					}},
+				},
+				Grafana: ArgoCDGrafanaSpec{Gateway: ArgoCDGatewaySpec{Enabled: true, TLS: &ArgoCDGatewayTLSSpec{}}},
+			},
+			fields: []string{"spec.server.grpc.gateway.tls.insecureRedirect", "spec.grafana.gateway.parentRef.name", "spec.grafana.gateway.tls.sectionName"},
+		},
+		{
+			name: "gateway routes to a TLS server",
+			spec: ArgoCDSpec{
+				Server: ArgoCDServerSpec{
+					Gateway: ArgoCDGatewaySpec{Enabled: true, ParentRef: ArgoCDGatewayParentRef{Name: "shared"}},
+					GRPC:    ArgoCDServerGRPCSpec{Gateway: ArgoCDGatewaySpec{Enabled: true, ParentRef: ArgoCDGatewayParentRef{Name: "shared"}}},
+				},
+			},
+			fields: []string{"spec.server.gateway.enabled", "spec.server.grpc.gateway.enabled"},
+		},
+		{
+			name: "valid redis HA topology",
+			spec: ArgoCDSpec{
+				HA: ArgoCDHASpec{Enabled: true, RedisReplicas: int32Ptr(5), SentinelQuorum: int32Ptr(3)},
+			},
+		},
+		{
+			name: "sentinel quorum exceeding the redis replicas and non-positive sentinel timeouts",
+			spec: ArgoCDSpec{
+				HA: ArgoCDHASpec{
+					Enabled:                 true,
+					SentinelQuorum:          int32Ptr(4),
+					SentinelDownAfter:       &metav1.Duration{},
+					SentinelFailoverTimeout: &metav1.Duration{Duration: -time.Second},
+				},
+			},
+			fields: []string{"spec.ha.sentinelQuorum", "spec.ha.sentinelDownAfter", "spec.ha.sentinelFailoverTimeout"},
+		},
+		{
+			name: "redis replicas below the default sentinel quorum",
+			spec: ArgoCDSpec{
+				HA: ArgoCDHASpec{Enabled: true, RedisReplicas: int32Ptr(1)},
+			},
+			fields: []string{"spec.ha.redisReplicas"},
+		},
+		{
+			name: "single redis replica with a matching sentinel quorum",
+			spec: ArgoCDSpec{
+				HA: ArgoCDHASpec{Enabled: true, RedisReplicas: int32Ptr(1), SentinelQuorum: int32Ptr(1)},
+			},
+		},
+		{
+			name: "redis tls with the default images",
+			spec: ArgoCDSpec{
+				Version: "v2.3.3",
+				Redis:   ArgoCDRedisSpec{TLSEnabled: true},
+			},
+			fields: []string{"spec.redis.tlsEnabled", "spec.redis.tlsEnabled"},
+		},
+		{
+			name: "redis tls with supported images",
+			spec: ArgoCDSpec{
+				Version: "v2.3.3",
+				Repo:    ArgoCDRepoSpec{Version: "v2.3.3"},
+				Redis:   ArgoCDRedisSpec{TLSEnabled: true, Version: "6.2.6"},
+			},
+		},
+		{
+			name: "valid external redis",
+			spec: ArgoCDSpec{
+				Version: "v2.3.3",
+				Repo:    ArgoCDRepoSpec{Version: "v2.3.3"},
+				Redis: ArgoCDRedisSpec{External: &ArgoCDRedisExternalSpec{
+					Address:            "redis-sentinel.cache:26379",
+					SentinelMasterName: "argocd",
+					TLSEnabled:         true,
+					CASecretRef:        &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "redis-ca"}, Key: "ca.crt"},
+				}},
+			},
+		},
+		{
+			name: "external redis without address and combined with managed redis options",
+			spec: ArgoCDSpec{
+				HA: ArgoCDHASpec{Enabled: true},
+				Redis: ArgoCDRedisSpec{
+					AuthEnabled: true,
+					TLSEnabled:  true,
+					External: &ArgoCDRedisExternalSpec{
+						CASecretRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "redis-ca"}, Key: "ca.crt"},
+					},
+				},
+			},
+			fields: []string{"spec.redis.external.address", "spec.redis.external.caSecretRef", "spec.redis.authEnabled", "spec.redis.tlsEnabled", "spec.ha.enabled"},
+		},
+		{
+			name: "external redis tls with the default images",
+			spec: ArgoCDSpec{
+				Repo: ArgoCDRepoSpec{Version: "v2.3.3"},
+				Redis: ArgoCDRedisSpec{External: &ArgoCDRedisExternalSpec{
+					Address:    "redis.cache:6379",
+					TLSEnabled: true,
+				}},
+			},
+			fields: []string{"spec.redis.external.tlsEnabled"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := makeTestWebhookArgoCD(test.spec).ValidateCreate()
+			if len(test.fields) == 0 {
+				assert.NilError(t, err)
+				return
+			}
+
+			assert.Assert(t, apierrors.IsInvalid(err))
+			causes := err.(*apierrors.StatusError).ErrStatus.Details.Causes
+			assert.Equal(t, len(causes), len(test.fields))
+			for i, field := range test.fields {
+				assert.Equal(t, causes[i].Field, field)
+			}
+		})
+	}
+}
+
+func Test_ArgoCD_ValidateUpdate(t *testing.T) {
+	old := makeTestWebhookArgoCD(ArgoCDSpec{
+		Server: ArgoCDServerSpec{LogLevel: "trace"},
+	})
+
+	// Updates leaving an invalid spec unchanged, such as finalizer updates, are accepted.
+	argocd := old.DeepCopy()
+	argocd.Finalizers = []string{"argoproj.io/finalizer"}
+	assert.NilError(t, argocd.ValidateUpdate(old))
+
+	// Resources being deleted are accepted.
+	argocd = old.DeepCopy()
+	argocd.Spec.Server.Replicas = int32Ptr(2)
+	now := metav1.Now()
+	argocd.DeletionTimestamp = &now
+	assert.NilError(t, argocd.ValidateUpdate(old))
+
+	// Spec changes are validated.
+	argocd.DeletionTimestamp = nil
+	err := argocd.ValidateUpdate(old)
+	assert.Assert(t, apierrors.IsInvalid(err))
+}
+
+func Test_ArgoCDExport_ValidateCreate(t *testing.T) {
+	schedule := func(s string) *string { return &s }
+
+	tests := []struct {
+		name   string
+		spec   ArgoCDExportSpec
+		fields []string
+	}{
+		{
+			name: "valid schedule",
+			spec: ArgoCDExportSpec{Argocd: "argocd", Schedule: schedule("*/15 0-6 * JAN-MAR mon,fri")},
+		},
+		{
+			name: "valid descriptor",
+			spec: ArgoCDExportSpec{Argocd: "argocd", Schedule: schedule("@daily")},
+		},
+		{
+			name:   "missing argocd",
+			spec:   ArgoCDExportSpec{},
+			fields: []string{"spec.argocd"},
+		},
+		{
+			name:   "too few schedule fields",
+			spec:   ArgoCDExportSpec{Argocd: "argocd", Schedule: schedule("0 0 * *")},
+			fields: []string{"spec.schedule"},
+		},
+		{
+			name:   "schedule value out of range",
+			spec:   ArgoCDExportSpec{Argocd: "argocd", Schedule: schedule("0 25 * * *")},
+			fields: []string{"spec.schedule"},
+		},
+		{
+			name:   "unsupported storage backend",
+			spec:   ArgoCDExportSpec{Argocd: "argocd", Storage: &ArgoCDExportStorageSpec{Backend: "s3"}},
+			fields: []string{"spec.storage.backend"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			export := &ArgoCDExport{
+				ObjectMeta: metav1.ObjectMeta{Name: "export", Namespace: "argocd"},
+				Spec:       test.spec,
+			}
+			err := export.ValidateCreate()
+			if len(test.fields) == 0 {
+				assert.NilError(t, err)
+				return
+			}
+
+			assert.Assert(t, apierrors.IsInvalid(err))
+			causes := err.(*apierrors.StatusError).ErrStatus.Details.Causes
+			assert.Equal(t, len(causes), len(test.fields))
+			for i, field := range test.fields {
+				assert.Equal(t, causes[i].Field, field)
+			}
+		})
+	}
+}
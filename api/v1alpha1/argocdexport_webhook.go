@@ -0,0 +1,201 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+// validExportStorageBackends are the storage backends supported for an ArgoCDExport.
+var validExportStorageBackends = []string{
+	common.ArgoCDExportStorageBackendLocal,
+	common.ArgoCDExportStorageBackendAWS,
+	common.ArgoCDExportStorageBackendAzure,
+	common.ArgoCDExportStorageBackendGCP,
+}
+
+// cronField describes the range and names allowed for a single field of a cron schedule.
+type cronField struct {
+	name  string
+	min   int
+	max   int
+	names []string
+}
+
+// cronFields are the five fields of a standard cron schedule, as accepted by the CronJob controller.
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}},
+	{name: "day of week", min: 0, max: 6, names: []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}},
+}
+
+// cronDescriptors are the predefined schedules accepted in place of the five cron fields.
+var cronDescriptors = []string{"@yearly", "@annually", "@monthly", "@weekly", "@daily", "@midnight", "@hourly"}
+
+// SetupWebhookWithManager registers the ArgoCDExport webhooks with the given manager.
+func (r *ArgoCDExport) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-argoproj-io-v1alpha1-argocdexport,mutating=false,failurePolicy=fail,sideEffects=None,groups=argoproj.io,resources=argocdexports,verbs=create;update,versions=v1alpha1,name=vargocdexport.kb.io,admissionReviewVersions={v1,v1beta1}
+
+var _ webhook.Validator = &ArgoCDExport{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *ArgoCDExport) ValidateCreate() error {
+	argocdlog.Info("validate create", "name", r.Name)
+	return r.validate()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *ArgoCDExport) ValidateUpdate(old runtime.Object) error {
+	argocdlog.Info("validate update", "name", r.Name)
+
+	// Updates that leave the spec unchanged, such as the finalizer updates made by the operator, are not validated,
+	// so that resources accepted before the webhook was installed can still be finalized and deleted.
+	if r.DeletionTimestamp != nil {
+		return nil
+	}
+	if previous, ok := old.(*ArgoCDExport); ok && reflect.DeepEqual(previous.Spec, r.Spec) {
+		return nil
+	}
+	return r.validate()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *ArgoCDExport) ValidateDelete() error {
+	return nil
+}
+
+// validate will return an Invalid error listing every problem found in the ArgoCDExport spec, or nil if there are none.
+func (r *ArgoCDExport) validate() error {
+	allErrs := field.ErrorList{}
+	path := field.NewPath("spec")
+
+	if r.Spec.Argocd == "" {
+		allErrs = append(allErrs, field.Required(path.Child("argocd"), "the name of the ArgoCD to export is required"))
+	}
+
+	if r.Spec.Schedule != nil && len(*r.Spec.Schedule) > 0 {
+		if err := validateCronSchedule(*r.Spec.Schedule); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("schedule"), *r.Spec.Schedule, err.Error()))
+		}
+	}
+
+	if r.Spec.Storage != nil && r.Spec.Storage.Backend != "" {
+		if !containsFold(validExportStorageBackends, r.Spec.Storage.Backend) {
+			allErrs = append(allErrs, field.NotSupported(path.Child("storage", "backend"), r.Spec.Storage.Backend, validExportStorageBackends))
+		}
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(GroupVersion.WithKind("ArgoCDExport").GroupKind(), r.Name, allErrs)
+}
+
+// validateCronSchedule will return an error if the given schedule is not a valid standard cron expression.
+func validateCronSchedule(schedule string) error {
+	schedule = strings.TrimSpace(schedule)
+	if strings.HasPrefix(schedule, "@") {
+		if strings.HasPrefix(schedule, "@every ") {
+			return nil
+		}
+		if containsFold(cronDescriptors, schedule) {
+			return nil
+		}
+		return fmt.Errorf("unrecognized descriptor %q", schedule)
+	}
+
+	fields := strings.Fields(schedule)
+	if len(fields) != len(cronFields) {
+		return fmt.Errorf("expected %d fields, found %d", len(cronFields), len(fields))
+	}
+
+	for i, f := range fields {
+		if err := cronFields[i].validate(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validate will return an error if the given value is not valid for the cron field.
+func (c cronField) validate(value string) error {
+	for _, expr := range strings.Split(value, ",") {
+		rangeExpr, step := expr, ""
+		if i := strings.Index(expr, "/"); i >= 0 {
+			rangeExpr, step = expr[:i], expr[i+1:]
+			if n, err := strconv.Atoi(step); err != nil || n < 1 {
+				return fmt.Errorf("invalid step %q in %s field", step, c.name)
+			}
+		}
+
+		if rangeExpr == "*" || rangeExpr == "?" {
+			continue
+		}
+
+		bounds := strings.SplitN(rangeExpr, "-", 2)
+		start, err := c.parseValue(bounds[0])
+		if err != nil {
+			return err
+		}
+		if len(bounds) == 2 {
+			end, err := c.parseValue(bounds[1])
+			if err != nil {
+				return err
+			}
+			if start > end {
+				return fmt.Errorf("invalid range %q in %s field", rangeExpr, c.name)
+			}
+		}
+	}
+	return nil
+}
+
+// parseValue will return the numeric value for the given cron field value, which may be a number or a name.
+func (c cronField) parseValue(value string) (int, error) {
+	for i, name := range c.names {
+		if strings.EqualFold(name, value) {
+			return c.min + i, nil
+		}
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q in %s field", value, c.name)
+	}
+	if n < c.min || n > c.max {
+		return 0, fmt.Errorf("value %d out of range [%d-%d] in %s field", n, c.min, c.max, c.name)
+	}
+	return n, nil
+}
@@ -23,10 +23,12 @@ package v1alpha1
 import (
 	routev1 "github.com/openshift/api/route/v1"
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	"k8s.io/api/autoscaling/v2beta2"
 	"k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -35,7 +37,7 @@ func (in *ArgoCD) DeepCopyInto(out *ArgoCD) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCD.
@@ -108,6 +110,28 @@ func (in *ArgoCDApplicationControllerSpec) DeepCopyInto(out *ArgoCDApplicationCo
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PodTemplateOverride != nil {
+		in, out := &in.PodTemplateOverride, &out.PodTemplateOverride
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PDB != nil {
+		in, out := &in.PDB, &out.PDB
+		*out = new(ArgoCDPodDisruptionBudgetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]v1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDApplicationControllerSpec.
@@ -128,6 +152,30 @@ func (in *ArgoCDApplicationSet) DeepCopyInto(out *ArgoCDApplicationSet) {
 		*out = new(v1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	in.Autoscale.DeepCopyInto(&out.Autoscale)
+	if in.PodTemplateOverride != nil {
+		in, out := &in.PodTemplateOverride, &out.PodTemplateOverride
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PDB != nil {
+		in, out := &in.PDB, &out.PDB
+		*out = new(ArgoCDPodDisruptionBudgetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]v1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.WebhookServer.DeepCopyInto(&out.WebhookServer)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDApplicationSet.
@@ -140,6 +188,65 @@ func (in *ArgoCDApplicationSet) DeepCopy() *ArgoCDApplicationSet {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDApplicationSetWebhookServerSpec) DeepCopyInto(out *ArgoCDApplicationSetWebhookServerSpec) {
+	*out = *in
+	in.Ingress.DeepCopyInto(&out.Ingress)
+	in.Route.DeepCopyInto(&out.Route)
+	if in.GitHubSecretRef != nil {
+		in, out := &in.GitHubSecretRef, &out.GitHubSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GitLabSecretRef != nil {
+		in, out := &in.GitLabSecretRef, &out.GitLabSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDApplicationSetWebhookServerSpec.
+func (in *ArgoCDApplicationSetWebhookServerSpec) DeepCopy() *ArgoCDApplicationSetWebhookServerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDApplicationSetWebhookServerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDAutoscaleSpec) DeepCopyInto(out *ArgoCDAutoscaleSpec) {
+	*out = *in
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make([]v2beta2.MetricSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Behavior != nil {
+		in, out := &in.Behavior, &out.Behavior
+		*out = new(v2beta2.HorizontalPodAutoscalerBehavior)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDAutoscaleSpec.
+func (in *ArgoCDAutoscaleSpec) DeepCopy() *ArgoCDAutoscaleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDAutoscaleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDCASpec) DeepCopyInto(out *ArgoCDCASpec) {
 	*out = *in
@@ -155,6 +262,47 @@ func (in *ArgoCDCASpec) DeepCopy() *ArgoCDCASpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDCertManagerIssuerRef) DeepCopyInto(out *ArgoCDCertManagerIssuerRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDCertManagerIssuerRef.
+func (in *ArgoCDCertManagerIssuerRef) DeepCopy() *ArgoCDCertManagerIssuerRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDCertManagerIssuerRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDCertManagerSpec) DeepCopyInto(out *ArgoCDCertManagerSpec) {
+	*out = *in
+	out.IssuerRef = in.IssuerRef
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDCertManagerSpec.
+func (in *ArgoCDCertManagerSpec) DeepCopy() *ArgoCDCertManagerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDCertManagerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDCertificateSpec) DeepCopyInto(out *ArgoCDCertificateSpec) {
 	*out = *in
@@ -170,6 +318,41 @@ func (in *ArgoCDCertificateSpec) DeepCopy() *ArgoCDCertificateSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDCertificateStatus) DeepCopyInto(out *ArgoCDCertificateStatus) {
+	*out = *in
+	in.NotAfter.DeepCopyInto(&out.NotAfter)
+	if in.RenewalTime != nil {
+		in, out := &in.RenewalTime, &out.RenewalTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDCertificateStatus.
+func (in *ArgoCDCertificateStatus) DeepCopy() *ArgoCDCertificateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDCertificateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDComponentReplicas) DeepCopyInto(out *ArgoCDComponentReplicas) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDComponentReplicas.
+func (in *ArgoCDComponentReplicas) DeepCopy() *ArgoCDComponentReplicas {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDComponentReplicas)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDDexOAuthSpec) DeepCopyInto(out *ArgoCDDexOAuthSpec) {
 	*out = *in
@@ -193,11 +376,38 @@ func (in *ArgoCDDexSpec) DeepCopyInto(out *ArgoCDDexSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
 		*out = new(v1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PodTemplateOverride != nil {
+		in, out := &in.PodTemplateOverride, &out.PodTemplateOverride
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PDB != nil {
+		in, out := &in.PDB, &out.PDB
+		*out = new(ArgoCDPodDisruptionBudgetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]v1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDDexSpec.
@@ -329,9 +539,80 @@ func (in *ArgoCDExportStorageSpec) DeepCopy() *ArgoCDExportStorageSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDGatewayParentRef) DeepCopyInto(out *ArgoCDGatewayParentRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDGatewayParentRef.
+func (in *ArgoCDGatewayParentRef) DeepCopy() *ArgoCDGatewayParentRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDGatewayParentRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDGatewaySpec) DeepCopyInto(out *ArgoCDGatewaySpec) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	out.ParentRef = in.ParentRef
+	if in.Hostnames != nil {
+		in, out := &in.Hostnames, &out.Hostnames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(ArgoCDGatewayTLSSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDGatewaySpec.
+func (in *ArgoCDGatewaySpec) DeepCopy() *ArgoCDGatewaySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDGatewaySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDGatewayTLSSpec) DeepCopyInto(out *ArgoCDGatewayTLSSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDGatewayTLSSpec.
+func (in *ArgoCDGatewayTLSSpec) DeepCopy() *ArgoCDGatewayTLSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDGatewayTLSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArgoCDGrafanaSpec) DeepCopyInto(out *ArgoCDGrafanaSpec) {
 	*out = *in
+	in.Gateway.DeepCopyInto(&out.Gateway)
 	in.Ingress.DeepCopyInto(&out.Ingress)
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
@@ -344,6 +625,28 @@ func (in *ArgoCDGrafanaSpec) DeepCopyInto(out *ArgoCDGrafanaSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.PodTemplateOverride != nil {
+		in, out := &in.PodTemplateOverride, &out.PodTemplateOverride
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PDB != nil {
+		in, out := &in.PDB, &out.PDB
+		*out = new(ArgoCDPodDisruptionBudgetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]v1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDGrafanaSpec.
@@ -364,6 +667,58 @@ func (in *ArgoCDHASpec) DeepCopyInto(out *ArgoCDHASpec) {
 		*out = new(v1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PodTemplateOverride != nil {
+		in, out := &in.PodTemplateOverride, &out.PodTemplateOverride
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PDB != nil {
+		in, out := &in.PDB, &out.PDB
+		*out = new(ArgoCDPodDisruptionBudgetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]v1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RedisReplicas != nil {
+		in, out := &in.RedisReplicas, &out.RedisReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RedisProxyReplicas != nil {
+		in, out := &in.RedisProxyReplicas, &out.RedisProxyReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SentinelQuorum != nil {
+		in, out := &in.SentinelQuorum, &out.SentinelQuorum
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SentinelDownAfter != nil {
+		in, out := &in.SentinelDownAfter, &out.SentinelDownAfter
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.SentinelFailoverTimeout != nil {
+		in, out := &in.SentinelFailoverTimeout, &out.SentinelFailoverTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RedisStorage != nil {
+		in, out := &in.RedisStorage, &out.RedisStorage
+		*out = new(v1.PersistentVolumeClaimSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDHASpec.
@@ -406,6 +761,28 @@ func (in *ArgoCDIngressSpec) DeepCopyInto(out *ArgoCDIngressSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IngressClassName != nil {
+		in, out := &in.IngressClassName, &out.IngressClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PathType != nil {
+		in, out := &in.PathType, &out.PathType
+		*out = new(networkingv1.PathType)
+		**out = **in
+	}
 	if in.TLS != nil {
 		in, out := &in.TLS, &out.TLS
 		*out = make([]networkingv1.IngressTLS, len(*in))
@@ -458,385 +835,1292 @@ func (in *ArgoCDList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDNodePlacementSpec) DeepCopyInto(out *ArgoCDNodePlacementSpec) {
+func (in *ArgoCDNetworkPolicySpec) DeepCopyInto(out *ArgoCDNetworkPolicySpec) {
 	*out = *in
-	if in.NodeSelector != nil {
-		in, out := &in.NodeSelector, &out.NodeSelector
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
+	if in.ApplicationSet != nil {
+		in, out := &in.ApplicationSet, &out.ApplicationSet
+		*out = make([]networkingv1.NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.Tolerations != nil {
-		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]v1.Toleration, len(*in))
+	if in.Controller != nil {
+		in, out := &in.Controller, &out.Controller
+		*out = make([]networkingv1.NetworkPolicyPeer, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDNodePlacementSpec.
-func (in *ArgoCDNodePlacementSpec) DeepCopy() *ArgoCDNodePlacementSpec {
-	if in == nil {
-		return nil
+	if in.Dex != nil {
+		in, out := &in.Dex, &out.Dex
+		*out = make([]networkingv1.NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	out := new(ArgoCDNodePlacementSpec)
-	in.DeepCopyInto(out)
-	return out
-}
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = make([]networkingv1.NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Redis != nil {
+		in, out := &in.Redis, &out.Redis
+		*out = make([]networkingv1.NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Repo != nil {
+		in, out := &in.Repo, &out.Repo
+		*out = make([]networkingv1.NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Server != nil {
+		in, out := &in.Server, &out.Server
+		*out = make([]networkingv1.NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDNetworkPolicySpec.
+func (in *ArgoCDNetworkPolicySpec) DeepCopy() *ArgoCDNetworkPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDNetworkPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDNodePlacementSpec) DeepCopyInto(out *ArgoCDNodePlacementSpec) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDNodePlacementSpec.
+func (in *ArgoCDNodePlacementSpec) DeepCopy() *ArgoCDNodePlacementSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDNodePlacementSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDNotifications) DeepCopyInto(out *ArgoCDNotifications) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Context != nil {
+		in, out := &in.Context, &out.Context
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Triggers != nil {
+		in, out := &in.Triggers, &out.Triggers
+		*out = make([]NotificationTrigger, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Templates != nil {
+		in, out := &in.Templates, &out.Templates
+		*out = make([]NotificationTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = make([]NotificationService, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PodTemplateOverride != nil {
+		in, out := &in.PodTemplateOverride, &out.PodTemplateOverride
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PDB != nil {
+		in, out := &in.PDB, &out.PDB
+		*out = new(ArgoCDPodDisruptionBudgetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]v1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDNotifications.
+func (in *ArgoCDNotifications) DeepCopy() *ArgoCDNotifications {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDNotifications)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDPodDisruptionBudgetSpec) DeepCopyInto(out *ArgoCDPodDisruptionBudgetSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDPodDisruptionBudgetSpec.
+func (in *ArgoCDPodDisruptionBudgetSpec) DeepCopy() *ArgoCDPodDisruptionBudgetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDPodDisruptionBudgetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDPrometheusSpec) DeepCopyInto(out *ArgoCDPrometheusSpec) {
+	*out = *in
+	in.Gateway.DeepCopyInto(&out.Gateway)
+	in.Ingress.DeepCopyInto(&out.Ingress)
+	in.Route.DeepCopyInto(&out.Route)
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDPrometheusSpec.
+func (in *ArgoCDPrometheusSpec) DeepCopy() *ArgoCDPrometheusSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDPrometheusSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRBACBinding) DeepCopyInto(out *ArgoCDRBACBinding) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRBACBinding.
+func (in *ArgoCDRBACBinding) DeepCopy() *ArgoCDRBACBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRBACBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRBACPermission) DeepCopyInto(out *ArgoCDRBACPermission) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRBACPermission.
+func (in *ArgoCDRBACPermission) DeepCopy() *ArgoCDRBACPermission {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRBACPermission)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRBACRole) DeepCopyInto(out *ArgoCDRBACRole) {
+	*out = *in
+	if in.Permissions != nil {
+		in, out := &in.Permissions, &out.Permissions
+		*out = make([]ArgoCDRBACPermission, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRBACRole.
+func (in *ArgoCDRBACRole) DeepCopy() *ArgoCDRBACRole {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRBACRole)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRBACSpec) DeepCopyInto(out *ArgoCDRBACSpec) {
+	*out = *in
+	if in.DefaultPolicy != nil {
+		in, out := &in.DefaultPolicy, &out.DefaultPolicy
+		*out = new(string)
+		**out = **in
+	}
+	if in.Policy != nil {
+		in, out := &in.Policy, &out.Policy
+		*out = new(string)
+		**out = **in
+	}
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]ArgoCDRBACRole, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Bindings != nil {
+		in, out := &in.Bindings, &out.Bindings
+		*out = make([]ArgoCDRBACBinding, len(*in))
+		copy(*out, *in)
+	}
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRBACSpec.
+func (in *ArgoCDRBACSpec) DeepCopy() *ArgoCDRBACSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRBACSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRedisExternalSpec) DeepCopyInto(out *ArgoCDRedisExternalSpec) {
+	*out = *in
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CASecretRef != nil {
+		in, out := &in.CASecretRef, &out.CASecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRedisExternalSpec.
+func (in *ArgoCDRedisExternalSpec) DeepCopy() *ArgoCDRedisExternalSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRedisExternalSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRedisSpec) DeepCopyInto(out *ArgoCDRedisSpec) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodTemplateOverride != nil {
+		in, out := &in.PodTemplateOverride, &out.PodTemplateOverride
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PDB != nil {
+		in, out := &in.PDB, &out.PDB
+		*out = new(ArgoCDPodDisruptionBudgetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]v1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.External != nil {
+		in, out := &in.External, &out.External
+		*out = new(ArgoCDRedisExternalSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRedisSpec.
+func (in *ArgoCDRedisSpec) DeepCopy() *ArgoCDRedisSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRedisSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRepoPluginCommand) DeepCopyInto(out *ArgoCDRepoPluginCommand) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRepoPluginCommand.
+func (in *ArgoCDRepoPluginCommand) DeepCopy() *ArgoCDRepoPluginCommand {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRepoPluginCommand)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRepoPluginDefinition) DeepCopyInto(out *ArgoCDRepoPluginDefinition) {
+	*out = *in
+	if in.Init != nil {
+		in, out := &in.Init, &out.Init
+		*out = new(ArgoCDRepoPluginCommand)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Generate.DeepCopyInto(&out.Generate)
+	if in.Discover != nil {
+		in, out := &in.Discover, &out.Discover
+		*out = new(ArgoCDRepoPluginDiscover)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRepoPluginDefinition.
+func (in *ArgoCDRepoPluginDefinition) DeepCopy() *ArgoCDRepoPluginDefinition {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRepoPluginDefinition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRepoPluginDiscover) DeepCopyInto(out *ArgoCDRepoPluginDiscover) {
+	*out = *in
+	if in.Find != nil {
+		in, out := &in.Find, &out.Find
+		*out = new(ArgoCDRepoPluginFind)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRepoPluginDiscover.
+func (in *ArgoCDRepoPluginDiscover) DeepCopy() *ArgoCDRepoPluginDiscover {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRepoPluginDiscover)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRepoPluginFind) DeepCopyInto(out *ArgoCDRepoPluginFind) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRepoPluginFind.
+func (in *ArgoCDRepoPluginFind) DeepCopy() *ArgoCDRepoPluginFind {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRepoPluginFind)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRepoPluginSpec) DeepCopyInto(out *ArgoCDRepoPluginSpec) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRepoPluginSpec.
+func (in *ArgoCDRepoPluginSpec) DeepCopy() *ArgoCDRepoPluginSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRepoPluginSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRepoSpec) DeepCopyInto(out *ArgoCDRepoSpec) {
+	*out = *in
+	in.Autoscale.DeepCopyInto(&out.Autoscale)
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExecTimeout != nil {
+		in, out := &in.ExecTimeout, &out.ExecTimeout
+		*out = new(int)
+		**out = **in
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]v1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]v1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InitContainers != nil {
+		in, out := &in.InitContainers, &out.InitContainers
+		*out = make([]v1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SidecarContainers != nil {
+		in, out := &in.SidecarContainers, &out.SidecarContainers
+		*out = make([]v1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Plugins != nil {
+		in, out := &in.Plugins, &out.Plugins
+		*out = make([]ArgoCDRepoPluginSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PodTemplateOverride != nil {
+		in, out := &in.PodTemplateOverride, &out.PodTemplateOverride
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PDB != nil {
+		in, out := &in.PDB, &out.PDB
+		*out = new(ArgoCDPodDisruptionBudgetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]v1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRepoSpec.
+func (in *ArgoCDRepoSpec) DeepCopy() *ArgoCDRepoSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRepoSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDRouteSpec) DeepCopyInto(out *ArgoCDRouteSpec) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(routev1.TLSConfig)
+		**out = **in
+	}
+	if in.WildcardPolicy != nil {
+		in, out := &in.WildcardPolicy, &out.WildcardPolicy
+		*out = new(routev1.WildcardPolicyType)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRouteSpec.
+func (in *ArgoCDRouteSpec) DeepCopy() *ArgoCDRouteSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDRouteSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDSSOSpec) DeepCopyInto(out *ArgoCDSSOSpec) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VerifyTLS != nil {
+		in, out := &in.VerifyTLS, &out.VerifyTLS
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDSSOSpec.
+func (in *ArgoCDSSOSpec) DeepCopy() *ArgoCDSSOSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDSSOSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDServerAutoscaleSpec) DeepCopyInto(out *ArgoCDServerAutoscaleSpec) {
+	*out = *in
+	if in.HPA != nil {
+		in, out := &in.HPA, &out.HPA
+		*out = new(autoscalingv1.HorizontalPodAutoscalerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDServerAutoscaleSpec.
+func (in *ArgoCDServerAutoscaleSpec) DeepCopy() *ArgoCDServerAutoscaleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDServerAutoscaleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDServerGRPCSpec) DeepCopyInto(out *ArgoCDServerGRPCSpec) {
+	*out = *in
+	in.Gateway.DeepCopyInto(&out.Gateway)
+	in.Ingress.DeepCopyInto(&out.Ingress)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDServerGRPCSpec.
+func (in *ArgoCDServerGRPCSpec) DeepCopy() *ArgoCDServerGRPCSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDServerGRPCSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDServerServiceSpec) DeepCopyInto(out *ArgoCDServerServiceSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDServerServiceSpec.
+func (in *ArgoCDServerServiceSpec) DeepCopy() *ArgoCDServerServiceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDServerServiceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDServerSpec) DeepCopyInto(out *ArgoCDServerSpec) {
+	*out = *in
+	in.Autoscale.DeepCopyInto(&out.Autoscale)
+	in.GRPC.DeepCopyInto(&out.GRPC)
+	in.Gateway.DeepCopyInto(&out.Gateway)
+	in.Ingress.DeepCopyInto(&out.Ingress)
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Route.DeepCopyInto(&out.Route)
+	out.Service = in.Service
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PodTemplateOverride != nil {
+		in, out := &in.PodTemplateOverride, &out.PodTemplateOverride
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PDB != nil {
+		in, out := &in.PDB, &out.PDB
+		*out = new(ArgoCDPodDisruptionBudgetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]v1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDServerSpec.
+func (in *ArgoCDServerSpec) DeepCopy() *ArgoCDServerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDServerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDShardAssignment) DeepCopyInto(out *ArgoCDShardAssignment) {
+	*out = *in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDShardAssignment.
+func (in *ArgoCDShardAssignment) DeepCopy() *ArgoCDShardAssignment {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDShardAssignment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDSpec) DeepCopyInto(out *ArgoCDSpec) {
+	*out = *in
+	if in.ApplicationSet != nil {
+		in, out := &in.ApplicationSet, &out.ApplicationSet
+		*out = new(ArgoCDApplicationSet)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Controller.DeepCopyInto(&out.Controller)
+	in.Dex.DeepCopyInto(&out.Dex)
+	in.Grafana.DeepCopyInto(&out.Grafana)
+	in.HA.DeepCopyInto(&out.HA)
+	if in.Import != nil {
+		in, out := &in.Import, &out.Import
+		*out = new(ArgoCDImportSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	out.InitialSSHKnownHosts = in.InitialSSHKnownHosts
+	if in.KustomizeVersions != nil {
+		in, out := &in.KustomizeVersions, &out.KustomizeVersions
+		*out = make([]KustomizeVersionSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.LocalUsers != nil {
+		in, out := &in.LocalUsers, &out.LocalUsers
+		*out = make([]LocalUserSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.NetworkPolicy.DeepCopyInto(&out.NetworkPolicy)
+	if in.NodePlacement != nil {
+		in, out := &in.NodePlacement, &out.NodePlacement
+		*out = new(ArgoCDNodePlacementSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = new(ArgoCDNotifications)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Prometheus.DeepCopyInto(&out.Prometheus)
+	in.RBAC.DeepCopyInto(&out.RBAC)
+	in.Redis.DeepCopyInto(&out.Redis)
+	in.Repo.DeepCopyInto(&out.Repo)
+	if in.ResourceOverrides != nil {
+		in, out := &in.ResourceOverrides, &out.ResourceOverrides
+		*out = make([]ResourceOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Server.DeepCopyInto(&out.Server)
+	if in.SSO != nil {
+		in, out := &in.SSO, &out.SSO
+		*out = new(ArgoCDSSOSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	in.TLS.DeepCopyInto(&out.TLS)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDSpec.
+func (in *ArgoCDSpec) DeepCopy() *ArgoCDSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDStatus) DeepCopyInto(out *ArgoCDStatus) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = make([]ArgoCDComponentReplicas, len(*in))
+		copy(*out, *in)
+	}
+	if in.Shards != nil {
+		in, out := &in.Shards, &out.Shards
+		*out = make([]ArgoCDShardAssignment, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Certificates != nil {
+		in, out := &in.Certificates, &out.Certificates
+		*out = make([]ArgoCDCertificateStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDStatus.
+func (in *ArgoCDStatus) DeepCopy() *ArgoCDStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDTLSSpec) DeepCopyInto(out *ArgoCDTLSSpec) {
+	*out = *in
+	out.CA = in.CA
+	if in.CertManager != nil {
+		in, out := &in.CertManager, &out.CertManager
+		*out = new(ArgoCDCertManagerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InitialCerts != nil {
+		in, out := &in.InitialCerts, &out.InitialCerts
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDTLSSpec.
+func (in *ArgoCDTLSSpec) DeepCopy() *ArgoCDTLSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDTLSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDPrometheusSpec) DeepCopyInto(out *ArgoCDPrometheusSpec) {
+func (in *KnownTypeField) DeepCopyInto(out *KnownTypeField) {
 	*out = *in
-	in.Ingress.DeepCopyInto(&out.Ingress)
-	in.Route.DeepCopyInto(&out.Route)
-	if in.Size != nil {
-		in, out := &in.Size, &out.Size
-		*out = new(int32)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KnownTypeField.
+func (in *KnownTypeField) DeepCopy() *KnownTypeField {
+	if in == nil {
+		return nil
 	}
+	out := new(KnownTypeField)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDPrometheusSpec.
-func (in *ArgoCDPrometheusSpec) DeepCopy() *ArgoCDPrometheusSpec {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KustomizeVersionSpec) DeepCopyInto(out *KustomizeVersionSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KustomizeVersionSpec.
+func (in *KustomizeVersionSpec) DeepCopy() *KustomizeVersionSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDPrometheusSpec)
+	out := new(KustomizeVersionSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDRBACSpec) DeepCopyInto(out *ArgoCDRBACSpec) {
+func (in *LocalUserSpec) DeepCopyInto(out *LocalUserSpec) {
 	*out = *in
-	if in.DefaultPolicy != nil {
-		in, out := &in.DefaultPolicy, &out.DefaultPolicy
-		*out = new(string)
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
 		**out = **in
 	}
-	if in.Policy != nil {
-		in, out := &in.Policy, &out.Policy
-		*out = new(string)
+	if in.APIKey != nil {
+		in, out := &in.APIKey, &out.APIKey
+		*out = new(bool)
 		**out = **in
 	}
-	if in.Scopes != nil {
-		in, out := &in.Scopes, &out.Scopes
-		*out = new(string)
+	if in.TokenLifetime != nil {
+		in, out := &in.TokenLifetime, &out.TokenLifetime
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.AutoRenewToken != nil {
+		in, out := &in.AutoRenewToken, &out.AutoRenewToken
+		*out = new(bool)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRBACSpec.
-func (in *ArgoCDRBACSpec) DeepCopy() *ArgoCDRBACSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalUserSpec.
+func (in *LocalUserSpec) DeepCopy() *LocalUserSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDRBACSpec)
+	out := new(LocalUserSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDRedisSpec) DeepCopyInto(out *ArgoCDRedisSpec) {
+func (in *NotificationEmailTemplate) DeepCopyInto(out *NotificationEmailTemplate) {
 	*out = *in
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRedisSpec.
-func (in *ArgoCDRedisSpec) DeepCopy() *ArgoCDRedisSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationEmailTemplate.
+func (in *NotificationEmailTemplate) DeepCopy() *NotificationEmailTemplate {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDRedisSpec)
+	out := new(NotificationEmailTemplate)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDRepoSpec) DeepCopyInto(out *ArgoCDRepoSpec) {
+func (in *NotificationService) DeepCopyInto(out *NotificationService) {
 	*out = *in
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(runtime.RawExtension)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.ExecTimeout != nil {
-		in, out := &in.ExecTimeout, &out.ExecTimeout
-		*out = new(int)
-		**out = **in
-	}
-	if in.Env != nil {
-		in, out := &in.Env, &out.Env
-		*out = make([]v1.EnvVar, len(*in))
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make([]NotificationServiceSecret, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRepoSpec.
-func (in *ArgoCDRepoSpec) DeepCopy() *ArgoCDRepoSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationService.
+func (in *NotificationService) DeepCopy() *NotificationService {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDRepoSpec)
+	out := new(NotificationService)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDRouteSpec) DeepCopyInto(out *ArgoCDRouteSpec) {
+func (in *NotificationServiceSecret) DeepCopyInto(out *NotificationServiceSecret) {
 	*out = *in
-	if in.Annotations != nil {
-		in, out := &in.Annotations, &out.Annotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.TLS != nil {
-		in, out := &in.TLS, &out.TLS
-		*out = new(routev1.TLSConfig)
-		**out = **in
+	in.SecretKeyRef.DeepCopyInto(&out.SecretKeyRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationServiceSecret.
+func (in *NotificationServiceSecret) DeepCopy() *NotificationServiceSecret {
+	if in == nil {
+		return nil
 	}
-	if in.WildcardPolicy != nil {
-		in, out := &in.WildcardPolicy, &out.WildcardPolicy
-		*out = new(routev1.WildcardPolicyType)
-		**out = **in
+	out := new(NotificationServiceSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationSlackTemplate) DeepCopyInto(out *NotificationSlackTemplate) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationSlackTemplate.
+func (in *NotificationSlackTemplate) DeepCopy() *NotificationSlackTemplate {
+	if in == nil {
+		return nil
 	}
+	out := new(NotificationSlackTemplate)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDRouteSpec.
-func (in *ArgoCDRouteSpec) DeepCopy() *ArgoCDRouteSpec {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationTemplate) DeepCopyInto(out *NotificationTemplate) {
+	*out = *in
+	in.NotificationTemplateSpec.DeepCopyInto(&out.NotificationTemplateSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationTemplate.
+func (in *NotificationTemplate) DeepCopy() *NotificationTemplate {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDRouteSpec)
+	out := new(NotificationTemplate)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDSSOSpec) DeepCopyInto(out *ArgoCDSSOSpec) {
+func (in *NotificationTemplateSpec) DeepCopyInto(out *NotificationTemplateSpec) {
 	*out = *in
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
+	if in.Email != nil {
+		in, out := &in.Email, &out.Email
+		*out = new(NotificationEmailTemplate)
+		**out = **in
 	}
-	if in.VerifyTLS != nil {
-		in, out := &in.VerifyTLS, &out.VerifyTLS
-		*out = new(bool)
+	if in.Slack != nil {
+		in, out := &in.Slack, &out.Slack
+		*out = new(NotificationSlackTemplate)
 		**out = **in
 	}
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = make(map[string]NotificationWebhookTemplate, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDSSOSpec.
-func (in *ArgoCDSSOSpec) DeepCopy() *ArgoCDSSOSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationTemplateSpec.
+func (in *NotificationTemplateSpec) DeepCopy() *NotificationTemplateSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDSSOSpec)
+	out := new(NotificationTemplateSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDServerAutoscaleSpec) DeepCopyInto(out *ArgoCDServerAutoscaleSpec) {
+func (in *NotificationTrigger) DeepCopyInto(out *NotificationTrigger) {
 	*out = *in
-	if in.HPA != nil {
-		in, out := &in.HPA, &out.HPA
-		*out = new(autoscalingv1.HorizontalPodAutoscalerSpec)
-		(*in).DeepCopyInto(*out)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]NotificationTriggerCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDServerAutoscaleSpec.
-func (in *ArgoCDServerAutoscaleSpec) DeepCopy() *ArgoCDServerAutoscaleSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationTrigger.
+func (in *NotificationTrigger) DeepCopy() *NotificationTrigger {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDServerAutoscaleSpec)
+	out := new(NotificationTrigger)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDServerGRPCSpec) DeepCopyInto(out *ArgoCDServerGRPCSpec) {
+func (in *NotificationTriggerCondition) DeepCopyInto(out *NotificationTriggerCondition) {
 	*out = *in
-	in.Ingress.DeepCopyInto(&out.Ingress)
+	if in.Send != nil {
+		in, out := &in.Send, &out.Send
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDServerGRPCSpec.
-func (in *ArgoCDServerGRPCSpec) DeepCopy() *ArgoCDServerGRPCSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationTriggerCondition.
+func (in *NotificationTriggerCondition) DeepCopy() *NotificationTriggerCondition {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDServerGRPCSpec)
+	out := new(NotificationTriggerCondition)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDServerServiceSpec) DeepCopyInto(out *ArgoCDServerServiceSpec) {
+func (in *NotificationWebhookTemplate) DeepCopyInto(out *NotificationWebhookTemplate) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDServerServiceSpec.
-func (in *ArgoCDServerServiceSpec) DeepCopy() *ArgoCDServerServiceSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationWebhookTemplate.
+func (in *NotificationWebhookTemplate) DeepCopy() *NotificationWebhookTemplate {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDServerServiceSpec)
+	out := new(NotificationWebhookTemplate)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDServerSpec) DeepCopyInto(out *ArgoCDServerSpec) {
+func (in *ResourceActionDefinition) DeepCopyInto(out *ResourceActionDefinition) {
 	*out = *in
-	in.Autoscale.DeepCopyInto(&out.Autoscale)
-	in.GRPC.DeepCopyInto(&out.GRPC)
-	in.Ingress.DeepCopyInto(&out.Ingress)
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
-	}
-	in.Route.DeepCopyInto(&out.Route)
-	out.Service = in.Service
-	if in.Env != nil {
-		in, out := &in.Env, &out.Env
-		*out = make([]v1.EnvVar, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDServerSpec.
-func (in *ArgoCDServerSpec) DeepCopy() *ArgoCDServerSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceActionDefinition.
+func (in *ResourceActionDefinition) DeepCopy() *ResourceActionDefinition {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDServerSpec)
+	out := new(ResourceActionDefinition)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDSpec) DeepCopyInto(out *ArgoCDSpec) {
+func (in *ResourceActions) DeepCopyInto(out *ResourceActions) {
 	*out = *in
-	if in.ApplicationSet != nil {
-		in, out := &in.ApplicationSet, &out.ApplicationSet
-		*out = new(ArgoCDApplicationSet)
-		(*in).DeepCopyInto(*out)
-	}
-	in.Controller.DeepCopyInto(&out.Controller)
-	in.Dex.DeepCopyInto(&out.Dex)
-	in.Grafana.DeepCopyInto(&out.Grafana)
-	in.HA.DeepCopyInto(&out.HA)
-	if in.Import != nil {
-		in, out := &in.Import, &out.Import
-		*out = new(ArgoCDImportSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	out.InitialSSHKnownHosts = in.InitialSSHKnownHosts
-	if in.KustomizeVersions != nil {
-		in, out := &in.KustomizeVersions, &out.KustomizeVersions
-		*out = make([]KustomizeVersionSpec, len(*in))
+	if in.Definitions != nil {
+		in, out := &in.Definitions, &out.Definitions
+		*out = make([]ResourceActionDefinition, len(*in))
 		copy(*out, *in)
 	}
-	if in.NodePlacement != nil {
-		in, out := &in.NodePlacement, &out.NodePlacement
-		*out = new(ArgoCDNodePlacementSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	in.Prometheus.DeepCopyInto(&out.Prometheus)
-	in.RBAC.DeepCopyInto(&out.RBAC)
-	in.Redis.DeepCopyInto(&out.Redis)
-	in.Repo.DeepCopyInto(&out.Repo)
-	in.Server.DeepCopyInto(&out.Server)
-	if in.SSO != nil {
-		in, out := &in.SSO, &out.SSO
-		*out = new(ArgoCDSSOSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	in.TLS.DeepCopyInto(&out.TLS)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDSpec.
-func (in *ArgoCDSpec) DeepCopy() *ArgoCDSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceActions.
+func (in *ResourceActions) DeepCopy() *ResourceActions {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDSpec)
+	out := new(ResourceActions)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDStatus) DeepCopyInto(out *ArgoCDStatus) {
+func (in *ResourceHealthCheck) DeepCopyInto(out *ResourceHealthCheck) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDStatus.
-func (in *ArgoCDStatus) DeepCopy() *ArgoCDStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceHealthCheck.
+func (in *ResourceHealthCheck) DeepCopy() *ResourceHealthCheck {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDStatus)
+	out := new(ResourceHealthCheck)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ArgoCDTLSSpec) DeepCopyInto(out *ArgoCDTLSSpec) {
+func (in *ResourceIgnoreDifferences) DeepCopyInto(out *ResourceIgnoreDifferences) {
 	*out = *in
-	out.CA = in.CA
-	if in.InitialCerts != nil {
-		in, out := &in.InitialCerts, &out.InitialCerts
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.JSONPointers != nil {
+		in, out := &in.JSONPointers, &out.JSONPointers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.JQPathExpressions != nil {
+		in, out := &in.JQPathExpressions, &out.JQPathExpressions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ManagedFieldsManagers != nil {
+		in, out := &in.ManagedFieldsManagers, &out.ManagedFieldsManagers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDTLSSpec.
-func (in *ArgoCDTLSSpec) DeepCopy() *ArgoCDTLSSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceIgnoreDifferences.
+func (in *ResourceIgnoreDifferences) DeepCopy() *ResourceIgnoreDifferences {
 	if in == nil {
 		return nil
 	}
-	out := new(ArgoCDTLSSpec)
+	out := new(ResourceIgnoreDifferences)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KustomizeVersionSpec) DeepCopyInto(out *KustomizeVersionSpec) {
+func (in *ResourceOverride) DeepCopyInto(out *ResourceOverride) {
 	*out = *in
+	if in.Health != nil {
+		in, out := &in.Health, &out.Health
+		*out = new(ResourceHealthCheck)
+		**out = **in
+	}
+	if in.Actions != nil {
+		in, out := &in.Actions, &out.Actions
+		*out = new(ResourceActions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IgnoreDifferences != nil {
+		in, out := &in.IgnoreDifferences, &out.IgnoreDifferences
+		*out = new(ResourceIgnoreDifferences)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KnownTypeFields != nil {
+		in, out := &in.KnownTypeFields, &out.KnownTypeFields
+		*out = make([]KnownTypeField, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KustomizeVersionSpec.
-func (in *KustomizeVersionSpec) DeepCopy() *KustomizeVersionSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceOverride.
+func (in *ResourceOverride) DeepCopy() *ResourceOverride {
 	if in == nil {
 		return nil
 	}
-	out := new(KustomizeVersionSpec)
+	out := new(ResourceOverride)
 	in.DeepCopyInto(out)
 	return out
 }
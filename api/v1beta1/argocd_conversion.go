@@ -0,0 +1,446 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+	"sigs.k8s.io/yaml"
+
+	"github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+)
+
+// LegacyConfigAnnotation holds the original v1alpha1 YAML of every structured field that could not be converted
+// to v1beta1 exactly, keyed by field path. It lets configuration that does not fit the v1beta1 schema, or that
+// relies on YAML formatting, survive a round trip through v1beta1 unchanged.
+const LegacyConfigAnnotation = "argoproj.io/v1alpha1-config"
+
+const (
+	// resourceCustomizationsKey is the LegacyConfigAnnotation key of the deprecated resourceCustomizations YAML
+	// string that the v1beta1 resourceCustomizations were parsed from.
+	resourceCustomizationsKey = "resourceCustomizations"
+
+	// retainedResourceCustomizationsKey is the LegacyConfigAnnotation key of the deprecated resourceCustomizations
+	// YAML string, when it was set alongside the typed resourceOverrides and is not represented in v1beta1.
+	retainedResourceCustomizationsKey = "resourceCustomizations.retained"
+)
+
+var _ conversion.Convertible = &ArgoCD{}
+
+// legacyField ties a v1alpha1 YAML string field to its structured v1beta1 counterpart.
+type legacyField struct {
+	// name is the key of the field in the LegacyConfigAnnotation.
+	name string
+
+	// alpha points to the v1alpha1 YAML string.
+	alpha *string
+
+	// beta points to the structured v1beta1 field.
+	beta interface{}
+
+	// decode parses the YAML string into the value pointed to by out.
+	decode func(data string, out interface{}) error
+
+	// encode renders the value pointed to by in as a YAML string.
+	encode func(in interface{}) (string, error)
+}
+
+// legacyFields returns the fields that are stored as YAML strings in v1alpha1 and as structured types in v1beta1.
+func legacyFields(alpha *v1alpha1.ArgoCDSpec, beta *ArgoCDSpec) []legacyField {
+	return []legacyField{
+		{name: "configManagementPlugins", alpha: &alpha.ConfigManagementPlugins, beta: &beta.ConfigManagementPlugins, decode: decodeYAML, encode: encodeYAML},
+		{name: "dex.config", alpha: &alpha.Dex.Config, beta: &beta.Dex.Config, decode: decodeYAML, encode: encodeYAML},
+		{name: "initialRepositories", alpha: &alpha.InitialRepositories, beta: &beta.InitialRepositories, decode: decodeYAML, encode: encodeYAML},
+		{name: "oidcConfig", alpha: &alpha.OIDCConfig, beta: &beta.OIDCConfig, decode: decodeYAML, encode: encodeYAML},
+		{name: "repositoryCredentials", alpha: &alpha.RepositoryCredentials, beta: &beta.RepositoryCredentials, decode: decodeYAML, encode: encodeYAML},
+		{name: "resourceExclusions", alpha: &alpha.ResourceExclusions, beta: &beta.ResourceExclusions, decode: decodeYAML, encode: encodeYAML},
+		{name: "resourceInclusions", alpha: &alpha.ResourceInclusions, beta: &beta.ResourceInclusions, decode: decodeYAML, encode: encodeYAML},
+	}
+}
+
+// ConvertTo converts this ArgoCD to the Hub version (v1alpha1).
+func (src *ArgoCD) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.ArgoCD)
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	originals, err := popLegacyConfig(&dst.ObjectMeta)
+	if err != nil {
+		return err
+	}
+
+	dst.Spec.ApplicationSet = src.Spec.ApplicationSet
+	dst.Spec.ApplicationInstanceLabelKey = src.Spec.ApplicationInstanceLabelKey
+	dst.Spec.Controller = src.Spec.Controller
+	dst.Spec.Dex.Affinity = src.Spec.Dex.Affinity
+	dst.Spec.Dex.Groups = src.Spec.Dex.Groups
+	dst.Spec.Dex.Image = src.Spec.Dex.Image
+	dst.Spec.Dex.OpenShiftOAuth = src.Spec.Dex.OpenShiftOAuth
+	dst.Spec.Dex.PDB = src.Spec.Dex.PDB
+	dst.Spec.Dex.PodTemplateOverride = src.Spec.Dex.PodTemplateOverride
+	dst.Spec.Dex.PriorityClassName = src.Spec.Dex.PriorityClassName
+	dst.Spec.Dex.Replicas = src.Spec.Dex.Replicas
+	dst.Spec.Dex.Resources = src.Spec.Dex.Resources
+	dst.Spec.Dex.TopologySpreadConstraints = src.Spec.Dex.TopologySpreadConstraints
+	dst.Spec.Dex.Version = src.Spec.Dex.Version
+	dst.Spec.DisableAdmin = src.Spec.DisableAdmin
+	dst.Spec.GATrackingID = src.Spec.GATrackingID
+	dst.Spec.GAAnonymizeUsers = src.Spec.GAAnonymizeUsers
+	dst.Spec.Grafana = src.Spec.Grafana
+	dst.Spec.HA = src.Spec.HA
+	dst.Spec.HelpChatURL = src.Spec.HelpChatURL
+	dst.Spec.HelpChatText = src.Spec.HelpChatText
+	dst.Spec.Image = src.Spec.Image
+	dst.Spec.Import = src.Spec.Import
+	dst.Spec.InitialSSHKnownHosts = src.Spec.InitialSSHKnownHosts
+	dst.Spec.KustomizeBuildOptions = src.Spec.KustomizeBuildOptions
+	dst.Spec.KustomizeVersions = src.Spec.KustomizeVersions
+	dst.Spec.LocalUsers = src.Spec.LocalUsers
+	dst.Spec.NetworkPolicy = src.Spec.NetworkPolicy
+	dst.Spec.NodePlacement = src.Spec.NodePlacement
+	dst.Spec.Notifications = src.Spec.Notifications
+	dst.Spec.Prometheus = src.Spec.Prometheus
+	dst.Spec.RBAC = src.Spec.RBAC
+	dst.Spec.Redis = src.Spec.Redis
+	dst.Spec.Repo = src.Spec.Repo
+	dst.Spec.Server = src.Spec.Server
+	dst.Spec.SSO = src.Spec.SSO
+	dst.Spec.StatusBadgeEnabled = src.Spec.StatusBadgeEnabled
+	dst.Spec.TLS = src.Spec.TLS
+	dst.Spec.UsersAnonymousEnabled = src.Spec.UsersAnonymousEnabled
+	dst.Spec.Version = src.Spec.Version
+
+	for _, f := range legacyFields(&dst.Spec, &src.Spec) {
+		original, found := originals[f.name]
+		value, err := f.toLegacy(original, found)
+		if err != nil {
+			return fmt.Errorf("failed to convert spec.%s: %w", f.name, err)
+		}
+		*f.alpha = value
+	}
+	convertResourceCustomizationsTo(&src.Spec, &dst.Spec, originals)
+
+	dst.Status = src.Status
+	return nil
+}
+
+// ConvertFrom converts from the Hub version (v1alpha1) to this version.
+func (dst *ArgoCD) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.ArgoCD)
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	if _, err := popLegacyConfig(&dst.ObjectMeta); err != nil {
+		return err
+	}
+
+	dst.Spec.ApplicationSet = src.Spec.ApplicationSet
+	dst.Spec.ApplicationInstanceLabelKey = src.Spec.ApplicationInstanceLabelKey
+	dst.Spec.Controller = src.Spec.Controller
+	dst.Spec.Dex.Affinity = src.Spec.Dex.Affinity
+	dst.Spec.Dex.Groups = src.Spec.Dex.Groups
+	dst.Spec.Dex.Image = src.Spec.Dex.Image
+	dst.Spec.Dex.OpenShiftOAuth = src.Spec.Dex.OpenShiftOAuth
+	dst.Spec.Dex.PDB = src.Spec.Dex.PDB
+	dst.Spec.Dex.PodTemplateOverride = src.Spec.Dex.PodTemplateOverride
+	dst.Spec.Dex.PriorityClassName = src.Spec.Dex.PriorityClassName
+	dst.Spec.Dex.Replicas = src.Spec.Dex.Replicas
+	dst.Spec.Dex.Resources = src.Spec.Dex.Resources
+	dst.Spec.Dex.TopologySpreadConstraints = src.Spec.Dex.TopologySpreadConstraints
+	dst.Spec.Dex.Version = src.Spec.Dex.Version
+	dst.Spec.DisableAdmin = src.Spec.DisableAdmin
+	dst.Spec.GATrackingID = src.Spec.GATrackingID
+	dst.Spec.GAAnonymizeUsers = src.Spec.GAAnonymizeUsers
+	dst.Spec.Grafana = src.Spec.Grafana
+	dst.Spec.HA = src.Spec.HA
+	dst.Spec.HelpChatURL = src.Spec.HelpChatURL
+	dst.Spec.HelpChatText = src.Spec.HelpChatText
+	dst.Spec.Image = src.Spec.Image
+	dst.Spec.Import = src.Spec.Import
+	dst.Spec.InitialSSHKnownHosts = src.Spec.InitialSSHKnownHosts
+	dst.Spec.KustomizeBuildOptions = src.Spec.KustomizeBuildOptions
+	dst.Spec.KustomizeVersions = src.Spec.KustomizeVersions
+	dst.Spec.LocalUsers = src.Spec.LocalUsers
+	dst.Spec.NetworkPolicy = src.Spec.NetworkPolicy
+	dst.Spec.NodePlacement = src.Spec.NodePlacement
+	dst.Spec.Notifications = src.Spec.Notifications
+	dst.Spec.Prometheus = src.Spec.Prometheus
+	dst.Spec.RBAC = src.Spec.RBAC
+	dst.Spec.Redis = src.Spec.Redis
+	dst.Spec.Repo = src.Spec.Repo
+	dst.Spec.Server = src.Spec.Server
+	dst.Spec.SSO = src.Spec.SSO
+	dst.Spec.StatusBadgeEnabled = src.Spec.StatusBadgeEnabled
+	dst.Spec.TLS = src.Spec.TLS
+	dst.Spec.UsersAnonymousEnabled = src.Spec.UsersAnonymousEnabled
+	dst.Spec.Version = src.Spec.Version
+
+	originals := map[string]string{}
+	alpha := src.Spec
+	for _, f := range legacyFields(&alpha, &dst.Spec) {
+		if f.fromLegacy() {
+			originals[f.name] = *f.alpha
+		}
+	}
+	convertResourceCustomizationsFrom(&src.Spec, &dst.Spec, originals)
+	if err := pushLegacyConfig(&dst.ObjectMeta, originals); err != nil {
+		return err
+	}
+
+	dst.Status = src.Status
+	return nil
+}
+
+// fromLegacy parses the v1alpha1 YAML string into the structured v1beta1 field. It returns true if the original
+// string must be kept to convert back without loss, either because it does not fit the v1beta1 schema or because
+// encoding the structured value does not reproduce it exactly.
+func (f legacyField) fromLegacy() bool {
+	if *f.alpha == "" {
+		return false
+	}
+
+	if err := f.decode(*f.alpha, f.beta); err != nil {
+		setZero(f.beta)
+		return true
+	}
+
+	encoded, err := f.encode(f.beta)
+	return err != nil || encoded != *f.alpha
+}
+
+// toLegacy renders the structured v1beta1 field as a v1alpha1 YAML string. The original string is returned
+// unchanged as long as the structured value has not been modified since it was parsed from it.
+func (f legacyField) toLegacy(original string, found bool) (string, error) {
+	if found {
+		decoded := reflect.New(reflect.TypeOf(f.beta).Elem()).Interface()
+		if err := f.decode(original, decoded); err != nil {
+			setZero(decoded)
+		}
+		if equalJSON(decoded, f.beta) {
+			return original, nil
+		}
+	}
+
+	if isEmpty(f.beta) {
+		return "", nil
+	}
+	return f.encode(f.beta)
+}
+
+// popLegacyConfig removes the LegacyConfigAnnotation from the given object and returns its contents.
+func popLegacyConfig(meta *metav1.ObjectMeta) (map[string]string, error) {
+	annotations := meta.GetAnnotations()
+	value, found := annotations[LegacyConfigAnnotation]
+	if !found {
+		return nil, nil
+	}
+
+	delete(annotations, LegacyConfigAnnotation)
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+	meta.SetAnnotations(annotations)
+
+	originals := map[string]string{}
+	if err := json.Unmarshal([]byte(value), &originals); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %w", LegacyConfigAnnotation, err)
+	}
+	return originals, nil
+}
+
+// pushLegacyConfig stores the given original v1alpha1 values in the LegacyConfigAnnotation of the given object.
+func pushLegacyConfig(meta *metav1.ObjectMeta, originals map[string]string) error {
+	if len(originals) == 0 {
+		return nil
+	}
+
+	value, err := json.Marshal(originals)
+	if err != nil {
+		return err
+	}
+
+	annotations := meta.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[LegacyConfigAnnotation] = string(value)
+	meta.SetAnnotations(annotations)
+	return nil
+}
+
+// decodeYAML parses the YAML string into the value pointed to by out.
+func decodeYAML(data string, out interface{}) error {
+	return yaml.Unmarshal([]byte(data), out)
+}
+
+// encodeYAML renders the value pointed to by in as a YAML string.
+func encodeYAML(in interface{}) (string, error) {
+	out, err := yaml.Marshal(in)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// setZero resets the value pointed to by ptr to its zero value.
+func setZero(ptr interface{}) {
+	v := reflect.ValueOf(ptr).Elem()
+	v.Set(reflect.Zero(v.Type()))
+}
+
+// isEmpty returns true if the value pointed to by ptr is nil, zero or an empty slice.
+func isEmpty(ptr interface{}) bool {
+	v := reflect.ValueOf(ptr).Elem()
+	if v.Kind() == reflect.Slice || v.Kind() == reflect.Map {
+		return v.Len() == 0
+	}
+	return v.IsZero()
+}
+
+// equalJSON returns true if the values pointed to by a and b have the same JSON representation.
+func equalJSON(a, b interface{}) bool {
+	if isEmpty(a) || isEmpty(b) {
+		return isEmpty(a) && isEmpty(b)
+	}
+
+	aJSON, aErr := json.Marshal(a)
+	bJSON, bErr := json.Marshal(b)
+	return aErr == nil && bErr == nil && bytes.Equal(aJSON, bJSON)
+}
+
+// resourceOverride is the argocd-cm representation of a ResourceOverride, keyed by group/Kind.
+type resourceOverride struct {
+	HealthLua         string                    `json:"health.lua,omitempty"`
+	UseOpenLibs       bool                      `json:"health.lua.useOpenLibs,omitempty"`
+	Actions           string                    `json:"actions,omitempty"`
+	IgnoreDifferences string                    `json:"ignoreDifferences,omitempty"`
+	KnownTypeFields   []v1alpha1.KnownTypeField `json:"knownTypeFields,omitempty"`
+}
+
+// resourceActions is the argocd-cm representation of ResourceActions.
+type resourceActions struct {
+	DiscoveryLua string                     `json:"discovery.lua,omitempty"`
+	Definitions  []resourceActionDefinition `json:"definitions,omitempty"`
+}
+
+// resourceActionDefinition is the argocd-cm representation of a ResourceActionDefinition.
+type resourceActionDefinition struct {
+	Name      string `json:"name"`
+	ActionLua string `json:"action.lua"`
+}
+
+// convertResourceCustomizationsTo sets the v1alpha1 resource customizations from the v1beta1 list. The list is
+// converted back to the deprecated YAML string it was parsed from as long as it has not been modified, and to the
+// typed resourceOverrides otherwise.
+func convertResourceCustomizationsTo(src *ArgoCDSpec, dst *v1alpha1.ArgoCDSpec, originals map[string]string) {
+	if retained, found := originals[retainedResourceCustomizationsKey]; found {
+		dst.ResourceCustomizations = retained
+		dst.ResourceOverrides = src.ResourceCustomizations
+		return
+	}
+
+	if original, found := originals[resourceCustomizationsKey]; found {
+		decoded := []v1alpha1.ResourceOverride{}
+		if err := decodeResourceCustomizations(original, &decoded); err != nil {
+			decoded = nil
+		}
+		if equalJSON(&decoded, &src.ResourceCustomizations) {
+			dst.ResourceCustomizations = original
+			return
+		}
+	}
+
+	dst.ResourceOverrides = src.ResourceCustomizations
+}
+
+// convertResourceCustomizationsFrom sets the v1beta1 resource customizations from the typed v1alpha1
+// resourceOverrides, or from the deprecated YAML string if there are none. The YAML string is always kept in the
+// returned originals so that it can be restored when converting back.
+func convertResourceCustomizationsFrom(src *v1alpha1.ArgoCDSpec, dst *ArgoCDSpec, originals map[string]string) {
+	if len(src.ResourceOverrides) > 0 {
+		dst.ResourceCustomizations = src.ResourceOverrides
+		if src.ResourceCustomizations != "" {
+			originals[retainedResourceCustomizationsKey] = src.ResourceCustomizations
+		}
+		return
+	}
+
+	if src.ResourceCustomizations != "" {
+		originals[resourceCustomizationsKey] = src.ResourceCustomizations
+		if err := decodeResourceCustomizations(src.ResourceCustomizations, &dst.ResourceCustomizations); err != nil {
+			dst.ResourceCustomizations = nil
+		}
+	}
+}
+
+// decodeResourceCustomizations parses the argocd-cm resource customizations into a list of ResourceOverride,
+// sorted by group/Kind.
+func decodeResourceCustomizations(data string, out *[]v1alpha1.ResourceOverride) error {
+	overrides := map[string]resourceOverride{}
+	if err := yaml.Unmarshal([]byte(data), &overrides); err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(overrides))
+	for key := range overrides {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	customizations := make([]v1alpha1.ResourceOverride, 0, len(keys))
+	for _, key := range keys {
+		override := overrides[key]
+		rc := v1alpha1.ResourceOverride{KnownTypeFields: override.KnownTypeFields}
+
+		rc.Kind = key
+		if i := strings.LastIndex(key, "/"); i >= 0 {
+			rc.Group, rc.Kind = key[:i], key[i+1:]
+		}
+
+		if override.HealthLua != "" || override.UseOpenLibs {
+			rc.Health = &v1alpha1.ResourceHealthCheck{Lua: override.HealthLua, UseOpenLibs: override.UseOpenLibs}
+		}
+
+		if override.Actions != "" {
+			actions := resourceActions{}
+			if err := yaml.Unmarshal([]byte(override.Actions), &actions); err != nil {
+				return fmt.Errorf("invalid actions for %s: %w", key, err)
+			}
+			rc.Actions = &v1alpha1.ResourceActions{DiscoveryLua: actions.DiscoveryLua}
+			for _, d := range actions.Definitions {
+				rc.Actions.Definitions = append(rc.Actions.Definitions, v1alpha1.ResourceActionDefinition{Name: d.Name, ActionLua: d.ActionLua})
+			}
+		}
+
+		if override.IgnoreDifferences != "" {
+			rc.IgnoreDifferences = &v1alpha1.ResourceIgnoreDifferences{}
+			if err := yaml.Unmarshal([]byte(override.IgnoreDifferences), rc.IgnoreDifferences); err != nil {
+				return fmt.Errorf("invalid ignoreDifferences for %s: %w", key, err)
+			}
+		}
+
+		customizations = append(customizations, rc)
+	}
+
+	*out = customizations
+	return nil
+}
@@ -0,0 +1,177 @@
+package v1beta1
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+)
+
+const (
+	testResourceCustomizations = `admissionregistration.k8s.io/MutatingWebhookConfiguration:
+  ignoreDifferences: |
+    jsonPointers:
+    - /webhooks/0/clientConfig/caBundle
+PersistentVolumeClaim:
+  health.lua: |
+    hs = {}
+    hs.status = "Healthy"
+    return hs
+`
+
+	testInitialRepositories = `- url: https://github.com/argoproj/argocd-example-apps
+- name: private
+  url: git@github.com:example/private.git
+  # The key is read from the repo-secret secret.
+  sshPrivateKeySecret:
+    name: repo-secret
+    key: sshPrivateKey
+`
+
+	testDexConfig = `connectors:
+- type: github
+  id: github
+  name: GitHub
+  config:
+    clientID: aabbccddeeff00112233
+    clientSecret: $dex.github.clientSecret
+`
+)
+
+func makeTestAlphaArgoCD() *v1alpha1.ArgoCD {
+	return &v1alpha1.ArgoCD{
+		ObjectMeta: metav1.ObjectMeta{Name: "argocd", Namespace: "argocd"},
+		Spec: v1alpha1.ArgoCDSpec{
+			ConfigManagementPlugins: "- name: kasane\n  generate:\n    command: [kasane, show]\n",
+			Dex: v1alpha1.ArgoCDDexSpec{
+				Config:  testDexConfig,
+				Version: "v2.27.0",
+			},
+			InitialRepositories:    testInitialRepositories,
+			OIDCConfig:             "name: Okta\nissuer: https://dev-123456.oktapreview.com\nclientID: aaaabbbbccccddddeee\n",
+			RepositoryCredentials:  "- url: https://github.com/example\n  passwordSecret:\n    key: password\n    name: creds\n",
+			ResourceCustomizations: testResourceCustomizations,
+			ResourceExclusions:     "- apiGroups:\n  - tekton.dev\n  kinds:\n  - TaskRun\n  - PipelineRun\n",
+			ResourceInclusions:     "not: [valid",
+			Server:                 v1alpha1.ArgoCDServerSpec{Insecure: true},
+		},
+		Status: v1alpha1.ArgoCDStatus{Phase: "Available"},
+	}
+}
+
+func TestArgoCD_ConvertFrom(t *testing.T) {
+	src := makeTestAlphaArgoCD()
+	dst := &ArgoCD{}
+	assert.NilError(t, dst.ConvertFrom(src))
+
+	assert.Equal(t, dst.Name, "argocd")
+	assert.Equal(t, dst.Status.Phase, "Available")
+	assert.Equal(t, dst.Spec.Server.Insecure, true)
+	assert.Equal(t, dst.Spec.Dex.Version, "v2.27.0")
+
+	assert.DeepEqual(t, dst.Spec.ConfigManagementPlugins, []ConfigManagementPlugin{
+		{Name: "kasane", Generate: PluginCommand{Command: []string{"kasane", "show"}}},
+	})
+
+	assert.Equal(t, len(dst.Spec.Dex.Config.Connectors), 1)
+	assert.Equal(t, dst.Spec.Dex.Config.Connectors[0].ID, "github")
+	assert.Equal(t, string(dst.Spec.Dex.Config.Connectors[0].Config.Raw), `{"clientID":"aabbccddeeff00112233","clientSecret":"$dex.github.clientSecret"}`)
+
+	assert.DeepEqual(t, dst.Spec.InitialRepositories, []Repository{
+		{RepositoryCredential: RepositoryCredential{URL: "https://github.com/argoproj/argocd-example-apps"}},
+		{
+			RepositoryCredential: RepositoryCredential{
+				URL: "git@github.com:example/private.git",
+				SSHPrivateKeySecret: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "repo-secret"},
+					Key:                  "sshPrivateKey",
+				},
+			},
+			Name: "private",
+		},
+	})
+
+	assert.DeepEqual(t, dst.Spec.OIDCConfig, &OIDCConfig{
+		Name:     "Okta",
+		Issuer:   "https://dev-123456.oktapreview.com",
+		ClientID: "aaaabbbbccccddddeee",
+	})
+
+	assert.DeepEqual(t, dst.Spec.ResourceCustomizations, []v1alpha1.ResourceOverride{
+		{Kind: "PersistentVolumeClaim", Health: &v1alpha1.ResourceHealthCheck{Lua: "hs = {}\nhs.status = \"Healthy\"\nreturn hs\n"}},
+		{
+			Group:             "admissionregistration.k8s.io",
+			Kind:              "MutatingWebhookConfiguration",
+			IgnoreDifferences: &v1alpha1.ResourceIgnoreDifferences{JSONPointers: []string{"/webhooks/0/clientConfig/caBundle"}},
+		},
+	})
+
+	assert.DeepEqual(t, dst.Spec.ResourceExclusions, []FilteredResource{
+		{APIGroups: []string{"tekton.dev"}, Kinds: []string{"TaskRun", "PipelineRun"}},
+	})
+
+	// Invalid YAML can not be represented in v1beta1 and is only kept in the annotation.
+	assert.Assert(t, dst.Spec.ResourceInclusions == nil)
+	assert.Assert(t, dst.Annotations[LegacyConfigAnnotation] != "")
+}
+
+func TestArgoCD_ConvertRoundTrip(t *testing.T) {
+	src := makeTestAlphaArgoCD()
+
+	beta := &ArgoCD{}
+	assert.NilError(t, beta.ConvertFrom(src))
+
+	dst := &v1alpha1.ArgoCD{}
+	assert.NilError(t, beta.ConvertTo(dst))
+
+	assert.DeepEqual(t, dst, src)
+}
+
+func TestArgoCD_ConvertToModified(t *testing.T) {
+	beta := &ArgoCD{}
+	assert.NilError(t, beta.ConvertFrom(makeTestAlphaArgoCD()))
+
+	beta.Spec.InitialRepositories = beta.Spec.InitialRepositories[:1]
+	beta.Spec.ResourceCustomizations[0].Health.UseOpenLibs = true
+	beta.Spec.ResourceInclusions = []FilteredResource{{APIGroups: []string{"*"}, Kinds: []string{"*"}, Clusters: []string{"*"}}}
+	beta.Spec.OIDCConfig = nil
+
+	dst := &v1alpha1.ArgoCD{}
+	assert.NilError(t, beta.ConvertTo(dst))
+
+	assert.Equal(t, dst.Spec.InitialRepositories, "- url: https://github.com/argoproj/argocd-example-apps\n")
+	// Modified resource customizations are converted to the typed resource overrides.
+	assert.Equal(t, dst.Spec.ResourceCustomizations, "")
+	assert.Equal(t, len(dst.Spec.ResourceOverrides), 2)
+	assert.Equal(t, dst.Spec.ResourceOverrides[0].Health.UseOpenLibs, true)
+	assert.Equal(t, dst.Spec.ResourceInclusions, "- apiGroups:\n  - '*'\n  clusters:\n  - '*'\n  kinds:\n  - '*'\n")
+	assert.Equal(t, dst.Spec.OIDCConfig, "")
+
+	// Unmodified fields keep their original formatting.
+	assert.Equal(t, dst.Spec.Dex.Config, testDexConfig)
+	assert.Equal(t, dst.Spec.ConfigManagementPlugins, "- name: kasane\n  generate:\n    command: [kasane, show]\n")
+
+	_, found := dst.Annotations[LegacyConfigAnnotation]
+	assert.Assert(t, !found)
+}
+
+func TestArgoCD_ConvertRoundTripResourceOverrides(t *testing.T) {
+	src := makeTestAlphaArgoCD()
+	src.Spec.ResourceOverrides = []v1alpha1.ResourceOverride{
+		{Group: "apps", Kind: "Deployment", KnownTypeFields: []v1alpha1.KnownTypeField{{Field: "spec.template.spec", Type: "core/v1/PodSpec"}}},
+	}
+
+	beta := &ArgoCD{}
+	assert.NilError(t, beta.ConvertFrom(src))
+
+	// The typed resource overrides take precedence over the deprecated YAML string.
+	assert.DeepEqual(t, beta.Spec.ResourceCustomizations, src.Spec.ResourceOverrides)
+
+	dst := &v1alpha1.ArgoCD{}
+	assert.NilError(t, beta.ConvertTo(dst))
+
+	assert.DeepEqual(t, dst, src)
+}
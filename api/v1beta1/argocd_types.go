@@ -0,0 +1,398 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+)
+
+func init() {
+	SchemeBuilder.Register(&ArgoCD{}, &ArgoCDList{})
+}
+
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+// Important: Run "make" to regenerate code after modifying this file
+
+//+kubebuilder:object:root=true
+
+// ArgoCD is the Schema for the argocds API
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+type ArgoCD struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ArgoCDSpec            `json:"spec,omitempty"`
+	Status v1alpha1.ArgoCDStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ArgoCDList contains a list of ArgoCD
+type ArgoCDList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ArgoCD `json:"items"`
+}
+
+// ArgoCDSpec defines the desired state of ArgoCD
+// +k8s:openapi-gen=true
+type ArgoCDSpec struct {
+
+	// ArgoCDApplicationSet defines whether the Argo CD ApplicationSet controller should be installed.
+	ApplicationSet *v1alpha1.ArgoCDApplicationSet `json:"applicationSet,omitempty"`
+
+	// ApplicationInstanceLabelKey is the key name where Argo CD injects the app name as a tracking label.
+	ApplicationInstanceLabelKey string `json:"applicationInstanceLabelKey,omitempty"`
+
+	// ConfigManagementPlugins is used to specify additional config management plugins.
+	// +listType=map
+	// +listMapKey=name
+	ConfigManagementPlugins []ConfigManagementPlugin `json:"configManagementPlugins,omitempty"`
+
+	// Controller defines the Application Controller options for ArgoCD.
+	Controller v1alpha1.ArgoCDApplicationControllerSpec `json:"controller,omitempty"`
+
+	// Dex defines the Dex server options for ArgoCD.
+	Dex ArgoCDDexSpec `json:"dex,omitempty"`
+
+	// DisableAdmin will disable the admin user.
+	DisableAdmin bool `json:"disableAdmin,omitempty"`
+
+	// GATrackingID is the google analytics tracking ID to use.
+	GATrackingID string `json:"gaTrackingID,omitempty"`
+
+	// GAAnonymizeUsers toggles user IDs being hashed before sending to google analytics.
+	GAAnonymizeUsers bool `json:"gaAnonymizeUsers,omitempty"`
+
+	// Grafana defines the Grafana server options for ArgoCD.
+	Grafana v1alpha1.ArgoCDGrafanaSpec `json:"grafana,omitempty"`
+
+	// HA options for High Availability support for the Redis component.
+	HA v1alpha1.ArgoCDHASpec `json:"ha,omitempty"`
+
+	// HelpChatURL is the URL for getting chat help, this will typically be your Slack channel for support.
+	HelpChatURL string `json:"helpChatURL,omitempty"`
+
+	// HelpChatText is the text for getting chat help, defaults to "Chat now!"
+	HelpChatText string `json:"helpChatText,omitempty"`
+
+	// Image is the ArgoCD container image for all ArgoCD components.
+	Image string `json:"image,omitempty"`
+
+	// Import is the import/restore options for ArgoCD.
+	Import *v1alpha1.ArgoCDImportSpec `json:"import,omitempty"`
+
+	// InitialRepositories to configure Argo CD with upon creation of the cluster.
+	// +listType=map
+	// +listMapKey=url
+	InitialRepositories []Repository `json:"initialRepositories,omitempty"`
+
+	// InitialSSHKnownHosts defines the SSH known hosts data upon creation of the cluster for connecting Git repositories via SSH.
+	InitialSSHKnownHosts v1alpha1.SSHHostsSpec `json:"initialSSHKnownHosts,omitempty"`
+
+	// KustomizeBuildOptions is used to specify build options/parameters to use with `kustomize build`.
+	KustomizeBuildOptions string `json:"kustomizeBuildOptions,omitempty"`
+
+	// KustomizeVersions is a listing of configured versions of Kustomize to be made available within ArgoCD.
+	KustomizeVersions []v1alpha1.KustomizeVersionSpec `json:"kustomizeVersions,omitempty"`
+
+	// LocalUsers are the Argo CD local users to manage, with operator generated API tokens and passwords.
+	//+listType=map
+	//+listMapKey=name
+	LocalUsers []v1alpha1.LocalUserSpec `json:"localUsers,omitempty"`
+
+	// NetworkPolicy defines the NetworkPolicies generated for the Argo CD components.
+	NetworkPolicy v1alpha1.ArgoCDNetworkPolicySpec `json:"networkPolicy,omitempty"`
+
+	// OIDCConfig is the OIDC configuration as an alternative to dex.
+	OIDCConfig *OIDCConfig `json:"oidcConfig,omitempty"`
+
+	// NodePlacement defines NodeSelectors and Taints for Argo CD workloads
+	NodePlacement *v1alpha1.ArgoCDNodePlacementSpec `json:"nodePlacement,omitempty"`
+
+	// Notifications defines whether the Argo CD Notifications controller should be installed.
+	Notifications *v1alpha1.ArgoCDNotifications `json:"notifications,omitempty"`
+
+	// Prometheus defines the Prometheus server options for ArgoCD.
+	Prometheus v1alpha1.ArgoCDPrometheusSpec `json:"prometheus,omitempty"`
+
+	// RBAC defines the RBAC configuration for Argo CD.
+	RBAC v1alpha1.ArgoCDRBACSpec `json:"rbac,omitempty"`
+
+	// Redis defines the Redis server options for ArgoCD.
+	Redis v1alpha1.ArgoCDRedisSpec `json:"redis,omitempty"`
+
+	// Repo defines the repo server options for Argo CD.
+	Repo v1alpha1.ArgoCDRepoSpec `json:"repo,omitempty"`
+
+	// RepositoryCredentials are the Git pull credentials to configure Argo CD with upon creation of the cluster.
+	// +listType=map
+	// +listMapKey=url
+	RepositoryCredentials []RepositoryCredential `json:"repositoryCredentials,omitempty"`
+
+	// ResourceCustomizations customizes the health checks, actions and diffing of resources, per group/kind.
+	ResourceCustomizations []v1alpha1.ResourceOverride `json:"resourceCustomizations,omitempty"`
+
+	// ResourceExclusions is used to completely ignore entire classes of resource group/kinds.
+	ResourceExclusions []FilteredResource `json:"resourceExclusions,omitempty"`
+
+	// ResourceInclusions is used to only include specific group/kinds in the
+	// reconciliation process.
+	ResourceInclusions []FilteredResource `json:"resourceInclusions,omitempty"`
+
+	// Server defines the options for the ArgoCD Server component.
+	Server v1alpha1.ArgoCDServerSpec `json:"server,omitempty"`
+
+	// SSO defines the Single Sign-on configuration for Argo CD
+	SSO *v1alpha1.ArgoCDSSOSpec `json:"sso,omitempty"`
+
+	// StatusBadgeEnabled toggles application status badge feature.
+	StatusBadgeEnabled bool `json:"statusBadgeEnabled,omitempty"`
+
+	// TLS defines the TLS options for ArgoCD.
+	TLS v1alpha1.ArgoCDTLSSpec `json:"tls,omitempty"`
+
+	// UsersAnonymousEnabled toggles anonymous user access.
+	// The anonymous users get default role permissions specified argocd-rbac-cm.
+	UsersAnonymousEnabled bool `json:"usersAnonymousEnabled,omitempty"`
+
+	// Version is the tag to use with the ArgoCD container image for all ArgoCD components.
+	Version string `json:"version,omitempty"`
+}
+
+// ArgoCDDexSpec defines the desired state for the Dex server component.
+type ArgoCDDexSpec struct {
+	// Config is the dex connector configuration.
+	Config *DexConfig `json:"config,omitempty"`
+
+	// Optional list of required groups a user must be a member of
+	Groups []string `json:"groups,omitempty"`
+
+	// Image is the Dex container image.
+	Image string `json:"image,omitempty"`
+
+	// OpenShiftOAuth enables OpenShift OAuth authentication for the Dex server.
+	OpenShiftOAuth bool `json:"openShiftOAuth,omitempty"`
+
+	// Replicas is the replica count for the Dex Deployment.
+	// +kubebuilder:validation:Minimum=0
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Resources defines the Compute Resources required by the container for Dex.
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Version is the Dex container image tag.
+	Version string `json:"version,omitempty"`
+
+	// PodTemplateOverride is a partial pod template applied as a strategic merge patch to the pod template of the Dex Deployment, after the operator has built it.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	PodTemplateOverride *runtime.RawExtension `json:"podTemplateOverride,omitempty"`
+
+	// PDB configures the PodDisruptionBudget for the pods of the Dex Deployment.
+	PDB *v1alpha1.ArgoCDPodDisruptionBudgetSpec `json:"pdb,omitempty"`
+
+	// Affinity defines the scheduling constraints of the Dex pods. It replaces the affinity set by the operator.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// TopologySpreadConstraints describes how the Dex pods are spread across topology domains.
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// PriorityClassName is the name of the PriorityClass of the Dex pods.
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+}
+
+// DexConfig defines the Dex configuration used by Argo CD.
+type DexConfig struct {
+	// Connectors are the upstream identity providers used by Dex.
+	// +listType=map
+	// +listMapKey=id
+	Connectors []DexConnector `json:"connectors,omitempty"`
+}
+
+// DexConnector defines a single Dex connector.
+type DexConnector struct {
+	// Type is the connector type, for example github, ldap or oidc.
+	// +kubebuilder:validation:MinLength=1
+	Type string `json:"type"`
+
+	// ID is the unique identifier of the connector.
+	// +kubebuilder:validation:MinLength=1
+	ID string `json:"id"`
+
+	// Name is the display name of the connector.
+	Name string `json:"name,omitempty"`
+
+	// Config is the connector specific configuration.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	Config *runtime.RawExtension `json:"config,omitempty"`
+}
+
+// OIDCConfig defines the OIDC provider used by Argo CD as an alternative to Dex.
+type OIDCConfig struct {
+	// Name is the display name of the provider on the login page.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Issuer is the URL of the OIDC issuer.
+	// +kubebuilder:validation:MinLength=1
+	Issuer string `json:"issuer"`
+
+	// ClientID is the OIDC client ID used by Argo CD.
+	// +kubebuilder:validation:MinLength=1
+	ClientID string `json:"clientID"`
+
+	// ClientSecret is the OIDC client secret, typically a reference to a key in the argocd-secret such as $oidc.clientSecret.
+	ClientSecret string `json:"clientSecret,omitempty"`
+
+	// CLIClientID is an optional client ID used by the Argo CD CLI.
+	CLIClientID string `json:"cliClientID,omitempty"`
+
+	// RequestedScopes are the scopes requested from the provider, defaults to openid, profile and email.
+	RequestedScopes []string `json:"requestedScopes,omitempty"`
+
+	// RequestedIDTokenClaims are the claims requested in the ID token.
+	RequestedIDTokenClaims map[string]OIDCClaim `json:"requestedIDTokenClaims,omitempty"`
+
+	// LogoutURL is the URL the user is redirected to after logging out of Argo CD.
+	LogoutURL string `json:"logoutURL,omitempty"`
+
+	// RootCA is the PEM encoded CA certificate used to verify the issuer.
+	RootCA string `json:"rootCA,omitempty"`
+}
+
+// OIDCClaim defines a claim requested in the OIDC ID token.
+type OIDCClaim struct {
+	// Essential marks the claim as required.
+	Essential bool `json:"essential,omitempty"`
+
+	// Value is the requested value of the claim.
+	Value string `json:"value,omitempty"`
+
+	// Values are the requested values of the claim.
+	Values []string `json:"values,omitempty"`
+}
+
+// ConfigManagementPlugin defines a config management plugin available to the repo server.
+type ConfigManagementPlugin struct {
+	// Name is the name of the plugin, as referenced by Applications.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Init is the command run in the application source directory before generating manifests.
+	Init *PluginCommand `json:"init,omitempty"`
+
+	// Generate is the command that writes the application manifests to stdout.
+	Generate PluginCommand `json:"generate"`
+
+	// LockRepo prevents concurrent manifest generation for the same repository.
+	LockRepo bool `json:"lockRepo,omitempty"`
+}
+
+// PluginCommand defines a command run by a config management plugin.
+type PluginCommand struct {
+	// Command is the command to run.
+	// +kubebuilder:validation:MinItems=1
+	Command []string `json:"command"`
+
+	// Args are the arguments passed to the command.
+	Args []string `json:"args,omitempty"`
+}
+
+// RepositoryCredential defines credentials used by every repository whose URL starts with the given prefix.
+type RepositoryCredential struct {
+	// URL is the repository URL, or the URL prefix for credential templates.
+	// +kubebuilder:validation:MinLength=1
+	URL string `json:"url"`
+
+	// Type is the type of repository.
+	// +kubebuilder:validation:Enum=git;helm
+	Type string `json:"type,omitempty"`
+
+	// UsernameSecret references the secret key holding the username.
+	UsernameSecret *corev1.SecretKeySelector `json:"usernameSecret,omitempty"`
+
+	// PasswordSecret references the secret key holding the password.
+	PasswordSecret *corev1.SecretKeySelector `json:"passwordSecret,omitempty"`
+
+	// SSHPrivateKeySecret references the secret key holding the SSH private key.
+	SSHPrivateKeySecret *corev1.SecretKeySelector `json:"sshPrivateKeySecret,omitempty"`
+
+	// TLSClientCertDataSecret references the secret key holding the TLS client certificate.
+	TLSClientCertDataSecret *corev1.SecretKeySelector `json:"tlsClientCertDataSecret,omitempty"`
+
+	// TLSClientCertKeySecret references the secret key holding the TLS client certificate key.
+	TLSClientCertKeySecret *corev1.SecretKeySelector `json:"tlsClientCertKeySecret,omitempty"`
+
+	// GithubAppPrivateKeySecret references the secret key holding the GitHub App private key.
+	GithubAppPrivateKeySecret *corev1.SecretKeySelector `json:"githubAppPrivateKeySecret,omitempty"`
+
+	// GithubAppID is the ID of the GitHub App used to access the repository.
+	GithubAppID int64 `json:"githubAppID,omitempty"`
+
+	// GithubAppInstallationID is the installation ID of the GitHub App used to access the repository.
+	GithubAppInstallationID int64 `json:"githubAppInstallationID,omitempty"`
+
+	// GithubAppEnterpriseBaseURL is the GitHub Enterprise API URL, if the GitHub App is not installed on github.com.
+	GithubAppEnterpriseBaseURL string `json:"githubAppEnterpriseBaseUrl,omitempty"`
+}
+
+// Repository defines a repository to configure Argo CD with.
+type Repository struct {
+	RepositoryCredential `json:",inline"`
+
+	// Name is the name of the repository, required for Helm repositories.
+	Name string `json:"name,omitempty"`
+
+	// Insecure skips verification of the repository server certificate.
+	Insecure bool `json:"insecure,omitempty"`
+
+	// InsecureIgnoreHostKey skips verification of the SSH host key.
+	InsecureIgnoreHostKey bool `json:"insecureIgnoreHostKey,omitempty"`
+
+	// EnableLFS enables Git LFS support for the repository.
+	EnableLFS bool `json:"enableLfs,omitempty"`
+
+	// EnableOCI enables OCI support for Helm repositories.
+	EnableOCI bool `json:"enableOCI,omitempty"`
+
+	// Proxy is the HTTP/HTTPS proxy used to access the repository.
+	Proxy string `json:"proxy,omitempty"`
+}
+
+// FilteredResource selects resources by API group, kind and cluster.
+type FilteredResource struct {
+	// APIGroups are the API groups to match, "*" matches all groups.
+	APIGroups []string `json:"apiGroups,omitempty"`
+
+	// Kinds are the resource kinds to match, "*" matches all kinds.
+	Kinds []string `json:"kinds,omitempty"`
+
+	// Clusters are the cluster URLs to match, "*" matches all clusters.
+	Clusters []string `json:"clusters,omitempty"`
+}
@@ -0,0 +1,480 @@
+// +build !ignore_autogenerated
+
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	"github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCD) DeepCopyInto(out *ArgoCD) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCD.
+func (in *ArgoCD) DeepCopy() *ArgoCD {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCD)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ArgoCD) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDDexSpec) DeepCopyInto(out *ArgoCDDexSpec) {
+	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(DexConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodTemplateOverride != nil {
+		in, out := &in.PodTemplateOverride, &out.PodTemplateOverride
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PDB != nil {
+		in, out := &in.PDB, &out.PDB
+		*out = new(v1alpha1.ArgoCDPodDisruptionBudgetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]v1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDDexSpec.
+func (in *ArgoCDDexSpec) DeepCopy() *ArgoCDDexSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDDexSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDList) DeepCopyInto(out *ArgoCDList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ArgoCD, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDList.
+func (in *ArgoCDList) DeepCopy() *ArgoCDList {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ArgoCDList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArgoCDSpec) DeepCopyInto(out *ArgoCDSpec) {
+	*out = *in
+	if in.ApplicationSet != nil {
+		in, out := &in.ApplicationSet, &out.ApplicationSet
+		*out = new(v1alpha1.ArgoCDApplicationSet)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigManagementPlugins != nil {
+		in, out := &in.ConfigManagementPlugins, &out.ConfigManagementPlugins
+		*out = make([]ConfigManagementPlugin, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Controller.DeepCopyInto(&out.Controller)
+	in.Dex.DeepCopyInto(&out.Dex)
+	in.Grafana.DeepCopyInto(&out.Grafana)
+	in.HA.DeepCopyInto(&out.HA)
+	if in.Import != nil {
+		in, out := &in.Import, &out.Import
+		*out = new(v1alpha1.ArgoCDImportSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InitialRepositories != nil {
+		in, out := &in.InitialRepositories, &out.InitialRepositories
+		*out = make([]Repository, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	out.InitialSSHKnownHosts = in.InitialSSHKnownHosts
+	if in.KustomizeVersions != nil {
+		in, out := &in.KustomizeVersions, &out.KustomizeVersions
+		*out = make([]v1alpha1.KustomizeVersionSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.LocalUsers != nil {
+		in, out := &in.LocalUsers, &out.LocalUsers
+		*out = make([]v1alpha1.LocalUserSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.NetworkPolicy.DeepCopyInto(&out.NetworkPolicy)
+	if in.OIDCConfig != nil {
+		in, out := &in.OIDCConfig, &out.OIDCConfig
+		*out = new(OIDCConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodePlacement != nil {
+		in, out := &in.NodePlacement, &out.NodePlacement
+		*out = new(v1alpha1.ArgoCDNodePlacementSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = new(v1alpha1.ArgoCDNotifications)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Prometheus.DeepCopyInto(&out.Prometheus)
+	in.RBAC.DeepCopyInto(&out.RBAC)
+	in.Redis.DeepCopyInto(&out.Redis)
+	in.Repo.DeepCopyInto(&out.Repo)
+	if in.RepositoryCredentials != nil {
+		in, out := &in.RepositoryCredentials, &out.RepositoryCredentials
+		*out = make([]RepositoryCredential, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ResourceCustomizations != nil {
+		in, out := &in.ResourceCustomizations, &out.ResourceCustomizations
+		*out = make([]v1alpha1.ResourceOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ResourceExclusions != nil {
+		in, out := &in.ResourceExclusions, &out.ResourceExclusions
+		*out = make([]FilteredResource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ResourceInclusions != nil {
+		in, out := &in.ResourceInclusions, &out.ResourceInclusions
+		*out = make([]FilteredResource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Server.DeepCopyInto(&out.Server)
+	if in.SSO != nil {
+		in, out := &in.SSO, &out.SSO
+		*out = new(v1alpha1.ArgoCDSSOSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	in.TLS.DeepCopyInto(&out.TLS)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArgoCDSpec.
+func (in *ArgoCDSpec) DeepCopy() *ArgoCDSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ArgoCDSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigManagementPlugin) DeepCopyInto(out *ConfigManagementPlugin) {
+	*out = *in
+	if in.Init != nil {
+		in, out := &in.Init, &out.Init
+		*out = new(PluginCommand)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Generate.DeepCopyInto(&out.Generate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigManagementPlugin.
+func (in *ConfigManagementPlugin) DeepCopy() *ConfigManagementPlugin {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigManagementPlugin)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DexConfig) DeepCopyInto(out *DexConfig) {
+	*out = *in
+	if in.Connectors != nil {
+		in, out := &in.Connectors, &out.Connectors
+		*out = make([]DexConnector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DexConfig.
+func (in *DexConfig) DeepCopy() *DexConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DexConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DexConnector) DeepCopyInto(out *DexConnector) {
+	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DexConnector.
+func (in *DexConnector) DeepCopy() *DexConnector {
+	if in == nil {
+		return nil
+	}
+	out := new(DexConnector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilteredResource) DeepCopyInto(out *FilteredResource) {
+	*out = *in
+	if in.APIGroups != nil {
+		in, out := &in.APIGroups, &out.APIGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Kinds != nil {
+		in, out := &in.Kinds, &out.Kinds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilteredResource.
+func (in *FilteredResource) DeepCopy() *FilteredResource {
+	if in == nil {
+		return nil
+	}
+	out := new(FilteredResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OIDCClaim) DeepCopyInto(out *OIDCClaim) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCClaim.
+func (in *OIDCClaim) DeepCopy() *OIDCClaim {
+	if in == nil {
+		return nil
+	}
+	out := new(OIDCClaim)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OIDCConfig) DeepCopyInto(out *OIDCConfig) {
+	*out = *in
+	if in.RequestedScopes != nil {
+		in, out := &in.RequestedScopes, &out.RequestedScopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RequestedIDTokenClaims != nil {
+		in, out := &in.RequestedIDTokenClaims, &out.RequestedIDTokenClaims
+		*out = make(map[string]OIDCClaim, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCConfig.
+func (in *OIDCConfig) DeepCopy() *OIDCConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OIDCConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PluginCommand) DeepCopyInto(out *PluginCommand) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PluginCommand.
+func (in *PluginCommand) DeepCopy() *PluginCommand {
+	if in == nil {
+		return nil
+	}
+	out := new(PluginCommand)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Repository) DeepCopyInto(out *Repository) {
+	*out = *in
+	in.RepositoryCredential.DeepCopyInto(&out.RepositoryCredential)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Repository.
+func (in *Repository) DeepCopy() *Repository {
+	if in == nil {
+		return nil
+	}
+	out := new(Repository)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RepositoryCredential) DeepCopyInto(out *RepositoryCredential) {
+	*out = *in
+	if in.UsernameSecret != nil {
+		in, out := &in.UsernameSecret, &out.UsernameSecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PasswordSecret != nil {
+		in, out := &in.PasswordSecret, &out.PasswordSecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SSHPrivateKeySecret != nil {
+		in, out := &in.SSHPrivateKeySecret, &out.SSHPrivateKeySecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLSClientCertDataSecret != nil {
+		in, out := &in.TLSClientCertDataSecret, &out.TLSClientCertDataSecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLSClientCertKeySecret != nil {
+		in, out := &in.TLSClientCertKeySecret, &out.TLSClientCertKeySecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GithubAppPrivateKeySecret != nil {
+		in, out := &in.GithubAppPrivateKeySecret, &out.GithubAppPrivateKeySecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepositoryCredential.
+func (in *RepositoryCredential) DeepCopy() *RepositoryCredential {
+	if in == nil {
+		return nil
+	}
+	out := new(RepositoryCredential)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -1,5 +1,15 @@
 dir "/data"
+{{- if eq .UseTLS "true"}}
+port 0
+tls-port 6379
+tls-cert-file {{.TLSPath}}/tls.crt
+tls-key-file {{.TLSPath}}/tls.key
+tls-ca-cert-file {{.TLSPath}}/ca.crt
+tls-auth-clients no
+tls-replication yes
+{{- else}}
 port 6379
+{{- end}}
 maxmemory 0
 maxmemory-policy volatile-lru
 min-replicas-max-lag 5
@@ -7,5 +17,15 @@ min-replicas-to-write 1
 rdbchecksum yes
 rdbcompression yes
 repl-diskless-sync yes
+{{- if eq .PersistenceEnabled "true"}}
+save 900 1
+save 300 10
+save 60 10000
+{{- else}}
 save ""
+{{- end}}
 protected-mode no
+{{- if eq .AuthEnabled "true"}}
+requirepass replace-default-auth
+masterauth replace-default-auth
+{{- end}}
@@ -1,5 +1,17 @@
 dir "/data"
-    sentinel down-after-milliseconds argocd 10000
-    sentinel failover-timeout argocd 180000
+    sentinel down-after-milliseconds argocd {{.DownAfterMilliseconds}}
+    sentinel failover-timeout argocd {{.FailoverTimeoutMilliseconds}}
     maxclients 10000
     sentinel parallel-syncs argocd 5
+{{- if eq .AuthEnabled "true"}}
+    sentinel auth-pass argocd replace-default-auth
+{{- end}}
+{{- if eq .UseTLS "true"}}
+    port 0
+    tls-port 26379
+    tls-cert-file {{.TLSPath}}/tls.crt
+    tls-key-file {{.TLSPath}}/tls.key
+    tls-ca-cert-file {{.TLSPath}}/ca.crt
+    tls-auth-clients no
+    tls-replication yes
+{{- end}}
@@ -12,4 +12,8 @@ const (
 	// AnnotationOpenShiftServiceCA is the annotation on services used to
 	// request a TLS certificate from OpenShift's Service CA for AutoTLS
 	AnnotationOpenShiftServiceCA = "service.beta.openshift.io/serving-cert-secret-name"
+
+	// AnnotationCertManagerCertificateName is the annotation set by cert-manager on
+	// the secrets it issues that specifies the name of the issuing Certificate
+	AnnotationCertManagerCertificateName = "cert-manager.io/certificate-name"
 )
@@ -14,6 +14,8 @@
 
 package common
 
+import "time"
+
 const (
 	// ArgoCDApplicationControllerComponent is the name of the application controller  control plane component
 	ArgoCDApplicationControllerComponent = "argocd-application-controller"
@@ -21,6 +23,15 @@ const (
 	// ArgoCDApplicationControllerDefaultShardReplicas is the default number of replicas that the ArgoCD Application Controller Should Use
 	ArgocdApplicationControllerDefaultReplicas = 1
 
+	// ArgoCDDefaultClustersPerShard is the default number of clusters managed by each Application Controller shard with dynamic scaling.
+	ArgoCDDefaultClustersPerShard = 1
+
+	// ArgoCDDefaultMinShards is the default minimum number of Application Controller shards with dynamic scaling.
+	ArgoCDDefaultMinShards = 1
+
+	// ArgoCDDefaultCMPServerUser is the user ID the Config Management Plugin sidecars run as.
+	ArgoCDDefaultCMPServerUser = 999
+
 	// ArgoCDDefaultLogLevel is the default log level to be used by all ArgoCD components.
 	ArgoCDDefaultLogLevel = "info"
 
@@ -48,9 +59,24 @@ const (
 	// ArgoCDDefaultApplicationSetImage is the Argo CD Application Set container image to use when not specified.
 	ArgoCDDefaultApplicationSetImage = "quay.io/argoproj/argocd-applicationset"
 
+	// ArgoCDDefaultApplicationSetMetricsPort is the default listen port for the ApplicationSet controller metrics.
+	ArgoCDDefaultApplicationSetMetricsPort = 8080
+
 	// ArgoCDDefaultApplicationSetVersion is the Argo CD Application Set image tag to use when not specified.
 	ArgoCDDefaultApplicationSetVersion = "v0.2.0"
 
+	// ArgoCDDefaultApplicationSetWebhookPort is the default listen port for the ApplicationSet controller webhook.
+	ArgoCDDefaultApplicationSetWebhookPort = 7000
+
+	// ArgoCDDefaultNotificationsImage is the Argo CD Notifications container image to use when not specified.
+	ArgoCDDefaultNotificationsImage = "quay.io/argoprojlabs/argocd-notifications"
+
+	// ArgoCDDefaultNotificationsMetricsPort is the default listen port for the Notifications controller metrics.
+	ArgoCDDefaultNotificationsMetricsPort = 9001
+
+	// ArgoCDDefaultNotificationsVersion is the Argo CD Notifications image tag to use when not specified.
+	ArgoCDDefaultNotificationsVersion = "v1.1.1"
+
 	// ArgoCDDefaultApplicationInstanceLabelKey is the default app name as a tracking label.
 	ArgoCDDefaultApplicationInstanceLabelKey = "app.kubernetes.io/instance"
 
@@ -60,6 +86,15 @@ const (
 	// ArgoCDDefaultArgoVersion is the Argo CD container image digest to use when version not specified.
 	ArgoCDDefaultArgoVersion = "sha256:2e0e725c9282bfb7b08bea7dbbfd8dbce6410d670e3f8addd9b6540d818ad520" // v2.1.2
 
+	// ArgoCDDefaultAutoscaleMaxReplicas is the default upper limit for the number of replicas of an autoscaled component.
+	ArgoCDDefaultAutoscaleMaxReplicas = int32(3)
+
+	// ArgoCDDefaultAutoscaleMinReplicas is the default lower limit for the number of replicas of an autoscaled component.
+	ArgoCDDefaultAutoscaleMinReplicas = int32(1)
+
+	// ArgoCDDefaultAutoscaleTargetCPUUtilization is the default average CPU utilization targeted by the autoscaler of a component.
+	ArgoCDDefaultAutoscaleTargetCPUUtilization = int32(50)
+
 	// ArgoCDDefaultBackupKeyLength is the length of the generated default backup key.
 	ArgoCDDefaultBackupKeyLength = 32
 
@@ -69,9 +104,21 @@ const (
 	// ArgoCDDefaultBackupKeyNumSymbols is the number of symbols to use for the generated default backup key.
 	ArgoCDDefaultBackupKeyNumSymbols = 5
 
+	// ArgoCDDefaultCertManagerIssuerGroup is the default API group of the cert-manager issuer of the Argo CD certificates.
+	ArgoCDDefaultCertManagerIssuerGroup = "cert-manager.io"
+
+	// ArgoCDDefaultCertManagerIssuerKind is the default kind of the cert-manager issuer of the Argo CD certificates.
+	ArgoCDDefaultCertManagerIssuerKind = "Issuer"
+
+	// ArgoCDDefaultCertificateRenewBefore is the default time before the expiry at which the operator re-issues the CA and certificates it generated.
+	ArgoCDDefaultCertificateRenewBefore = time.Hour * 24 * 30
+
 	// ArgoCDDefaultConfigManagementPlugins is the default configuration value for the config management plugins.
 	ArgoCDDefaultConfigManagementPlugins = ""
 
+	// ArgoCDDefaultControllerMetricsPort is the default listen port for the Argo CD application controller metrics.
+	ArgoCDDefaultControllerMetricsPort = 8082
+
 	// ArgoCDDefaultControllerResourceLimitCPU is the default CPU limit when not specified for the Argo CD application
 	// controller contianer.
 	ArgoCDDefaultControllerResourceLimitCPU = "1000m"
@@ -178,6 +225,12 @@ const (
 	// ArgoCDDefaultOIDCConfig is the default OIDC configuration.
 	ArgoCDDefaultOIDCConfig = ""
 
+	// ArgoCDDefaultPDBMaxUnavailable is the default number of pods that can be unavailable during a voluntary disruption.
+	ArgoCDDefaultPDBMaxUnavailable = 1
+
+	// ArgoCDDefaultPrometheusPort is the default listen port for Prometheus.
+	ArgoCDDefaultPrometheusPort = 9090
+
 	// ArgoCDDefaultPrometheusReplicas is the default Prometheus replica count.
 	ArgoCDDefaultPrometheusReplicas = int32(1)
 
@@ -196,6 +249,15 @@ const (
 	// ArgoCDDefaultRedisHAReplicas is the defaul number of replicas for Redis when rinning in HA mode.
 	ArgoCDDefaultRedisHAReplicas = int32(3)
 
+	// ArgoCDDefaultRedisSentinelDownAfter is the default time a Redis master is unreachable before a Sentinel considers it down.
+	ArgoCDDefaultRedisSentinelDownAfter = 10 * time.Second
+
+	// ArgoCDDefaultRedisSentinelFailoverTimeout is the default failover timeout of the Redis Sentinels.
+	ArgoCDDefaultRedisSentinelFailoverTimeout = 3 * time.Minute
+
+	// ArgoCDDefaultRedisSentinelQuorum is the default number of Sentinels that need to agree a Redis master is down.
+	ArgoCDDefaultRedisSentinelQuorum = int32(2)
+
 	// ArgoCDDefaultRedisHAProxyImage is the default Redis HAProxy image to use when not specified.
 	ArgoCDDefaultRedisHAProxyImage = "haproxy"
 
@@ -208,6 +270,9 @@ const (
 	// ArgoCDDefaultRedisPort is the default listen port for Redis.
 	ArgoCDDefaultRedisPort = 6379
 
+	// ArgoCDDefaultRedisTLSPath is the path the Redis TLS certificate is mounted at in the Redis and Argo CD containers.
+	ArgoCDDefaultRedisTLSPath = "/app/config/redis/tls"
+
 	// ArgoCDDefaultRedisSentinelPort is the default listen port for Redis sentinel.
 	ArgoCDDefaultRedisSentinelPort = 26379
 
@@ -244,6 +309,9 @@ const (
 	// ArgoCDDefaultRSAKeySize is the default RSA key size when not specified.
 	ArgoCDDefaultRSAKeySize = 2048
 
+	// ArgoCDDefaultServerMetricsPort is the default listen port for the Argo CD server metrics.
+	ArgoCDDefaultServerMetricsPort = 8083
+
 	// ArgoCDDefaultServerOperationProcessors is the number of ArgoCD Server Operation Processors to use when not specified.
 	ArgoCDDefaultServerOperationProcessors = int32(10)
 
@@ -253,6 +321,9 @@ const (
 	// ArgoCDDefaultControllerParellelismLimit is the default parallelism limit for application controller
 	ArgoCDDefaultControllerParallelismLimit = int32(10)
 
+	// ArgoCDDefaultServerPort is the default listen port for the Argo CD server.
+	ArgoCDDefaultServerPort = 8080
+
 	// ArgoCDDefaultServerResourceLimitCPU is the default CPU limit when not specified for the Argo CD server contianer.
 	ArgoCDDefaultServerResourceLimitCPU = "1000m"
 
@@ -19,6 +19,9 @@ import (
 )
 
 const (
+	// ArgoCDKeyAccounts is the prefix of the configuration keys for Argo CD local accounts.
+	ArgoCDKeyAccounts = "accounts"
+
 	// ArgoCDKeyAdminEnabled is the configuration key for the admin enabled setting..
 	ArgoCDKeyAdminEnabled = "admin.enabled"
 
@@ -94,6 +97,18 @@ const (
 	// ArgoCDKeyName is the resource name key for labels.
 	ArgoCDKeyName = "app.kubernetes.io/name"
 
+	// ArgoCDKeyNotificationsContext is the configuration key for the Argo CD Notifications template context.
+	ArgoCDKeyNotificationsContext = "context"
+
+	// ArgoCDKeyNotificationsService is the prefix of the configuration keys for Argo CD Notifications services.
+	ArgoCDKeyNotificationsService = "service"
+
+	// ArgoCDKeyNotificationsTemplate is the prefix of the configuration keys for Argo CD Notifications templates.
+	ArgoCDKeyNotificationsTemplate = "template"
+
+	// ArgoCDKeyNotificationsTrigger is the prefix of the configuration keys for Argo CD Notifications triggers.
+	ArgoCDKeyNotificationsTrigger = "trigger"
+
 	// ArgoCDKeyOIDCConfig is the configuration key for the OIDC configuration.
 	ArgoCDKeyOIDCConfig = "oidc.config"
 
@@ -118,12 +133,33 @@ const (
 	// ArgoCDKeyRBACScopes is the configuration key for the Argo CD RBAC scopes.
 	ArgoCDKeyRBACScopes = "scopes"
 
+	// ArgoCDKeyRedisAuth is the key for the Redis password in the Redis auth Secret.
+	ArgoCDKeyRedisAuth = "auth"
+
 	// ArgoCDKeyRelease is the prometheus release key for labels.
 	ArgoCDKeyRelease = "release"
 
 	// ArgoCDKeyResourceCustomizations is the configuration key for resource customizations.
 	ArgoCDKeyResourceCustomizations = "resource.customizations"
 
+	// ArgoCDKeyResourceCustomizationsActions is the configuration key prefix for the actions of a resource group/kind.
+	ArgoCDKeyResourceCustomizationsActions = "resource.customizations.actions"
+
+	// ArgoCDKeyResourceCustomizationsHealth is the configuration key prefix for the health check of a resource group/kind.
+	ArgoCDKeyResourceCustomizationsHealth = "resource.customizations.health"
+
+	// ArgoCDKeyResourceCustomizationsIgnoreDifferences is the configuration key prefix for the ignored differences of
+	// a resource group/kind.
+	ArgoCDKeyResourceCustomizationsIgnoreDifferences = "resource.customizations.ignoreDifferences"
+
+	// ArgoCDKeyResourceCustomizationsKnownTypeFields is the configuration key prefix for the known type fields of a
+	// resource group/kind.
+	ArgoCDKeyResourceCustomizationsKnownTypeFields = "resource.customizations.knownTypeFields"
+
+	// ArgoCDKeyResourceCustomizationsUseOpenLibs is the configuration key prefix that gives the health check of a
+	// resource group/kind access to the standard Lua libraries.
+	ArgoCDKeyResourceCustomizationsUseOpenLibs = "resource.customizations.useOpenLibs"
+
 	// ArgoCDKeyResourceExclusions is the configuration key for resource exclusions.
 	ArgoCDKeyResourceExclusions = "resource.exclusions"
 
@@ -171,6 +207,10 @@ const (
 	// for the ApplicationSet controller
 	ArgoCDApplicationSetEnvName = "ARGOCD_APPLICATIONSET_IMAGE"
 
+	// ArgoCDNotificationsEnvName is the environment variable used to get the image
+	// for the Notifications controller
+	ArgoCDNotificationsEnvName = "ARGOCD_NOTIFICATIONS_IMAGE"
+
 	// ArgoCDDexImageEnvName is the environment variable used to get the image
 	// to used for the Dex container.
 	ArgoCDDexImageEnvName = "ARGOCD_DEX_IMAGE"
@@ -208,6 +248,54 @@ const (
 	// ArgoCDSecretTypeLabel is needed for cluster secrets
 	ArgoCDSecretTypeLabel = "argocd.argoproj.io/secret-type"
 
+	// ArgoCDShardWeightAnnotation is the default cluster secret annotation holding the weight of the cluster for the balanced shard assignment.
+	ArgoCDShardWeightAnnotation = "argocd.argoproj.io/shard-weight"
+
+	// ArgoCDShardAssignedAnnotation marks cluster secrets whose shard has been assigned by the operator.
+	ArgoCDShardAssignedAnnotation = "argocd.argoproj.io/shard-assigned"
+
 	// ArgoCDManagedByLabel is needed to identify namespace managed by an instance on ArgoCD
 	ArgoCDManagedByLabel = "argocd.argoproj.io/managed-by"
+
+	// ArgoCDCMPPluginLabel identifies the ConfigMap holding the definition of a Config Management Plugin sidecar.
+	ArgoCDCMPPluginLabel = "argocd.argoproj.io/cmp-plugin"
+
+	// ArgoCDCMPPluginsChecksumAnnotation is the checksum of the Config Management Plugin definitions used to roll out the repo server on changes.
+	ArgoCDCMPPluginsChecksumAnnotation = "argocd.argoproj.io/cmp-plugins-checksum"
+
+	// ArgoCDPodTemplateOverrideChecksumAnnotation is the checksum of the pod template override applied to the pod template of a component.
+	ArgoCDPodTemplateOverrideChecksumAnnotation = "argocd.argoproj.io/pod-template-override-checksum"
+
+	// ArgoCDRedisHAConfigChecksumAnnotation is the checksum of the Redis HA configuration used to roll out the Redis HA pods on changes.
+	ArgoCDRedisHAConfigChecksumAnnotation = "checksum/init-config"
+
+	// ArgoCDKeyCMPPluginConfig is the key for the plugin definition in a Config Management Plugin ConfigMap.
+	ArgoCDKeyCMPPluginConfig = "plugin.yaml"
+
+	// ArgoCDLocalUserLabel identifies the Secret holding the generated credentials of a local user.
+	ArgoCDLocalUserLabel = "argocd.argoproj.io/local-user"
+
+	// ArgoCDLocalUsersAnnotation lists the local users that have been rendered into argocd-cm by the operator.
+	ArgoCDLocalUsersAnnotation = "argocd.argoproj.io/local-users"
+
+	// ArgoCDRBACRolesAnnotation marks the RBAC ConfigMap whose policy contains the typed roles and bindings rendered by the operator.
+	ArgoCDRBACRolesAnnotation = "argocd.argoproj.io/rbac-roles"
+
+	// ArgoCDManagedKeysAnnotation lists the keys of a Secret that are managed by the operator.
+	ArgoCDManagedKeysAnnotation = "argocd.argoproj.io/managed-keys"
+
+	// ArgoCDKeyLocalUserAPIToken is the key for the generated API token in a local user Secret.
+	ArgoCDKeyLocalUserAPIToken = "apiToken"
+
+	// ArgoCDKeyLocalUserPassword is the key for the generated password in a local user Secret.
+	ArgoCDKeyLocalUserPassword = "password"
+
+	// ArgoCDKeyLocalUserUsername is the key for the user name in a local user Secret.
+	ArgoCDKeyLocalUserUsername = "username"
+
+	// ArgoCDKeyWebhookGitHubSecret is the key of the GitHub webhook secret in the Argo CD secret.
+	ArgoCDKeyWebhookGitHubSecret = "webhook.github.secret"
+
+	// ArgoCDKeyWebhookGitLabSecret is the key of the GitLab webhook secret in the Argo CD secret.
+	ArgoCDKeyWebhookGitLabSecret = "webhook.gitlab.secret"
 )
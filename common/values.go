@@ -62,6 +62,12 @@ const (
 	// ArgoCDRedisProbesConfigMapName is the upstream ArgoCD Redis Probes ConfigMap name.
 	ArgoCDRedisProbesConfigMapName = "argocd-redis-ha-probes"
 
+	// ArgoCDNotificationsConfigMapName is the upstream hard-coded Argo CD Notifications ConfigMap name.
+	ArgoCDNotificationsConfigMapName = "argocd-notifications-cm"
+
+	// ArgoCDNotificationsSecretName is the upstream hard-coded Argo CD Notifications Secret name.
+	ArgoCDNotificationsSecretName = "argocd-notifications-secret"
+
 	// ArgoCDRBACConfigMapName is the upstream hard-coded RBAC ConfigMap name.
 	ArgoCDRBACConfigMapName = "argocd-rbac-cm"
 
@@ -79,4 +85,10 @@ const (
 
 	// ArgoCDServerTLSSecretName is the name of the TLS secret for the argocd-server
 	ArgoCDServerTLSSecretName = "argocd-server-tls"
+
+	// ArgoCDRedisExternalCAVolumeName is the name of the volume of the CA certificate of an external Redis.
+	ArgoCDRedisExternalCAVolumeName = "argocd-redis-external-ca"
+
+	// ArgoCDRedisServerTLSSecretName is the name of the TLS secret for Redis
+	ArgoCDRedisServerTLSSecretName = "argocd-operator-redis-tls"
 )
@@ -170,6 +170,12 @@ func (r *ReconcileArgoCD) reconcileApplicationSetDeployment(cr *argoprojv1a1.Arg
 		},
 	}}
 
+	applyPodScheduling(&deploy.Spec.Template.Spec, cr.Spec.ApplicationSet.Affinity, cr.Spec.ApplicationSet.TopologySpreadConstraints, cr.Spec.ApplicationSet.PriorityClassName)
+
+	if err := applyPodTemplateOverride(&deploy.Spec.Template, cr.Spec.ApplicationSet.PodTemplateOverride); err != nil {
+		return err
+	}
+
 	if existing := newDeploymentWithSuffix("applicationset-controller", "controller", cr); argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
 
 		existingSpec := existing.Spec.Template.Spec
@@ -193,6 +199,12 @@ func (r *ReconcileArgoCD) reconcileApplicationSetDeployment(cr *argoprojv1a1.Arg
 			existing.Spec.Selector = deploy.Spec.Selector
 			existing.Spec.Template.Spec.NodeSelector = deploy.Spec.Template.Spec.NodeSelector
 			existing.Spec.Template.Spec.Tolerations = deploy.Spec.Template.Spec.Tolerations
+		}
+		updatePodScheduling(&existing.Spec.Template.Spec, &deploy.Spec.Template.Spec, &deploymentsDifferent)
+		if err := updatePodTemplateOverride(&existing.Spec.Template, &deploy.Spec.Template, cr.Spec.ApplicationSet.PodTemplateOverride, &deploymentsDifferent); err != nil {
+			return err
+		}
+		if deploymentsDifferent {
 			return r.Client.Update(context.TODO(), existing)
 		}
 		return nil // Deployment found with nothing to do, move along...
@@ -420,6 +432,65 @@ func getApplicationSetResources(cr *argoprojv1a1.ArgoCD) corev1.ResourceRequirem
 	return resources
 }
 
+// getApplicationSetWebhookServerHost will return the host of the ApplicationSet controller webhook server.
+func getApplicationSetWebhookServerHost(cr *argoprojv1a1.ArgoCD) string {
+	host := nameWithSuffix("applicationset-controller", cr)
+	if cr.Spec.ApplicationSet != nil && len(cr.Spec.ApplicationSet.WebhookServer.Host) > 0 {
+		host = cr.Spec.ApplicationSet.WebhookServer.Host
+	}
+	return host
+}
+
+// getApplicationSetWebhookSecretRefs will return the referenced webhook secrets of the ApplicationSet controller,
+// keyed by their property name in the Argo CD secret.
+func getApplicationSetWebhookSecretRefs(cr *argoprojv1a1.ArgoCD) map[string]*corev1.SecretKeySelector {
+	refs := make(map[string]*corev1.SecretKeySelector)
+	if cr.Spec.ApplicationSet == nil {
+		return refs
+	}
+
+	if ref := cr.Spec.ApplicationSet.WebhookServer.GitHubSecretRef; ref != nil {
+		refs[common.ArgoCDKeyWebhookGitHubSecret] = ref
+	}
+	if ref := cr.Spec.ApplicationSet.WebhookServer.GitLabSecretRef; ref != nil {
+		refs[common.ArgoCDKeyWebhookGitLabSecret] = ref
+	}
+	return refs
+}
+
+// reconcileApplicationSetWebhookSecrets will copy the referenced webhook secrets of the ApplicationSet controller
+// into the given Argo CD secret. It returns true when the Argo CD secret has changed.
+func (r *ReconcileArgoCD) reconcileApplicationSetWebhookSecrets(cr *argoprojv1a1.ArgoCD, argoSecret *corev1.Secret) (bool, error) {
+	changed := false
+
+	for key, ref := range getApplicationSetWebhookSecretRefs(cr) {
+		secret := argoutil.NewSecretWithName(cr, ref.Name)
+		if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: ref.Name, Namespace: cr.Namespace}, secret); err != nil {
+			if !errors.IsNotFound(err) {
+				return false, err
+			}
+			log.Info(fmt.Sprintf("webhook secret [%s] not found, skipping argo secret property [%s]", ref.Name, key))
+			continue
+		}
+
+		value, ok := secret.Data[ref.Key]
+		if !ok {
+			log.Info(fmt.Sprintf("key [%s] not found in webhook secret [%s], skipping argo secret property [%s]", ref.Key, ref.Name, key))
+			continue
+		}
+
+		if argoSecret.Data == nil {
+			argoSecret.Data = make(map[string][]byte)
+		}
+		if !reflect.DeepEqual(argoSecret.Data[key], value) {
+			argoSecret.Data[key] = value
+			changed = true
+		}
+	}
+
+	return changed, nil
+}
+
 func setAppSetLabels(obj *metav1.ObjectMeta) {
 	obj.Labels["app.kubernetes.io/name"] = "argocd-applicationset-controller"
 	obj.Labels["app.kubernetes.io/part-of"] = "argocd-applicationset"
@@ -25,11 +25,13 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	resourcev1 "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/argoproj-labs/argocd-operator/api/v1alpha1"
 	"github.com/argoproj-labs/argocd-operator/common"
@@ -431,3 +433,84 @@ func appsetAssertExpectedLabels(t *testing.T, meta *metav1.ObjectMeta) {
 	assert.Equal(t, meta.Labels["app.kubernetes.io/part-of"], "argocd-applicationset")
 	assert.Equal(t, meta.Labels["app.kubernetes.io/component"], "controller")
 }
+
+func TestReconcileApplicationSet_WebhookServer(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	a.Spec.ApplicationSet = &v1alpha1.ArgoCDApplicationSet{
+		WebhookServer: v1alpha1.ArgoCDApplicationSetWebhookServerSpec{
+			Host: "appset.example.com",
+			Ingress: v1alpha1.ArgoCDIngressSpec{
+				Enabled: true,
+			},
+		},
+	}
+	r := makeTestReconciler(t, a)
+
+	assert.NilError(t, r.reconcileServices(a))
+	assert.NilError(t, r.reconcileIngresses(a))
+
+	svc := &corev1.Service{}
+	assert.NilError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-applicationset-controller", Namespace: a.Namespace}, svc))
+	assert.Equal(t, svc.Spec.Selector[common.ArgoCDKeyName], "argocd-applicationset-controller")
+	assert.Equal(t, svc.Spec.Ports[0].Name, "webhook")
+	assert.Equal(t, svc.Spec.Ports[0].Port, int32(common.ArgoCDDefaultApplicationSetWebhookPort))
+
+	ingress, err := getTestIngress(t, r, "argocd-applicationset-controller")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, getTestIngressHosts(ingress), []string{"appset.example.com"})
+	backend := ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service
+	assert.Equal(t, backend.Name, "argocd-applicationset-controller")
+	assert.Equal(t, backend.Port.Name, "webhook")
+
+	// Removing the ApplicationSet controller removes the resources exposing its webhook server.
+	a.Spec.ApplicationSet = nil
+	assert.NilError(t, r.reconcileServices(a))
+	assert.NilError(t, r.reconcileIngresses(a))
+
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-applicationset-controller", Namespace: a.Namespace}, svc)
+	assert.Assert(t, apierrors.IsNotFound(err))
+	_, err = getTestIngress(t, r, "argocd-applicationset-controller")
+	assert.Assert(t, apierrors.IsNotFound(err))
+}
+
+func TestReconcileApplicationSet_WebhookSecrets(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	a.Spec.ApplicationSet = &v1alpha1.ArgoCDApplicationSet{
+		WebhookServer: v1alpha1.ArgoCDApplicationSetWebhookServerSpec{
+			GitHubSecretRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "appset-webhooks"},
+				Key:                  "github",
+			},
+			GitLabSecretRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "missing-webhooks"},
+				Key:                  "gitlab",
+			},
+		},
+	}
+	webhookSecret := argoutil.NewSecretWithName(a, "appset-webhooks")
+	webhookSecret.Data = map[string][]byte{"github": []byte("s3cr3t")}
+	r := makeTestReconciler(t, a, webhookSecret)
+
+	argoSecret := argoutil.NewSecretWithName(a, common.ArgoCDSecretName)
+	changed, err := r.reconcileApplicationSetWebhookSecrets(a, argoSecret)
+	assert.NilError(t, err)
+	assert.Assert(t, changed)
+	assert.Equal(t, string(argoSecret.Data[common.ArgoCDKeyWebhookGitHubSecret]), "s3cr3t")
+
+	// Secrets that can't be found are skipped.
+	_, found := argoSecret.Data[common.ArgoCDKeyWebhookGitLabSecret]
+	assert.Assert(t, !found)
+
+	changed, err = r.reconcileApplicationSetWebhookSecrets(a, argoSecret)
+	assert.NilError(t, err)
+	assert.Assert(t, !changed)
+
+	// Changes to the referenced secrets reconcile the ArgoCD referencing them.
+	assert.DeepEqual(t, r.appSetWebhookSecretMapper(webhookSecret), []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Name: a.Name, Namespace: a.Namespace}},
+	})
+	other := argoutil.NewSecretWithName(a, "other")
+	assert.DeepEqual(t, r.appSetWebhookSecretMapper(other), []reconcile.Request{})
+}
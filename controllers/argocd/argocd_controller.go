@@ -50,7 +50,10 @@ var log = logr.Log.WithName("controller_argocd")
 //+kubebuilder:rbac:groups=argoproj.io,resources=argocds;argocds/finalizers;argocds/status,verbs=*
 //+kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=*
 //+kubebuilder:rbac:groups=batch,resources=cronjobs;jobs,verbs=*
-//+kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=*
+//+kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=*
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes;grpcroutes,verbs=*
+//+kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses;networkpolicies,verbs=*
+//+kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=*
 //+kubebuilder:rbac:groups=monitoring.coreos.com,resources=prometheuses;servicemonitors,verbs=*
 //+kubebuilder:rbac:groups=route.openshift.io,resources=routes;routes/custom-host,verbs=*
 //+kubebuilder:rbac:groups=argoproj.io,resources=applications;appprojects,verbs=*
@@ -76,6 +79,7 @@ func (r *ReconcileArgoCD) Reconcile(ctx context.Context, request ctrl.Request) (
 			// Request object not found, could have been deleted after reconcile request.
 			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
 			// Return and don't requeue
+			deleteExternalRedisProbe(request.NamespacedName)
 			return reconcile.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
@@ -96,6 +100,8 @@ func (r *ReconcileArgoCD) Reconcile(ctx context.Context, request ctrl.Request) (
 				return reconcile.Result{}, err
 			}
 		}
+		deleteCertificateExpiryMetrics(argocd)
+		deleteExternalRedisProbe(request.NamespacedName)
 		return reconcile.Result{}, nil
 	}
 
@@ -111,17 +117,32 @@ func (r *ReconcileArgoCD) Reconcile(ctx context.Context, request ctrl.Request) (
 	}
 
 	if err := r.reconcileResources(argocd); err != nil {
+		if statusErr := r.reconcileStatusReconcileResult(argocd, err); statusErr != nil {
+			reqLogger.Error(statusErr, "failed to update reconcile status condition")
+		}
 		// Error reconciling ArgoCD sub-resources - requeue the request.
 		return reconcile.Result{}, err
 	}
 
-	// Return and don't requeue
-	return reconcile.Result{}, nil
+	if err := r.reconcileStatusReconcileResult(argocd, nil); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	// Requeue when an API token of a local user or a certificate is due for renewal, or to refresh the status of an
+	// external Redis.
+	requeueAfter := r.getLocalUserTokenRequeueAfter(argocd)
+	if d := getCertificateRequeueAfter(argocd); d > 0 && (requeueAfter == 0 || d < requeueAfter) {
+		requeueAfter = d
+	}
+	if d := getExternalRedisRequeueAfter(argocd); d > 0 && (requeueAfter == 0 || d < requeueAfter) {
+		requeueAfter = d
+	}
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ReconcileArgoCD) SetupWithManager(mgr ctrl.Manager) error {
 	bldr := ctrl.NewControllerManagedBy(mgr)
-	setResourceWatches(bldr, r.clusterResourceMapper, r.tlsSecretMapper, r.namespaceResourceMapper)
+	setResourceWatches(bldr, r.clusterResourceMapper, r.tlsSecretMapper, r.namespaceResourceMapper, r.clusterSecretMapper, r.appSetWebhookSecretMapper)
 	return bldr.Complete(r)
 }
@@ -73,6 +73,24 @@ func TestReconcileArgoCD_Reconcile_with_deleted(t *testing.T) {
 	}
 }
 
+func TestReconcileArgoCD_Reconcile_deletedExternalRedisProbe(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(deletedAt(time.Now()))
+	r := makeTestReconciler(t, a)
+	key := types.NamespacedName{Name: a.Name, Namespace: a.Namespace}
+
+	defer func() {
+		externalRedisProbes = map[types.NamespacedName]*externalRedisProbe{}
+	}()
+	externalRedisProbes[key] = &externalRedisProbe{address: "redis.cache.example.com:6379", checked: time.Now()}
+
+	_, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: key})
+	assert.NilError(t, err)
+
+	_, found := externalRedisProbes[key]
+	assert.Assert(t, !found)
+}
+
 func TestReconcileArgoCD_Reconcile(t *testing.T) {
 	logf.SetLogger(ZapLogger(true))
 	a := makeTestArgoCD()
@@ -0,0 +1,177 @@
+// Copyright 2021 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	argoprojv1a1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// certificateExpiryGauge reports the expiry of the CA and certificates generated by the operator.
+var certificateExpiryGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "argocd_operator_certificate_expiry_timestamp_seconds",
+	Help: "The expiry of the CA and certificates generated by the operator, in seconds since the epoch.",
+}, []string{"namespace", "argocd", "secret"})
+
+func init() {
+	metrics.Registry.MustRegister(certificateExpiryGauge)
+}
+
+// getCertificateSecretNames returns the names of the Secrets containing the CA and certificates generated by the operator.
+// The CA comes first, the TLS Secrets generated for the Ingresses come last.
+func getCertificateSecretNames(cr *argoprojv1a1.ArgoCD) []string {
+	names := []string{nameWithSuffix(common.ArgoCDCASuffix, cr), nameWithSuffix("tls", cr), common.ArgoCDRedisServerTLSSecretName}
+	return append(names, getIngressTLSSecretNames(cr)...)
+}
+
+// getIngressTLSSecretNames returns the names of the TLS Secrets the operator may generate for the Ingresses of the given ArgoCD.
+func getIngressTLSSecretNames(cr *argoprojv1a1.ArgoCD) []string {
+	names := []string{}
+	for _, suffix := range []string{"applicationset-controller", "server", "grpc", "grafana", "prometheus"} {
+		names = append(names, getIngressTLSSecretName(nameWithSuffix(suffix, cr)))
+	}
+	return names
+}
+
+// deleteCertificateExpiryMetrics will remove the certificate expiry metrics of the given ArgoCD.
+func deleteCertificateExpiryMetrics(cr *argoprojv1a1.ArgoCD) {
+	for _, name := range getCertificateSecretNames(cr) {
+		certificateExpiryGauge.DeleteLabelValues(cr.Namespace, cr.Name, name)
+	}
+}
+
+// getCertificateRenewBefore returns how long before the expiry the operator re-issues the CA and certificates of the given ArgoCD.
+func getCertificateRenewBefore(cr *argoprojv1a1.ArgoCD) time.Duration {
+	if cr.Spec.TLS.RenewBefore != nil {
+		return cr.Spec.TLS.RenewBefore.Duration
+	}
+	return common.ArgoCDDefaultCertificateRenewBefore
+}
+
+// getCertificateRenewalTime returns when the operator re-issues the given certificate.
+// The renewal window is capped to half the lifetime of the certificate, so that a renewed certificate is not renewed again right away.
+func getCertificateRenewalTime(cert *x509.Certificate, cr *argoprojv1a1.ArgoCD) time.Time {
+	renewBefore := getCertificateRenewBefore(cr)
+	if lifetime := cert.NotAfter.Sub(cert.NotBefore); renewBefore > lifetime/2 {
+		renewBefore = lifetime / 2
+	}
+	return cert.NotAfter.Add(-renewBefore).Truncate(time.Second)
+}
+
+// isCertificateDueForRenewal returns whether the given certificate is within the renewal window of the given ArgoCD.
+func isCertificateDueForRenewal(cert *x509.Certificate, cr *argoprojv1a1.ArgoCD) bool {
+	return !time.Now().Before(getCertificateRenewalTime(cert, cr))
+}
+
+// isSelfSignedCertificate returns whether the given certificate is a self-signed CA, as generated by the operator.
+func isSelfSignedCertificate(cert *x509.Certificate) bool {
+	return cert.IsCA && cert.CheckSignatureFrom(cert) == nil
+}
+
+// parseSecretCertificate returns the certificate in the tls.crt key of the given Secret.
+func parseSecretCertificate(secret *corev1.Secret) (*x509.Certificate, error) {
+	return argoutil.ParsePEMEncodedCert(secret.Data[corev1.TLSCertKey])
+}
+
+// parseSecretCA returns the CA certificate and key in the given Secret.
+func parseSecretCA(secret *corev1.Secret) (*x509.Certificate, *rsa.PrivateKey, error) {
+	caCert, err := parseSecretCertificate(secret)
+	if err != nil {
+		return nil, nil, err
+	}
+	caKey, err := argoutil.ParsePEMEncodedPrivateKey(secret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return nil, nil, err
+	}
+	return caCert, caKey, nil
+}
+
+// getCertificateStatus returns the status of the given certificate, with a renewal time when the operator re-issues it.
+func getCertificateStatus(secretName string, cert *x509.Certificate, renewed bool, cr *argoprojv1a1.ArgoCD) argoprojv1a1.ArgoCDCertificateStatus {
+	status := argoprojv1a1.ArgoCDCertificateStatus{
+		SecretName: secretName,
+		NotAfter:   metav1.NewTime(cert.NotAfter),
+	}
+	if renewed {
+		renewalTime := metav1.NewTime(getCertificateRenewalTime(cert, cr))
+		status.RenewalTime = &renewalTime
+	}
+	return status
+}
+
+// getCertificateRequeueAfter returns the time until the next renewal of the CA and certificates of the given ArgoCD,
+// or zero when there is none.
+func getCertificateRequeueAfter(cr *argoprojv1a1.ArgoCD) time.Duration {
+	var requeueAfter time.Duration
+	for _, certificate := range cr.Status.Certificates {
+		if certificate.RenewalTime == nil {
+			continue
+		}
+
+		d := time.Until(certificate.RenewalTime.Time)
+		if d < time.Second {
+			d = time.Second
+		}
+		if requeueAfter == 0 || d < requeueAfter {
+			requeueAfter = d
+		}
+	}
+	return requeueAfter
+}
+
+// renewClusterTLSSecret will re-issue the certificate in the given TLS Secret using the given CA.
+func (r *ReconcileArgoCD) renewClusterTLSSecret(secret *corev1.Secret, caCert *x509.Certificate, caKey *rsa.PrivateKey, cr *argoprojv1a1.ArgoCD) error {
+	renewed, err := newCertificateSecret("tls", caCert, caKey, cr)
+	if err != nil {
+		return err
+	}
+
+	log.Info(fmt.Sprintf("renewing tls secret [%s]", secret.Name))
+	secret.Data = renewed.Data
+	return r.Client.Update(context.TODO(), secret)
+}
+
+// renewRedisTLSSecret will re-issue the certificate in the given Redis TLS Secret using the given CA.
+func (r *ReconcileArgoCD) renewRedisTLSSecret(secret *corev1.Secret, caCert *x509.Certificate, caKey *rsa.PrivateKey, cr *argoprojv1a1.ArgoCD) error {
+	renewed, err := newRedisTLSSecret(caCert, caKey, cr)
+	if err != nil {
+		return err
+	}
+
+	log.Info(fmt.Sprintf("renewing redis tls secret [%s]", secret.Name))
+	secret.Data = renewed.Data
+	return r.Client.Update(context.TODO(), secret)
+}
+
+// isSecretSignedBy returns whether the given Secret exists and contains a certificate signed by the given CA.
+func (r *ReconcileArgoCD) isSecretSignedBy(secret *corev1.Secret, caCert *x509.Certificate) bool {
+	if !argoutil.IsObjectFound(r.Client, secret.Namespace, secret.Name, secret) {
+		return false
+	}
+	cert, err := parseSecretCertificate(secret)
+	return err == nil && cert.CheckSignatureFrom(caCert) == nil
+}
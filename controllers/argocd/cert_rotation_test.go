@@ -0,0 +1,213 @@
+package argocd
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoprojv1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// makeTestCertificateSecret returns a TLS Secret with a one-year certificate expiring at the given time,
+// signed by the given CA or self-signed as a CA when the given CA is nil.
+func makeTestCertificateSecret(t *testing.T, name string, notAfter time.Time, caCert *x509.Certificate, caKey *rsa.PrivateKey) *corev1.Secret {
+	t.Helper()
+	key, err := argoutil.NewPrivateKey()
+	assert.NilError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		NotBefore:             notAfter.Add(-common.ArgoCDDuration365Days),
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  caCert == nil,
+	}
+	parent, parentKey := tmpl, key
+	if caCert != nil {
+		parent, parentKey = caCert, caKey
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, key.Public(), parentKey)
+	assert.NilError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.NilError(t, err)
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace},
+		Type:       corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       argoutil.EncodeCertificatePEM(cert),
+			corev1.TLSPrivateKeyKey: argoutil.EncodePrivateKeyPEM(key),
+		},
+	}
+}
+
+func getTestSecretCertificate(t *testing.T, r *ReconcileArgoCD, name string) *x509.Certificate {
+	t.Helper()
+	secret := &corev1.Secret{}
+	assert.NilError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: testNamespace}, secret))
+	cert, err := parseSecretCertificate(secret)
+	assert.NilError(t, err)
+	return cert
+}
+
+func TestReconcileArgoCD_reconcileClusterCASecret_renewal(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	expiringCA := makeTestCertificateSecret(t, "argocd-ca", time.Now().Add(24*time.Hour), nil, nil)
+	r := makeTestReconciler(t, a, expiringCA)
+
+	assert.NilError(t, r.reconcileClusterTLSSecret(a))
+	assert.NilError(t, r.reconcileCAConfigMap(a))
+	previousCA := getTestSecretCertificate(t, r, "argocd-ca")
+	assert.NilError(t, getTestSecretCertificate(t, r, "argocd-tls").CheckSignatureFrom(previousCA))
+
+	// The CA is renewed within the renewal window, along with the certificate it signed.
+	assert.NilError(t, r.reconcileClusterCASecret(a))
+	ca := getTestSecretCertificate(t, r, "argocd-ca")
+	assert.Assert(t, ca.NotAfter.After(time.Now().Add(300*24*time.Hour)))
+	assert.NilError(t, getTestSecretCertificate(t, r, "argocd-tls").CheckSignatureFrom(ca))
+
+	// The CA ConfigMap is updated with the renewed CA.
+	assert.NilError(t, r.reconcileCAConfigMap(a))
+	cm := &corev1.ConfigMap{}
+	assert.NilError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-ca", Namespace: testNamespace}, cm))
+	assert.Equal(t, cm.Data[corev1.TLSCertKey], string(argoutil.EncodeCertificatePEM(ca)))
+
+	// A renewed CA is left alone.
+	assert.NilError(t, r.reconcileClusterCASecret(a))
+	assert.Equal(t, getTestSecretCertificate(t, r, "argocd-ca").SerialNumber.Cmp(ca.SerialNumber), 0)
+}
+
+func TestReconcileArgoCD_reconcileClusterTLSSecret_renewal(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	r := makeTestReconciler(t, a)
+	assert.NilError(t, r.reconcileClusterCASecret(a))
+
+	caSecret := &corev1.Secret{}
+	assert.NilError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-ca", Namespace: testNamespace}, caSecret))
+	caCert, caKey, err := parseSecretCA(caSecret)
+	assert.NilError(t, err)
+
+	expiring := makeTestCertificateSecret(t, "argocd-tls", time.Now().Add(24*time.Hour), caCert, caKey)
+	assert.NilError(t, r.Client.Create(context.TODO(), expiring))
+
+	// Certificates signed by the operator CA are renewed within the renewal window.
+	assert.NilError(t, r.reconcileClusterTLSSecret(a))
+	cert := getTestSecretCertificate(t, r, "argocd-tls")
+	assert.Assert(t, cert.NotAfter.After(time.Now().Add(300*24*time.Hour)))
+	assert.NilError(t, cert.CheckSignatureFrom(caCert))
+}
+
+func TestReconcileArgoCD_reconcileClusterTLSSecret_keepsCustomCertificate(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	otherCA := makeTestCertificateSecret(t, "other-ca", time.Now().Add(24*time.Hour), nil, nil)
+	otherCert, otherKey, err := parseSecretCA(otherCA)
+	assert.NilError(t, err)
+	custom := makeTestCertificateSecret(t, "argocd-tls", time.Now().Add(24*time.Hour), otherCert, otherKey)
+	r := makeTestReconciler(t, a, custom)
+
+	// Certificates not signed by the operator CA are never renewed by the operator.
+	assert.NilError(t, r.reconcileClusterCASecret(a))
+	assert.NilError(t, r.reconcileClusterTLSSecret(a))
+	assert.NilError(t, getTestSecretCertificate(t, r, "argocd-tls").CheckSignatureFrom(otherCert))
+}
+
+func TestReconcileArgoCD_reconcileStatusCertificates(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.TLS.RenewBefore = &metav1.Duration{Duration: 60 * 24 * time.Hour}
+	})
+	r := makeTestReconciler(t, a)
+	assert.NilError(t, r.reconcileClusterCASecret(a))
+	assert.NilError(t, r.reconcileClusterTLSSecret(a))
+
+	assert.NilError(t, r.reconcileStatusCertificates(a))
+	assert.Equal(t, len(a.Status.Certificates), 2)
+
+	ca := getTestSecretCertificate(t, r, "argocd-ca")
+	status := a.Status.Certificates[0]
+	assert.Equal(t, status.SecretName, "argocd-ca")
+	assert.Assert(t, status.NotAfter.Time.Equal(ca.NotAfter))
+	assert.Assert(t, status.RenewalTime.Time.Equal(ca.NotAfter.Add(-60*24*time.Hour).Truncate(time.Second)))
+	assert.Equal(t, a.Status.Certificates[1].SecretName, "argocd-tls")
+	assert.Equal(t, testutil.ToFloat64(certificateExpiryGauge.WithLabelValues(testNamespace, "argocd", "argocd-ca")), float64(ca.NotAfter.Unix()))
+
+	// The reconciliation is requeued before the first renewal.
+	requeueAfter := getCertificateRequeueAfter(a)
+	assert.Assert(t, requeueAfter > 290*24*time.Hour && requeueAfter < 310*24*time.Hour)
+
+	// Certificates reported without a renewal time are not issued by the operator and never requeued.
+	a.Status.Certificates[0].RenewalTime = nil
+	a.Status.Certificates[1].RenewalTime = nil
+	assert.Equal(t, getCertificateRequeueAfter(a), time.Duration(0))
+
+	deleteCertificateExpiryMetrics(a)
+	assert.Equal(t, testutil.CollectAndCount(certificateExpiryGauge), 0)
+}
+
+func TestReconcileArgoCD_reconcileStatusCertificates_ingressTLSSecret(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Server.Ingress = argoprojv1alpha1.ArgoCDIngressSpec{
+			Enabled: true,
+			TLS:     []networkingv1.IngressTLS{{}},
+		}
+	})
+	userSecret := makeTestCertificateSecret(t, "argocd-grpc-ingress-tls", time.Now().Add(24*time.Hour), nil, nil)
+	r := makeTestReconciler(t, a, userSecret)
+	assert.NilError(t, r.reconcileClusterCASecret(a))
+	assert.NilError(t, r.reconcileIngresses(a))
+
+	assert.NilError(t, r.reconcileStatusCertificates(a))
+	assert.Equal(t, len(a.Status.Certificates), 2)
+
+	// Generated Ingress certificates are reported, renewed and exported like the other operator certificates.
+	cert := getTestSecretCertificate(t, r, "argocd-server-ingress-tls")
+	status := a.Status.Certificates[1]
+	assert.Equal(t, status.SecretName, "argocd-server-ingress-tls")
+	assert.Assert(t, status.RenewalTime != nil)
+	assert.Assert(t, getCertificateRequeueAfter(a) > 0)
+	assert.Equal(t, testutil.ToFloat64(certificateExpiryGauge.WithLabelValues(testNamespace, "argocd", "argocd-server-ingress-tls")), float64(cert.NotAfter.Unix()))
+
+	deleteCertificateExpiryMetrics(a)
+	assert.Equal(t, testutil.CollectAndCount(certificateExpiryGauge), 0)
+}
+
+func TestReconcileArgoCD_reconcileClusterCASecret_renewsRedisTLSSecret(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Redis.TLSEnabled = true
+	})
+	expiringCA := makeTestCertificateSecret(t, "argocd-ca", time.Now().Add(24*time.Hour), nil, nil)
+	r := makeTestReconciler(t, a, expiringCA)
+
+	assert.NilError(t, r.reconcileRedisTLSSecret(a))
+	previousCA := getTestSecretCertificate(t, r, "argocd-ca")
+	assert.NilError(t, getTestSecretCertificate(t, r, "argocd-operator-redis-tls").CheckSignatureFrom(previousCA))
+
+	// The Redis certificate is re-issued along with the CA, with the renewed CA alongside it.
+	assert.NilError(t, r.reconcileClusterCASecret(a))
+	ca := getTestSecretCertificate(t, r, "argocd-ca")
+	assert.NilError(t, getTestSecretCertificate(t, r, "argocd-operator-redis-tls").CheckSignatureFrom(ca))
+
+	secret := &corev1.Secret{}
+	assert.NilError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-operator-redis-tls", Namespace: testNamespace}, secret))
+	assert.DeepEqual(t, secret.Data[corev1.ServiceAccountRootCAKey], argoutil.EncodeCertificatePEM(ca))
+}
@@ -0,0 +1,220 @@
+// Copyright 2021 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	argoprojv1a1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// certificateGVK is the GroupVersionKind of the cert-manager Certificate resource.
+var certificateGVK = schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"}
+
+var certManagerAPIFound = false
+
+// IsCertManagerAPIAvailable returns true if the cert-manager API is present.
+func IsCertManagerAPIAvailable() bool {
+	return certManagerAPIFound
+}
+
+// verifyCertManagerAPI will verify that the cert-manager API is present.
+func verifyCertManagerAPI() error {
+	found, err := argoutil.VerifyAPI(certificateGVK.Group, certificateGVK.Version)
+	if err != nil {
+		return err
+	}
+	certManagerAPIFound = found
+	return nil
+}
+
+// isCertManagerEnabled returns whether the certificates of the given ArgoCD are issued by cert-manager.
+func isCertManagerEnabled(cr *argoprojv1a1.ArgoCD) bool {
+	return cr.Spec.TLS.CertManager != nil && IsCertManagerAPIAvailable()
+}
+
+// certificateTarget describes a certificate of an Argo CD component issued by cert-manager.
+type certificateTarget struct {
+	// suffix is the name suffix of the Certificate.
+	suffix string
+	// secretName is the name of the Secret the certificate is issued to.
+	secretName string
+	// services are the names of the Services the certificate is valid for.
+	services []string
+	// dnsNames are the additional DNS names the certificate is valid for.
+	dnsNames []string
+	// deployed reports whether the component is managed for the given ArgoCD.
+	deployed bool
+}
+
+// getServiceDNSNames returns the DNS names of the Service with the given name in the namespace of the given ArgoCD.
+func getServiceDNSNames(name string, cr *argoprojv1a1.ArgoCD) []string {
+	return []string{
+		name,
+		fmt.Sprintf("%s.%s.svc", name, cr.Namespace),
+		fmt.Sprintf("%s.%s.svc.cluster.local", name, cr.Namespace),
+	}
+}
+
+// getCertificateTargets returns the certificates of the Argo CD components that can be issued by cert-manager.
+func getCertificateTargets(cr *argoprojv1a1.ArgoCD) []certificateTarget {
+	redisServices := []string{nameWithSuffix("redis", cr)}
+	if cr.Spec.HA.Enabled {
+		redisServices = []string{nameWithSuffix("redis-ha-haproxy", cr), nameWithSuffix("redis-ha", cr)}
+	}
+
+	return []certificateTarget{
+		{
+			suffix:     "server",
+			secretName: common.ArgoCDServerTLSSecretName,
+			services:   []string{nameWithSuffix("server", cr)},
+			dnsNames:   []string{getArgoServerHost(cr), getArgoServerGRPCHost(cr)},
+			deployed:   true,
+		},
+		{
+			suffix:     "repo-server",
+			secretName: common.ArgoCDRepoServerTLSSecretName,
+			services:   []string{nameWithSuffix("repo-server", cr)},
+			deployed:   true,
+		},
+		{
+			suffix:     "redis",
+			secretName: common.ArgoCDRedisServerTLSSecretName,
+			services:   redisServices,
+			deployed:   !isExternalRedis(cr),
+		},
+	}
+}
+
+// newCertificateWithSuffix returns a new cert-manager Certificate for the given ArgoCD using the given suffix.
+func newCertificateWithSuffix(suffix string, cr *argoprojv1a1.ArgoCD) *unstructured.Unstructured {
+	certificate := &unstructured.Unstructured{}
+	certificate.SetGroupVersionKind(certificateGVK)
+	certificate.SetName(nameWithSuffix(suffix, cr))
+	certificate.SetNamespace(cr.Namespace)
+	certificate.SetLabels(argoutil.LabelsForCluster(cr))
+	return certificate
+}
+
+// getCertificateSpec returns the cert-manager Certificate spec for the given target.
+func getCertificateSpec(target certificateTarget, cr *argoprojv1a1.ArgoCD) map[string]interface{} {
+	certManager := cr.Spec.TLS.CertManager
+
+	kind := common.ArgoCDDefaultCertManagerIssuerKind
+	if certManager.IssuerRef.Kind != "" {
+		kind = certManager.IssuerRef.Kind
+	}
+	group := common.ArgoCDDefaultCertManagerIssuerGroup
+	if certManager.IssuerRef.Group != "" {
+		group = certManager.IssuerRef.Group
+	}
+
+	dnsNames := []interface{}{}
+	for _, service := range target.services {
+		for _, name := range getServiceDNSNames(service, cr) {
+			dnsNames = append(dnsNames, name)
+		}
+	}
+	for _, name := range target.dnsNames {
+		dnsNames = append(dnsNames, name)
+	}
+
+	spec := map[string]interface{}{
+		"secretName": target.secretName,
+		"commonName": target.services[0],
+		"dnsNames":   dnsNames,
+		"issuerRef": map[string]interface{}{
+			"name":  certManager.IssuerRef.Name,
+			"kind":  kind,
+			"group": group,
+		},
+		"usages": []interface{}{"server auth", "client auth"},
+		"secretTemplate": map[string]interface{}{
+			// The owner annotation maps changes of the issued secret back to the ArgoCD.
+			"annotations": map[string]interface{}{
+				common.AnnotationName: cr.Name,
+			},
+		},
+	}
+	if certManager.Duration != nil {
+		spec["duration"] = certManager.Duration.Duration.String()
+	}
+	if certManager.RenewBefore != nil {
+		spec["renewBefore"] = certManager.RenewBefore.Duration.String()
+	}
+	return spec
+}
+
+// reconcileCertificate will ensure that the cert-manager Certificate for the given target is present or absent.
+func (r *ReconcileArgoCD) reconcileCertificate(target certificateTarget, cr *argoprojv1a1.ArgoCD) error {
+	enabled := isCertManagerEnabled(cr) && target.deployed
+
+	existing := newCertificateWithSuffix(target.suffix, cr)
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: existing.GetName(), Namespace: cr.Namespace}, existing)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	if err == nil {
+		if !enabled {
+			return r.Client.Delete(context.TODO(), existing) // Certificate found but disabled, delete it.
+		}
+
+		spec := getCertificateSpec(target, cr)
+		if !reflect.DeepEqual(existing.Object["spec"], spec) {
+			existing.Object["spec"] = spec
+			return r.Client.Update(context.TODO(), existing)
+		}
+		return nil // Certificate found with nothing to do, move along...
+	}
+
+	if !enabled {
+		return nil // Certificate not enabled, move along...
+	}
+
+	certificate := newCertificateWithSuffix(target.suffix, cr)
+	certificate.Object["spec"] = getCertificateSpec(target, cr)
+	if err := controllerutil.SetControllerReference(cr, certificate, r.Scheme); err != nil {
+		return err
+	}
+	return r.Client.Create(context.TODO(), certificate)
+}
+
+// reconcileCertificates will ensure that the cert-manager Certificates of the Argo CD components are present or absent.
+func (r *ReconcileArgoCD) reconcileCertificates(cr *argoprojv1a1.ArgoCD) error {
+	if !IsCertManagerAPIAvailable() {
+		if cr.Spec.TLS.CertManager != nil {
+			log.Info("cert-manager API not found, skipping certificates")
+		}
+		return nil // cert-manager not installed, nothing to clean up.
+	}
+
+	for _, target := range getCertificateTargets(cr) {
+		if err := r.reconcileCertificate(target, cr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoprojv1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+)
+
+func getTestCertificate(t *testing.T, r *ReconcileArgoCD, name string) (*unstructured.Unstructured, error) {
+	t.Helper()
+	certificate := &unstructured.Unstructured{}
+	certificate.SetGroupVersionKind(certificateGVK)
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: testNamespace}, certificate)
+	return certificate, err
+}
+
+func TestReconcileArgoCD_reconcileCertificates(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	certManagerAPIFound = true
+	defer func() {
+		certManagerAPIFound = false
+	}()
+
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.TLS.CertManager = &argoprojv1alpha1.ArgoCDCertManagerSpec{
+			IssuerRef: argoprojv1alpha1.ArgoCDCertManagerIssuerRef{Name: "corporate-ca", Kind: "ClusterIssuer"},
+		}
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NilError(t, r.reconcileCertificates(a))
+
+	server, err := getTestCertificate(t, r, "argocd-server")
+	assert.NilError(t, err)
+	assert.Equal(t, server.GetOwnerReferences()[0].Name, a.Name)
+	secretName, _, _ := unstructured.NestedString(server.Object, "spec", "secretName")
+	assert.Equal(t, secretName, "argocd-server-tls")
+	issuer, _, _ := unstructured.NestedStringMap(server.Object, "spec", "issuerRef")
+	assert.DeepEqual(t, issuer, map[string]string{"name": "corporate-ca", "kind": "ClusterIssuer", "group": "cert-manager.io"})
+	dnsNames, _, _ := unstructured.NestedStringSlice(server.Object, "spec", "dnsNames")
+	assert.DeepEqual(t, dnsNames, []string{"argocd-server", "argocd-server.argocd.svc", "argocd-server.argocd.svc.cluster.local", "argocd", "argocd-grpc"})
+	annotations, _, _ := unstructured.NestedStringMap(server.Object, "spec", "secretTemplate", "annotations")
+	assert.DeepEqual(t, annotations, map[string]string{"argocds.argoproj.io/name": "argocd"})
+
+	repo, err := getTestCertificate(t, r, "argocd-repo-server")
+	assert.NilError(t, err)
+	secretName, _, _ = unstructured.NestedString(repo.Object, "spec", "secretName")
+	assert.Equal(t, secretName, "argocd-repo-server-tls")
+
+	redis, err := getTestCertificate(t, r, "argocd-redis")
+	assert.NilError(t, err)
+	secretName, _, _ = unstructured.NestedString(redis.Object, "spec", "secretName")
+	assert.Equal(t, secretName, "argocd-operator-redis-tls")
+
+	// Changes to the cert-manager options are applied to the existing Certificates.
+	a.Spec.HA.Enabled = true
+	a.Spec.TLS.CertManager.Duration = &metav1.Duration{Duration: 720 * time.Hour}
+	assert.NilError(t, r.reconcileCertificates(a))
+
+	redis, err = getTestCertificate(t, r, "argocd-redis")
+	assert.NilError(t, err)
+	duration, _, _ := unstructured.NestedString(redis.Object, "spec", "duration")
+	assert.Equal(t, duration, "720h0m0s")
+	commonName, _, _ := unstructured.NestedString(redis.Object, "spec", "commonName")
+	assert.Equal(t, commonName, "argocd-redis-ha-haproxy")
+
+	// Removing the cert-manager options removes the Certificates.
+	a.Spec.TLS.CertManager = nil
+	assert.NilError(t, r.reconcileCertificates(a))
+
+	for _, name := range []string{"argocd-server", "argocd-repo-server", "argocd-redis"} {
+		_, err = getTestCertificate(t, r, name)
+		assert.Assert(t, apierrors.IsNotFound(err), name)
+	}
+}
+
+func TestReconcileArgoCD_reconcileCertificates_withoutCertManager(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.TLS.CertManager = &argoprojv1alpha1.ArgoCDCertManagerSpec{
+			IssuerRef: argoprojv1alpha1.ArgoCDCertManagerIssuerRef{Name: "corporate-ca"},
+		}
+	})
+	r := makeTestReconciler(t, a)
+
+	// Certificates are only created when cert-manager is installed.
+	assert.NilError(t, r.reconcileCertificates(a))
+	assert.Assert(t, !isCertManagerEnabled(a))
+}
@@ -16,9 +16,12 @@ package argocd
 
 import (
 	"context"
+	"crypto/sha256"
 	"fmt"
 	"io/ioutil"
 	"path/filepath"
+	"reflect"
+	"sort"
 	"strings"
 	"time"
 
@@ -33,11 +36,22 @@ import (
 
 // createRBACConfigMap will create the Argo CD RBAC ConfigMap resource.
 func (r *ReconcileArgoCD) createRBACConfigMap(cm *corev1.ConfigMap, cr *argoprojv1a1.ArgoCD) error {
+	policy, valid, err := r.getValidRBACPolicy(cr)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		policy = common.ArgoCDDefaultRBACPolicy
+	}
+
 	data := make(map[string]string)
-	data[common.ArgoCDKeyRBACPolicyCSV] = getRBACPolicy(cr)
+	data[common.ArgoCDKeyRBACPolicyCSV] = policy
 	data[common.ArgoCDKeyRBACPolicyDefault] = getRBACDefaultPolicy(cr)
 	data[common.ArgoCDKeyRBACScopes] = getRBACScopes(cr)
 	cm.Data = data
+	if valid && hasRBACRoles(cr) {
+		cm.Annotations = map[string]string{common.ArgoCDRBACRolesAnnotation: "true"}
+	}
 
 	if err := controllerutil.SetControllerReference(cr, cm, r.Scheme); err != nil {
 		return err
@@ -124,12 +138,19 @@ func getOIDCConfig(cr *argoprojv1a1.ArgoCD) string {
 	return config
 }
 
-// getRBACPolicy will return the RBAC policy for the given ArgoCD.
+// getRBACPolicy will return the RBAC policy for the given ArgoCD, followed by the policy rendered from the typed
+// roles and bindings.
 func getRBACPolicy(cr *argoprojv1a1.ArgoCD) string {
 	policy := common.ArgoCDDefaultRBACPolicy
 	if cr.Spec.RBAC.Policy != nil {
 		policy = *cr.Spec.RBAC.Policy
 	}
+	if roles := getRBACRolePolicy(cr); roles != "" {
+		if policy != "" && !strings.HasSuffix(policy, "\n") {
+			policy += "\n"
+		}
+		policy += roles
+	}
 	return policy
 }
 
@@ -276,25 +297,37 @@ func (r *ReconcileArgoCD) reconcileConfigMaps(cr *argoprojv1a1.ArgoCD) error {
 		return err
 	}
 
+	if err := r.reconcileCMPPluginConfigMaps(cr); err != nil {
+		return err
+	}
+
 	return r.reconcileGPGKeysConfigMap(cr)
 }
 
 // reconcileCAConfigMap will ensure that the Certificate Authority ConfigMap is present.
-// This ConfigMap holds the CA Certificate data for client use.
+// This ConfigMap holds the CA Certificate data for client use, and is updated when the CA is renewed.
 func (r *ReconcileArgoCD) reconcileCAConfigMap(cr *argoprojv1a1.ArgoCD) error {
 	cm := newConfigMapWithName(getCAConfigMapName(cr), cr)
-	if argoutil.IsObjectFound(r.Client, cr.Namespace, cm.Name, cm) {
-		return nil // ConfigMap found, do nothing
-	}
-
 	caSecret := argoutil.NewSecretWithSuffix(cr, common.ArgoCDCASuffix)
 	if !argoutil.IsObjectFound(r.Client, cr.Namespace, caSecret.Name, caSecret) {
 		log.Info(fmt.Sprintf("ca secret [%s] not found, waiting to reconcile ca configmap [%s]", caSecret.Name, cm.Name))
 		return nil
 	}
+	caCert := string(caSecret.Data[common.ArgoCDKeyTLSCert])
+
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, cm.Name, cm) {
+		if cm.Data[common.ArgoCDKeyTLSCert] != caCert {
+			if cm.Data == nil {
+				cm.Data = make(map[string]string)
+			}
+			cm.Data[common.ArgoCDKeyTLSCert] = caCert
+			return r.Client.Update(context.TODO(), cm)
+		}
+		return nil // ConfigMap found with nothing to do, move along...
+	}
 
 	cm.Data = map[string]string{
-		common.ArgoCDKeyTLSCert: string(caSecret.Data[common.ArgoCDKeyTLSCert]),
+		common.ArgoCDKeyTLSCert: caCert,
 	}
 
 	if err := controllerutil.SetControllerReference(cr, cm, r.Scheme); err != nil {
@@ -334,9 +367,11 @@ func (r *ReconcileArgoCD) reconcileArgoConfigMap(cr *argoprojv1a1.ArgoCD) error
 		}
 	}
 
+	reconcileLocalUserAccounts(cm, cr)
+
 	cm.Data[common.ArgoCDKeyOIDCConfig] = getOIDCConfig(cr)
-	if c := getResourceCustomizations(cr); c != "" {
-		cm.Data[common.ArgoCDKeyResourceCustomizations] = c
+	if _, err := r.reconcileResourceCustomizations(cm, cr); err != nil {
+		return err
 	}
 	cm.Data[common.ArgoCDKeyResourceExclusions] = getResourceExclusions(cr)
 	cm.Data[common.ArgoCDKeyResourceInclusions] = getResourceInclusions(cr)
@@ -454,6 +489,10 @@ func (r *ReconcileArgoCD) reconcileExistingArgoConfigMap(cm *corev1.ConfigMap, c
 		}
 	}
 
+	if reconcileLocalUserAccounts(cm, cr) {
+		changed = true
+	}
+
 	if cr.Spec.SSO == nil {
 		if cm.Data[common.ArgoCDKeyOIDCConfig] != cr.Spec.OIDCConfig {
 			cm.Data[common.ArgoCDKeyOIDCConfig] = cr.Spec.OIDCConfig
@@ -461,10 +500,11 @@ func (r *ReconcileArgoCD) reconcileExistingArgoConfigMap(cm *corev1.ConfigMap, c
 		}
 	}
 
-	if cm.Data[common.ArgoCDKeyResourceCustomizations] != cr.Spec.ResourceCustomizations {
-		cm.Data[common.ArgoCDKeyResourceCustomizations] = cr.Spec.ResourceCustomizations
-		changed = true
+	rcChanged, err := r.reconcileResourceCustomizations(cm, cr)
+	if err != nil {
+		return err
 	}
+	changed = changed || rcChanged
 
 	if cm.Data[common.ArgoCDKeyResourceExclusions] != cr.Spec.ResourceExclusions {
 		cm.Data[common.ArgoCDKeyResourceExclusions] = cr.Spec.ResourceExclusions
@@ -593,9 +633,29 @@ func (r *ReconcileArgoCD) reconcileRBAC(cr *argoprojv1a1.ArgoCD) error {
 // reconcileRBACConfigMap will ensure that the RBAC ConfigMap is syncronized with the given ArgoCD.
 func (r *ReconcileArgoCD) reconcileRBACConfigMap(cm *corev1.ConfigMap, cr *argoprojv1a1.ArgoCD) error {
 	changed := false
-	// Policy CSV
-	if cr.Spec.RBAC.Policy != nil && cm.Data[common.ArgoCDKeyRBACPolicyCSV] != *cr.Spec.RBAC.Policy {
-		cm.Data[common.ArgoCDKeyRBACPolicyCSV] = *cr.Spec.RBAC.Policy
+	// Policy CSV, an invalid policy leaves the current one in place.
+	policy, valid, err := r.getValidRBACPolicy(cr)
+	if err != nil {
+		return err
+	}
+	// Roles rendered by the operator are removed from the policy once they are removed from the ArgoCD.
+	rendered := cm.Annotations[common.ArgoCDRBACRolesAnnotation] == "true"
+	if valid && (cr.Spec.RBAC.Policy != nil || hasRBACRoles(cr) || rendered) && cm.Data[common.ArgoCDKeyRBACPolicyCSV] != policy {
+		if cm.Data == nil {
+			cm.Data = make(map[string]string)
+		}
+		cm.Data[common.ArgoCDKeyRBACPolicyCSV] = policy
+		changed = true
+	}
+	if valid && hasRBACRoles(cr) != rendered {
+		if hasRBACRoles(cr) {
+			if cm.Annotations == nil {
+				cm.Annotations = make(map[string]string)
+			}
+			cm.Annotations[common.ArgoCDRBACRolesAnnotation] = "true"
+		} else {
+			delete(cm.Annotations, common.ArgoCDRBACRolesAnnotation)
+		}
 		changed = true
 	}
 
@@ -626,28 +686,56 @@ func (r *ReconcileArgoCD) reconcileRedisConfiguration(cr *argoprojv1a1.ArgoCD) e
 	return nil
 }
 
+// getRedisHAConfigData will return the data of the Redis HA ConfigMap for the given ArgoCD.
+func getRedisHAConfigData(cr *argoprojv1a1.ArgoCD) map[string]string {
+	return map[string]string{
+		"haproxy.cfg":     getRedisHAProxyConfig(cr),
+		"haproxy_init.sh": getRedisHAProxyScript(cr),
+		"init.sh":         getRedisInitScript(cr),
+		"redis.conf":      getRedisConf(cr),
+		"sentinel.conf":   getRedisSentinelConf(cr),
+	}
+}
+
+// getRedisHAConfigChecksum will return a checksum of the data of the Redis HA ConfigMap for the given ArgoCD.
+// The Redis HA pods only read the configuration on startup, so the checksum is used to roll them out when it changes.
+func getRedisHAConfigChecksum(cr *argoprojv1a1.ArgoCD) string {
+	data := getRedisHAConfigData(cr)
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	hash := sha256.New()
+	for _, key := range keys {
+		fmt.Fprintf(hash, "%s\n%s\n", key, data[key])
+	}
+	return fmt.Sprintf("%x", hash.Sum(nil))
+}
+
 // reconcileRedisHAConfigMap will ensure that the Redis HA ConfigMap is present for the given ArgoCD.
 func (r *ReconcileArgoCD) reconcileRedisHAConfigMap(cr *argoprojv1a1.ArgoCD) error {
 	cm := newConfigMapWithName(common.ArgoCDRedisHAConfigMapName, cr)
+	data := getRedisHAConfigData(cr)
+
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, cm.Name, cm) {
-		if !cr.Spec.HA.Enabled {
-			// ConfigMap exists but HA enabled flag has been set to false, delete the ConfigMap
+		if !isRedisHAEnabled(cr) {
+			// ConfigMap exists but HA has been disabled or an external Redis is used, delete the ConfigMap
 			return r.Client.Delete(context.TODO(), cm)
 		}
+		if !reflect.DeepEqual(cm.Data, data) {
+			cm.Data = data
+			return r.Client.Update(context.TODO(), cm)
+		}
 		return nil // ConfigMap found with nothing changed, move along...
 	}
 
-	if !cr.Spec.HA.Enabled {
-		return nil // HA not enabled, do nothing.
+	if !isRedisHAEnabled(cr) {
+		return nil // HA not enabled or external Redis used, do nothing.
 	}
 
-	cm.Data = map[string]string{
-		"haproxy.cfg":     getRedisHAProxyConfig(cr),
-		"haproxy_init.sh": getRedisHAProxyScript(cr),
-		"init.sh":         getRedisInitScript(cr),
-		"redis.conf":      getRedisConf(cr),
-		"sentinel.conf":   getRedisSentinelConf(cr),
-	}
+	cm.Data = data
 
 	if err := controllerutil.SetControllerReference(cr, cm, r.Scheme); err != nil {
 		return err
@@ -19,12 +19,15 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"gopkg.in/yaml.v2"
 	"gotest.tools/assert"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -387,3 +390,372 @@ func TestReconcileArgoCD_reconcileArgoConfigMap_withResourceCustomizations(t *te
 		t.Fatalf("reconcileArgoConfigMap failed got %q, want %q", c, customizations)
 	}
 }
+
+func TestReconcileArgoCD_reconcileArgoConfigMap_withResourceOverrides(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.ResourceOverrides = []argoprojv1alpha1.ResourceOverride{
+			{
+				Group:  "certmanager.k8s.io",
+				Kind:   "Certificate",
+				Health: &argoprojv1alpha1.ResourceHealthCheck{Lua: "return {status = \"Healthy\"}\n", UseOpenLibs: true},
+				Actions: &argoprojv1alpha1.ResourceActions{
+					DiscoveryLua: "return {renew = {}}\n",
+					Definitions:  []argoprojv1alpha1.ResourceActionDefinition{{Name: "renew", ActionLua: "return obj\n"}},
+				},
+			},
+			{
+				Kind:              "Service",
+				IgnoreDifferences: &argoprojv1alpha1.ResourceIgnoreDifferences{JSONPointers: []string{"/spec/clusterIP"}},
+				KnownTypeFields:   []argoprojv1alpha1.KnownTypeField{{Field: "spec", Type: "core/v1/ServiceSpec"}},
+			},
+		}
+	})
+	r := makeTestReconciler(t, a)
+
+	err := r.reconcileArgoConfigMap(a)
+	assert.NilError(t, err)
+
+	cm := &corev1.ConfigMap{}
+	err = r.Client.Get(context.TODO(), types.NamespacedName{
+		Name:      common.ArgoCDConfigMapName,
+		Namespace: testNamespace,
+	}, cm)
+	assert.NilError(t, err)
+
+	want := map[string]string{
+		"resource.customizations.health.certmanager.k8s.io_Certificate":      "return {status = \"Healthy\"}\n",
+		"resource.customizations.useOpenLibs.certmanager.k8s.io_Certificate": "true",
+		"resource.customizations.actions.certmanager.k8s.io_Certificate":     "discovery.lua: |\n  return {renew = {}}\ndefinitions:\n- name: renew\n  action.lua: |\n    return obj\n",
+		"resource.customizations.ignoreDifferences.Service":                  "jsonPointers:\n- /spec/clusterIP\n",
+		"resource.customizations.knownTypeFields.Service":                    "- field: spec\n  type: core/v1/ServiceSpec\n",
+	}
+	for k, v := range want {
+		assert.Equal(t, cm.Data[k], v, k)
+	}
+	_, found := cm.Data[common.ArgoCDKeyResourceCustomizations]
+	assert.Assert(t, !found)
+
+	// Removing an override removes its keys from the ConfigMap.
+	a.Spec.ResourceOverrides = a.Spec.ResourceOverrides[1:]
+	err = r.reconcileArgoConfigMap(a)
+	assert.NilError(t, err)
+
+	cm = &corev1.ConfigMap{}
+	err = r.Client.Get(context.TODO(), types.NamespacedName{
+		Name:      common.ArgoCDConfigMapName,
+		Namespace: testNamespace,
+	}, cm)
+	assert.NilError(t, err)
+
+	for k := range cm.Data {
+		assert.Assert(t, !strings.Contains(k, "Certificate"), k)
+	}
+	assert.Equal(t, cm.Data["resource.customizations.ignoreDifferences.Service"], "jsonPointers:\n- /spec/clusterIP\n")
+}
+
+func TestReconcileArgoCD_reconcileArgoConfigMap_withInvalidResourceCustomizations(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	customizations := "apps/Deployment:\n  health.lua: |\n    return hs\n"
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.ResourceCustomizations = customizations
+	})
+	r := makeTestReconciler(t, a)
+
+	err := r.reconcileArgoConfigMap(a)
+	assert.NilError(t, err)
+
+	condition := meta.FindStatusCondition(a.Status.Conditions, argoprojv1alpha1.ArgoCDConditionResourceCustomizationsValid)
+	assert.Assert(t, condition != nil)
+	assert.Equal(t, condition.Status, metav1.ConditionTrue)
+
+	// A badly indented health script is reported in status and leaves the ConfigMap untouched.
+	a.Spec.ResourceCustomizations = "apps/Deployment:\n  health.lua: |\n    return hs\n  bad indentation\n"
+	a.Spec.ResourceOverrides = []argoprojv1alpha1.ResourceOverride{
+		{Kind: "Service", Health: &argoprojv1alpha1.ResourceHealthCheck{}},
+		{Kind: "Service"},
+	}
+	err = r.reconcileArgoConfigMap(a)
+	assert.NilError(t, err)
+
+	cm := &corev1.ConfigMap{}
+	err = r.Client.Get(context.TODO(), types.NamespacedName{
+		Name:      common.ArgoCDConfigMapName,
+		Namespace: testNamespace,
+	}, cm)
+	assert.NilError(t, err)
+	assert.Equal(t, cm.Data[common.ArgoCDKeyResourceCustomizations], customizations)
+	_, found := cm.Data["resource.customizations.health.Service"]
+	assert.Assert(t, !found)
+
+	condition = meta.FindStatusCondition(a.Status.Conditions, argoprojv1alpha1.ArgoCDConditionResourceCustomizationsValid)
+	assert.Assert(t, condition != nil)
+	assert.Equal(t, condition.Status, metav1.ConditionFalse)
+	assert.Equal(t, condition.Reason, argoprojv1alpha1.ArgoCDReasonInvalidResourceCustomizations)
+	assert.Assert(t, strings.Contains(condition.Message, "spec.resourceCustomizations"))
+	assert.Assert(t, strings.Contains(condition.Message, "spec.resourceOverrides[0].health.lua"))
+	assert.Assert(t, strings.Contains(condition.Message, "spec.resourceOverrides[1]: Duplicate value"))
+}
+
+func TestReconcileArgoCD_reconcileRBAC_withRoles(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	policy := "g, system:cluster-admins, role:admin"
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.RBAC.Policy = &policy
+		a.Spec.RBAC.Roles = []argoprojv1alpha1.ArgoCDRBACRole{
+			{
+				Name: "deployer",
+				Permissions: []argoprojv1alpha1.ArgoCDRBACPermission{
+					{Resource: "applications", Action: "sync", Object: "team-a/*"},
+					{Resource: "applications", Action: "delete", Effect: "deny"},
+				},
+			},
+		}
+		a.Spec.RBAC.Bindings = []argoprojv1alpha1.ArgoCDRBACBinding{
+			{Subject: "team-a", Role: "deployer"},
+			{Subject: "auditors", Role: "role:readonly"},
+		}
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NilError(t, r.reconcileRBAC(a))
+
+	cm := &corev1.ConfigMap{}
+	assert.NilError(t, r.Client.Get(context.TODO(), types.NamespacedName{
+		Name:      common.ArgoCDRBACConfigMapName,
+		Namespace: testNamespace,
+	}, cm))
+	assert.Equal(t, cm.Data[common.ArgoCDKeyRBACPolicyCSV], `g, system:cluster-admins, role:admin
+p, role:deployer, applications, sync, team-a/*, allow
+p, role:deployer, applications, delete, *, deny
+g, team-a, role:deployer
+g, auditors, role:readonly
+`)
+
+	condition := meta.FindStatusCondition(a.Status.Conditions, argoprojv1alpha1.ArgoCDConditionRBACPolicyValid)
+	assert.Assert(t, condition != nil)
+	assert.Equal(t, condition.Status, metav1.ConditionTrue)
+
+	// Removing the roles on an existing ConfigMap renders the plain policy again.
+	a.Spec.RBAC.Roles = nil
+	a.Spec.RBAC.Bindings = nil
+	assert.NilError(t, r.reconcileRBAC(a))
+
+	cm = &corev1.ConfigMap{}
+	assert.NilError(t, r.Client.Get(context.TODO(), types.NamespacedName{
+		Name:      common.ArgoCDRBACConfigMapName,
+		Namespace: testNamespace,
+	}, cm))
+	assert.Equal(t, cm.Data[common.ArgoCDKeyRBACPolicyCSV], policy)
+}
+
+func TestReconcileArgoCD_reconcileRBAC_removeRolesWithoutPolicy(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.RBAC.Roles = []argoprojv1alpha1.ArgoCDRBACRole{
+			{
+				Name:        "deployer",
+				Permissions: []argoprojv1alpha1.ArgoCDRBACPermission{{Resource: "applications", Action: "sync", Object: "team-a/*"}},
+			},
+		}
+		a.Spec.RBAC.Bindings = []argoprojv1alpha1.ArgoCDRBACBinding{{Subject: "team-a", Role: "deployer"}}
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NilError(t, r.reconcileRBAC(a))
+
+	cm := &corev1.ConfigMap{}
+	assert.NilError(t, r.Client.Get(context.TODO(), types.NamespacedName{
+		Name:      common.ArgoCDRBACConfigMapName,
+		Namespace: testNamespace,
+	}, cm))
+	assert.Assert(t, strings.Contains(cm.Data[common.ArgoCDKeyRBACPolicyCSV], "p, role:deployer, applications, sync, team-a/*, allow\n"))
+	assert.Equal(t, cm.Annotations[common.ArgoCDRBACRolesAnnotation], "true")
+
+	// Removing the last role and binding without a policy removes the rendered lines.
+	a.Spec.RBAC.Roles = nil
+	a.Spec.RBAC.Bindings = nil
+	assert.NilError(t, r.reconcileRBAC(a))
+
+	cm = &corev1.ConfigMap{}
+	assert.NilError(t, r.Client.Get(context.TODO(), types.NamespacedName{
+		Name:      common.ArgoCDRBACConfigMapName,
+		Namespace: testNamespace,
+	}, cm))
+	assert.Equal(t, cm.Data[common.ArgoCDKeyRBACPolicyCSV], common.ArgoCDDefaultRBACPolicy)
+	assert.Assert(t, !strings.Contains(cm.Data[common.ArgoCDKeyRBACPolicyCSV], "role:deployer"))
+	_, ok := cm.Annotations[common.ArgoCDRBACRolesAnnotation]
+	assert.Assert(t, !ok)
+
+	// A policy edited in the ConfigMap afterwards is left alone again.
+	cm.Data[common.ArgoCDKeyRBACPolicyCSV] = "g, team-b, role:admin"
+	assert.NilError(t, r.Client.Update(context.TODO(), cm))
+	assert.NilError(t, r.reconcileRBAC(a))
+
+	cm = &corev1.ConfigMap{}
+	assert.NilError(t, r.Client.Get(context.TODO(), types.NamespacedName{
+		Name:      common.ArgoCDRBACConfigMapName,
+		Namespace: testNamespace,
+	}, cm))
+	assert.Equal(t, cm.Data[common.ArgoCDKeyRBACPolicyCSV], "g, team-b, role:admin")
+}
+
+func TestReconcileArgoCD_reconcileRBAC_withInvalidPolicy(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	policy := "p, role:dev, applications, get, */*, allow"
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.RBAC.Policy = &policy
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NilError(t, r.reconcileRBAC(a))
+
+	tests := []struct {
+		name    string
+		policy  string
+		roles   []argoprojv1alpha1.ArgoCDRBACRole
+		message string
+	}{
+		{
+			name:    "incomplete binding",
+			policy:  "g, team-a",
+			message: "policy syntax error",
+		},
+		{
+			name:   "invalid role",
+			policy: policy,
+			roles: []argoprojv1alpha1.ArgoCDRBACRole{
+				{Name: "dev", Permissions: []argoprojv1alpha1.ArgoCDRBACPermission{{Resource: "applications", Action: "get, sync", Effect: "permit"}}},
+				{Name: "dev"},
+			},
+			message: "spec.rbac.roles[0].permissions[0].action",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a.Spec.RBAC.Policy = &test.policy
+			a.Spec.RBAC.Roles = test.roles
+			assert.NilError(t, r.reconcileRBAC(a))
+
+			// The invalid policy is reported in status and the last valid policy is kept.
+			cm := &corev1.ConfigMap{}
+			assert.NilError(t, r.Client.Get(context.TODO(), types.NamespacedName{
+				Name:      common.ArgoCDRBACConfigMapName,
+				Namespace: testNamespace,
+			}, cm))
+			assert.Equal(t, cm.Data[common.ArgoCDKeyRBACPolicyCSV], policy)
+
+			condition := meta.FindStatusCondition(a.Status.Conditions, argoprojv1alpha1.ArgoCDConditionRBACPolicyValid)
+			assert.Assert(t, condition != nil)
+			assert.Equal(t, condition.Status, metav1.ConditionFalse)
+			assert.Equal(t, condition.Reason, argoprojv1alpha1.ArgoCDReasonInvalidRBACPolicy)
+			assert.Assert(t, strings.Contains(condition.Message, test.message), condition.Message)
+		})
+	}
+}
+
+func TestReconcileArgoCD_createRBACConfigMap_withInvalidPolicy(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	policy := "g, team-a"
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.RBAC.Policy = &policy
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NilError(t, r.reconcileRBAC(a))
+
+	cm := &corev1.ConfigMap{}
+	assert.NilError(t, r.Client.Get(context.TODO(), types.NamespacedName{
+		Name:      common.ArgoCDRBACConfigMapName,
+		Namespace: testNamespace,
+	}, cm))
+	assert.Equal(t, cm.Data[common.ArgoCDKeyRBACPolicyCSV], common.ArgoCDDefaultRBACPolicy)
+	assert.Equal(t, cm.Data[common.ArgoCDKeyRBACPolicyDefault], common.ArgoCDDefaultRBACDefaultPolicy)
+}
+
+func TestReconcileArgoCD_reconcileRedisHAConfigMap_authAndTLS(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	os.Setenv("REDIS_CONFIG_PATH", "../../build/redis")
+	defer os.Unsetenv("REDIS_CONFIG_PATH")
+
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.HA.Enabled = true
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NilError(t, r.reconcileRedisHAConfigMap(a))
+
+	cm := &corev1.ConfigMap{}
+	assert.NilError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDRedisHAConfigMapName, Namespace: testNamespace}, cm))
+	assert.Assert(t, strings.Contains(cm.Data["redis.conf"], "\nport 6379\n"))
+	assert.Assert(t, !strings.Contains(cm.Data["redis.conf"], "requirepass"))
+	assert.Assert(t, !strings.Contains(cm.Data["haproxy.cfg"], "AUTH"))
+	assert.Assert(t, strings.Contains(cm.Data["init.sh"], `REDIS_CLI="redis-cli"`))
+
+	// Enabling authentication and TLS updates the existing ConfigMap.
+	a.Spec.Redis.AuthEnabled = true
+	a.Spec.Redis.TLSEnabled = true
+	assert.NilError(t, r.reconcileRedisHAConfigMap(a))
+	assert.NilError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDRedisHAConfigMapName, Namespace: testNamespace}, cm))
+
+	redisConf := cm.Data["redis.conf"]
+	for _, line := range []string{"port 0", "tls-port 6379", "tls-cert-file /app/config/redis/tls/tls.crt", "requirepass replace-default-auth", "masterauth replace-default-auth"} {
+		assert.Assert(t, strings.Contains(redisConf, line+"\n"), line)
+	}
+	sentinelConf := cm.Data["sentinel.conf"]
+	for _, line := range []string{"sentinel auth-pass argocd replace-default-auth", "tls-port 26379", "tls-replication yes"} {
+		assert.Assert(t, strings.Contains(sentinelConf, line+"\n"), line)
+	}
+	haproxyConfig := cm.Data["haproxy.cfg"]
+	assert.Assert(t, strings.Contains(haproxyConfig, "tcp-check send AUTH\\ REPLACE_AUTH_SECRET\\r\\n"))
+	assert.Assert(t, strings.Contains(haproxyConfig, "bind *:6379 ssl crt /usr/local/etc/haproxy/redis.pem\n"))
+	assert.Assert(t, strings.Contains(haproxyConfig, "server R0 argocd-redis-ha-announce-0:6379 check inter 3s fall 1 rise 1 ssl ca-file /app/config/redis/tls/ca.crt"))
+	assert.Assert(t, strings.Contains(cm.Data["haproxy_init.sh"], "cat /app/config/redis/tls/tls.crt /app/config/redis/tls/tls.key > /data/redis.pem\n"))
+	assert.Assert(t, strings.Contains(cm.Data["init.sh"], `REDIS_CLI="redis-cli --tls --cacert /app/config/redis/tls/ca.crt"`))
+	assert.Assert(t, strings.Contains(cm.Data["init.sh"], `-a "$AUTH" --no-auth-warning ping`))
+}
+
+func TestReconcileArgoCD_reconcileRedisHAConfigMap_topology(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	os.Setenv("REDIS_CONFIG_PATH", "../../build/redis")
+	defer os.Unsetenv("REDIS_CONFIG_PATH")
+
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.HA.Enabled = true
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NilError(t, r.reconcileRedisHAConfigMap(a))
+
+	cm := &corev1.ConfigMap{}
+	assert.NilError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDRedisHAConfigMapName, Namespace: testNamespace}, cm))
+	assert.Assert(t, strings.Contains(cm.Data["sentinel.conf"], "sentinel down-after-milliseconds argocd 10000\n"))
+	assert.Assert(t, strings.Contains(cm.Data["sentinel.conf"], "sentinel failover-timeout argocd 180000\n"))
+	assert.Assert(t, strings.Contains(cm.Data["init.sh"], `QUORUM="2"`))
+	assert.Assert(t, strings.Contains(cm.Data["redis.conf"], "save \"\"\n"))
+	assert.Assert(t, !strings.Contains(cm.Data["haproxy.cfg"], "announce-3"))
+
+	// Changes to the topology update the existing ConfigMap.
+	a.Spec.HA.RedisReplicas = int32Ptr(5)
+	a.Spec.HA.SentinelQuorum = int32Ptr(3)
+	a.Spec.HA.SentinelDownAfter = &metav1.Duration{Duration: 5 * time.Second}
+	a.Spec.HA.SentinelFailoverTimeout = &metav1.Duration{Duration: time.Minute}
+	a.Spec.HA.RedisStorage = &corev1.PersistentVolumeClaimSpec{}
+	assert.NilError(t, r.reconcileRedisHAConfigMap(a))
+	assert.NilError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDRedisHAConfigMapName, Namespace: testNamespace}, cm))
+
+	assert.Assert(t, strings.Contains(cm.Data["sentinel.conf"], "sentinel down-after-milliseconds argocd 5000\n"))
+	assert.Assert(t, strings.Contains(cm.Data["sentinel.conf"], "sentinel failover-timeout argocd 60000\n"))
+	assert.Assert(t, strings.Contains(cm.Data["init.sh"], `QUORUM="3"`))
+	assert.Assert(t, strings.Contains(cm.Data["redis.conf"], "save 900 1\n"))
+	assert.Assert(t, !strings.Contains(cm.Data["redis.conf"], "save \"\""))
+
+	haproxyConfig := cm.Data["haproxy.cfg"]
+	assert.Assert(t, strings.Contains(haproxyConfig, "backend check_if_redis_is_master_4\n"))
+	assert.Assert(t, strings.Contains(haproxyConfig, "    server R4 argocd-redis-ha-announce-4:26379 check inter 3s\n"))
+	assert.Assert(t, strings.Contains(haproxyConfig, "use-server R4 if { srv_is_up(R4) } { nbsrv(check_if_redis_is_master_4) ge 3 }\n"))
+	assert.Equal(t, strings.Count(haproxyConfig, ":26379 check"), 25)
+	assert.Assert(t, strings.Contains(cm.Data["haproxy_init.sh"], `sed -i "s/REPLACE_ANNOUNCE4/$ANNOUNCE_IP4/" "$HAPROXY_CONF"`))
+	assert.Equal(t, strings.Count(cm.Data["haproxy_init.sh"], "REPLACE_AUTH_SECRET"), 1)
+}
@@ -39,6 +39,17 @@ func (r *ReconcileArgoCD) clusterResourceMapper(o client.Object) []reconcile.Req
 func (r *ReconcileArgoCD) tlsSecretMapper(o client.Object) []reconcile.Request {
 	var result = []reconcile.Request{}
 
+	// Secrets issued by cert-manager for the certificates of an ArgoCD are
+	// annotated with the name of the ArgoCD by the Certificate's template.
+	annotations := o.GetAnnotations()
+	if _, ok := annotations[common.AnnotationCertManagerCertificateName]; ok {
+		if owner, ok := annotations[common.AnnotationName]; ok {
+			return []reconcile.Request{
+				{NamespacedName: client.ObjectKey{Name: owner, Namespace: o.GetNamespace()}},
+			}
+		}
+	}
+
 	// The secret must end with '-repo-server-tls'
 	if !strings.HasSuffix(o.GetName(), "-repo-server-tls") {
 		return result
@@ -125,3 +136,59 @@ func (r *ReconcileArgoCD) namespaceResourceMapper(o client.Object) []reconcile.R
 
 	return result
 }
+
+// clusterSecretMapper maps a watch event on a cluster secret back to the
+// ArgoCD objects in the same namespace that size their Application Controller
+// shards dynamically or assign the shards of the clusters.
+func (r *ReconcileArgoCD) clusterSecretMapper(o client.Object) []reconcile.Request {
+	var result = []reconcile.Request{}
+
+	if o.GetLabels()[common.ArgoCDSecretTypeLabel] != "cluster" {
+		return result
+	}
+
+	argocds := &argoprojv1alpha1.ArgoCDList{}
+	if err := r.Client.List(context.TODO(), argocds, &client.ListOptions{Namespace: o.GetNamespace()}); err != nil {
+		log.Error(err, fmt.Sprintf("could not list ArgoCD instances in namespace %s", o.GetNamespace()))
+		return result
+	}
+
+	for _, argocd := range argocds.Items {
+		if !argocd.Spec.Controller.Sharding.DynamicScalingEnabled && !argocd.Spec.Controller.Sharding.BalancedAssignment {
+			continue
+		}
+		result = append(result, reconcile.Request{
+			NamespacedName: client.ObjectKey{Name: argocd.Name, Namespace: argocd.Namespace},
+		})
+	}
+
+	return result
+}
+
+// appSetWebhookSecretMapper maps a watch event on a secret back to the ArgoCD
+// objects in the same namespace that reference it as a webhook secret of the
+// ApplicationSet controller.
+func (r *ReconcileArgoCD) appSetWebhookSecretMapper(o client.Object) []reconcile.Request {
+	var result = []reconcile.Request{}
+
+	argocds := &argoprojv1alpha1.ArgoCDList{}
+	if err := r.Client.List(context.TODO(), argocds, &client.ListOptions{Namespace: o.GetNamespace()}); err != nil {
+		log.Error(err, fmt.Sprintf("could not list ArgoCD instances in namespace %s", o.GetNamespace()))
+		return result
+	}
+
+	for i := range argocds.Items {
+		argocd := &argocds.Items[i]
+		for _, ref := range getApplicationSetWebhookSecretRefs(argocd) {
+			if ref.Name != o.GetName() {
+				continue
+			}
+			result = append(result, reconcile.Request{
+				NamespacedName: client.ObjectKey{Name: argocd.Name, Namespace: argocd.Namespace},
+			})
+			break
+		}
+	}
+
+	return result
+}
@@ -318,6 +318,40 @@ func TestReconcileArgoCD_tlsSecretMapper(t *testing.T) {
 		}
 	})
 
+	t.Run("Map secret issued by cert-manager", func(t *testing.T) {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "argocd-operator-redis-tls",
+				Namespace: "argocd-operator",
+				Annotations: map[string]string{
+					common.AnnotationCertManagerCertificateName: "argocd-redis",
+					common.AnnotationName:                       "argocd",
+				},
+			},
+			Type: corev1.SecretTypeTLS,
+			Data: map[string][]byte{
+				corev1.TLSCertKey:       []byte("foo"),
+				corev1.TLSPrivateKeyKey: []byte("bar"),
+			},
+		}
+		objs := []runtime.Object{
+			secret,
+		}
+		r := makeReconciler(t, argocd, objs...)
+		want := []reconcile.Request{
+			{
+				NamespacedName: types.NamespacedName{
+					Name:      "argocd",
+					Namespace: "argocd-operator",
+				},
+			},
+		}
+		got := r.tlsSecretMapper(secret)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Reconciliation unsucessful: got: %v, want: %v", got, want)
+		}
+	})
+
 }
 
 func TestReconcileArgoCD_namespaceResourceMapper(t *testing.T) {
@@ -375,3 +409,73 @@ func TestReconcileArgoCD_namespaceResourceMapper(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileArgoCD_clusterSecretMapper(t *testing.T) {
+	a := makeTestArgoCD(func(a *v1alpha1.ArgoCD) {
+		a.Spec.Controller.Sharding.DynamicScalingEnabled = true
+	})
+	r := makeTestReconciler(t, a)
+
+	type test struct {
+		name string
+		o    client.Object
+		want []reconcile.Request
+	}
+
+	tests := []test{
+		{
+			name: "test when secret is a cluster secret",
+			o: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "prod-cluster",
+					Namespace: a.Namespace,
+					Labels: map[string]string{
+						common.ArgoCDSecretTypeLabel: "cluster",
+					},
+				},
+			},
+			want: []reconcile.Request{
+				{
+					NamespacedName: types.NamespacedName{
+						Name:      a.Name,
+						Namespace: a.Namespace,
+					},
+				},
+			},
+		},
+		{
+			name: "test when secret is not a cluster secret",
+			o: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "prod-repo",
+					Namespace: a.Namespace,
+					Labels: map[string]string{
+						common.ArgoCDSecretTypeLabel: "repository",
+					},
+				},
+			},
+			want: []reconcile.Request{},
+		},
+		{
+			name: "test when cluster secret is in another namespace",
+			o: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "prod-cluster",
+					Namespace: "other",
+					Labels: map[string]string{
+						common.ArgoCDSecretTypeLabel: "cluster",
+					},
+				},
+			},
+			want: []reconcile.Request{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.clusterSecretMapper(tt.o); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReconcileArgoCD.clusterSecretMapper(), got = %v, want = %v", got, tt.want)
+			}
+		})
+	}
+}
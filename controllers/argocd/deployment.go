@@ -16,6 +16,8 @@ package argocd
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/json"
 	"fmt"
 	"os"
 	"reflect"
@@ -28,8 +30,11 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
@@ -191,8 +196,7 @@ func getArgoRepoCommand(cr *argoprojv1a1.ArgoCD) []string {
 	cmd = append(cmd, "uid_entrypoint.sh")
 	cmd = append(cmd, "argocd-repo-server")
 
-	cmd = append(cmd, "--redis")
-	cmd = append(cmd, getRedisServerAddress(cr))
+	cmd = append(cmd, getRedisClientArgs(cr)...)
 
 	cmd = append(cmd, "--loglevel")
 	cmd = append(cmd, getLogLevel(cr.Spec.Repo.LogLevel))
@@ -225,8 +229,7 @@ func getArgoServerCommand(cr *argoprojv1a1.ArgoCD) []string {
 	cmd = append(cmd, "--repo-server")
 	cmd = append(cmd, getRepoServerAddress(cr))
 
-	cmd = append(cmd, "--redis")
-	cmd = append(cmd, getRedisServerAddress(cr))
+	cmd = append(cmd, getRedisClientArgs(cr)...)
 
 	cmd = append(cmd, "--loglevel")
 	cmd = append(cmd, getLogLevel(cr.Spec.Server.LogLevel))
@@ -333,6 +336,7 @@ func (r *ReconcileArgoCD) reconcileDeployments(cr *argoprojv1a1.ArgoCD) error {
 // reconcileDexDeployment will ensure the Deployment resource is present for the ArgoCD Dex component.
 func (r *ReconcileArgoCD) reconcileDexDeployment(cr *argoprojv1a1.ArgoCD) error {
 	deploy := newDeploymentWithSuffix("dex-server", "dex-server", cr)
+	deploy.Spec.Replicas = cr.Spec.Dex.Replicas
 	deploy.Spec.Template.Spec.Containers = []corev1.Container{{
 		Command: []string{
 			"/shared/argocd-dex",
@@ -383,6 +387,13 @@ func (r *ReconcileArgoCD) reconcileDexDeployment(cr *argoprojv1a1.ArgoCD) error
 			EmptyDir: &corev1.EmptyDirVolumeSource{},
 		},
 	}}
+
+	applyPodScheduling(&deploy.Spec.Template.Spec, cr.Spec.Dex.Affinity, cr.Spec.Dex.TopologySpreadConstraints, cr.Spec.Dex.PriorityClassName)
+
+	if err := applyPodTemplateOverride(&deploy.Spec.Template, cr.Spec.Dex.PodTemplateOverride); err != nil {
+		return err
+	}
+
 	dexDisabled := isDexDisabled()
 	if dexDisabled {
 		log.Info("reconciling for dex, but dex is disabled")
@@ -398,7 +409,7 @@ func (r *ReconcileArgoCD) reconcileDexDeployment(cr *argoprojv1a1.ArgoCD) error
 		changed := false
 
 		actualImage := existing.Spec.Template.Spec.Containers[0].Image
-		desiredImage := getDexContainerImage(cr)
+		desiredImage := deploy.Spec.Template.Spec.Containers[0].Image
 		if actualImage != desiredImage {
 			existing.Spec.Template.Spec.Containers[0].Image = desiredImage
 			existing.Spec.Template.ObjectMeta.Labels["image.upgraded"] = time.Now().UTC().Format("01022006-150406-MST")
@@ -406,7 +417,7 @@ func (r *ReconcileArgoCD) reconcileDexDeployment(cr *argoprojv1a1.ArgoCD) error
 		}
 
 		actualImage = existing.Spec.Template.Spec.InitContainers[0].Image
-		desiredImage = getArgoContainerImage(cr)
+		desiredImage = deploy.Spec.Template.Spec.InitContainers[0].Image
 		if actualImage != desiredImage {
 			existing.Spec.Template.Spec.InitContainers[0].Image = desiredImage
 			existing.Spec.Template.ObjectMeta.Labels["image.upgraded"] = time.Now().UTC().Format("01022006-150406-MST")
@@ -430,6 +441,10 @@ func (r *ReconcileArgoCD) reconcileDexDeployment(cr *argoprojv1a1.ArgoCD) error
 			changed = true
 		}
 
+		updateDeploymentReplicas(existing, deploy, &changed)
+		if err := updatePodTemplateOverride(&existing.Spec.Template, &deploy.Spec.Template, cr.Spec.Dex.PodTemplateOverride, &changed); err != nil {
+			return err
+		}
 		if changed {
 			return r.Client.Update(context.TODO(), existing)
 		}
@@ -530,6 +545,12 @@ func (r *ReconcileArgoCD) reconcileGrafanaDeployment(cr *argoprojv1a1.ArgoCD) er
 		},
 	}
 
+	applyPodScheduling(&deploy.Spec.Template.Spec, cr.Spec.Grafana.Affinity, cr.Spec.Grafana.TopologySpreadConstraints, cr.Spec.Grafana.PriorityClassName)
+
+	if err := applyPodTemplateOverride(&deploy.Spec.Template, cr.Spec.Grafana.PodTemplateOverride); err != nil {
+		return err
+	}
+
 	existing := newDeploymentWithSuffix("grafana", "grafana", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
 		if !cr.Spec.Grafana.Enabled {
@@ -551,6 +572,9 @@ func (r *ReconcileArgoCD) reconcileGrafanaDeployment(cr *argoprojv1a1.ArgoCD) er
 			existing.Spec.Template.Spec.Containers[0].Resources = deploy.Spec.Template.Spec.Containers[0].Resources
 			changed = true
 		}
+		if err := updatePodTemplateOverride(&existing.Spec.Template, &deploy.Spec.Template, cr.Spec.Grafana.PodTemplateOverride, &changed); err != nil {
+			return err
+		}
 		if changed {
 			return r.Client.Update(context.TODO(), existing)
 		}
@@ -570,12 +594,7 @@ func (r *ReconcileArgoCD) reconcileGrafanaDeployment(cr *argoprojv1a1.ArgoCD) er
 func (r *ReconcileArgoCD) reconcileRedisDeployment(cr *argoprojv1a1.ArgoCD) error {
 	deploy := newDeploymentWithSuffix("redis", "redis", cr)
 	deploy.Spec.Template.Spec.Containers = []corev1.Container{{
-		Args: []string{
-			"--save",
-			"",
-			"--appendonly",
-			"no",
-		},
+		Args:            getRedisArgs(cr),
 		Image:           getRedisContainerImage(cr),
 		ImagePullPolicy: corev1.PullAlways,
 		Name:            "redis",
@@ -584,23 +603,31 @@ func (r *ReconcileArgoCD) reconcileRedisDeployment(cr *argoprojv1a1.ArgoCD) erro
 				ContainerPort: common.ArgoCDDefaultRedisPort,
 			},
 		},
-		Resources: getRedisResources(cr),
-		Env:       proxyEnvVars(),
+		Resources:    getRedisResources(cr),
+		Env:          proxyEnvVars(getRedisAuthEnv("REDIS_PASSWORD", cr)...),
+		VolumeMounts: getRedisTLSVolumeMounts(cr),
 	}}
+	deploy.Spec.Template.Spec.Volumes = getRedisTLSVolumes(cr)
 
 	if err := applyReconcilerHook(cr, deploy, ""); err != nil {
 		return err
 	}
 
+	applyPodScheduling(&deploy.Spec.Template.Spec, cr.Spec.Redis.Affinity, cr.Spec.Redis.TopologySpreadConstraints, cr.Spec.Redis.PriorityClassName)
+
+	if err := applyPodTemplateOverride(&deploy.Spec.Template, cr.Spec.Redis.PodTemplateOverride); err != nil {
+		return err
+	}
+
 	existing := newDeploymentWithSuffix("redis", "redis", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
-		if cr.Spec.HA.Enabled {
-			// Deployment exists but HA enabled flag has been set to true, delete the Deployment
+		if !isRedisDeploymentEnabled(cr) {
+			// Deployment exists but HA has been enabled or an external Redis is used, delete the Deployment
 			return r.Client.Delete(context.TODO(), deploy)
 		}
 		changed := false
 		actualImage := deploy.Spec.Template.Spec.Containers[0].Image
-		desiredImage := getRedisContainerImage(cr)
+		desiredImage := deploy.Spec.Template.Spec.Containers[0].Image
 		if actualImage != desiredImage {
 			existing.Spec.Template.Spec.Containers[0].Image = desiredImage
 			existing.Spec.Template.ObjectMeta.Labels["image.upgraded"] = time.Now().UTC().Format("01022006-150406-MST")
@@ -617,15 +644,31 @@ func (r *ReconcileArgoCD) reconcileRedisDeployment(cr *argoprojv1a1.ArgoCD) erro
 			existing.Spec.Template.Spec.Containers[0].Resources = deploy.Spec.Template.Spec.Containers[0].Resources
 			changed = true
 		}
+		if !reflect.DeepEqual(deploy.Spec.Template.Spec.Containers[0].Args, existing.Spec.Template.Spec.Containers[0].Args) {
+			existing.Spec.Template.Spec.Containers[0].Args = deploy.Spec.Template.Spec.Containers[0].Args
+			changed = true
+		}
+		if !reflect.DeepEqual(deploy.Spec.Template.Spec.Volumes, existing.Spec.Template.Spec.Volumes) {
+			existing.Spec.Template.Spec.Volumes = deploy.Spec.Template.Spec.Volumes
+			changed = true
+		}
+		if !reflect.DeepEqual(deploy.Spec.Template.Spec.Containers[0].VolumeMounts,
+			existing.Spec.Template.Spec.Containers[0].VolumeMounts) {
+			existing.Spec.Template.Spec.Containers[0].VolumeMounts = deploy.Spec.Template.Spec.Containers[0].VolumeMounts
+			changed = true
+		}
 
+		if err := updatePodTemplateOverride(&existing.Spec.Template, &deploy.Spec.Template, cr.Spec.Redis.PodTemplateOverride, &changed); err != nil {
+			return err
+		}
 		if changed {
 			return r.Client.Update(context.TODO(), existing)
 		}
 		return nil // Deployment found with nothing to do, move along...
 	}
 
-	if cr.Spec.HA.Enabled {
-		return nil // HA enabled, do nothing.
+	if !isRedisDeploymentEnabled(cr) {
+		return nil // HA enabled or external Redis used, do nothing.
 	}
 	if err := controllerutil.SetControllerReference(cr, deploy, r.Scheme); err != nil {
 		return err
@@ -636,32 +679,7 @@ func (r *ReconcileArgoCD) reconcileRedisDeployment(cr *argoprojv1a1.ArgoCD) erro
 // reconcileRedisHAProxyDeployment will ensure the Deployment resource is present for the Redis HA Proxy component.
 func (r *ReconcileArgoCD) reconcileRedisHAProxyDeployment(cr *argoprojv1a1.ArgoCD) error {
 	deploy := newDeploymentWithSuffix("redis-ha-haproxy", "redis", cr)
-
-	existing := newDeploymentWithSuffix("redis-ha-haproxy", "redis", cr)
-	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
-		if !cr.Spec.HA.Enabled {
-			// Deployment exists but HA enabled flag has been set to false, delete the Deployment
-			return r.Client.Delete(context.TODO(), existing)
-		}
-		changed := false
-		actualImage := existing.Spec.Template.Spec.Containers[0].Image
-		desiredImage := getRedisHAProxyContainerImage(cr)
-
-		if actualImage != desiredImage {
-			existing.Spec.Template.Spec.Containers[0].Image = desiredImage
-			existing.Spec.Template.ObjectMeta.Labels["image.upgraded"] = time.Now().UTC().Format("01022006-150406-MST")
-			changed = true
-		}
-		updateNodePlacement(existing, deploy, &changed)
-		if changed {
-			return r.Client.Update(context.TODO(), existing)
-		}
-		return nil // Deployment found, do nothing
-	}
-
-	if !cr.Spec.HA.Enabled {
-		return nil // HA not enabled, do nothing.
-	}
+	deploy.Spec.Replicas = cr.Spec.HA.RedisProxyReplicas
 
 	deploy.Spec.Template.Spec.Affinity = &corev1.Affinity{
 		PodAntiAffinity: &corev1.PodAntiAffinity{
@@ -713,7 +731,7 @@ func (r *ReconcileArgoCD) reconcileRedisHAProxyDeployment(cr *argoprojv1a1.ArgoC
 			},
 		},
 		Resources: getRedisHAProxyResources(cr),
-		VolumeMounts: []corev1.VolumeMount{
+		VolumeMounts: append([]corev1.VolumeMount{
 			{
 				Name:      "data",
 				MountPath: "/usr/local/etc/haproxy",
@@ -722,7 +740,7 @@ func (r *ReconcileArgoCD) reconcileRedisHAProxyDeployment(cr *argoprojv1a1.ArgoC
 				Name:      "shared-socket",
 				MountPath: "/run/haproxy",
 			},
-		},
+		}, getRedisTLSVolumeMounts(cr)...),
 	}}
 
 	deploy.Spec.Template.Spec.InitContainers = []corev1.Container{{
@@ -735,9 +753,9 @@ func (r *ReconcileArgoCD) reconcileRedisHAProxyDeployment(cr *argoprojv1a1.ArgoC
 		Image:           getRedisHAProxyContainerImage(cr),
 		ImagePullPolicy: corev1.PullIfNotPresent,
 		Name:            "config-init",
-		Env:             proxyEnvVars(),
+		Env:             proxyEnvVars(getRedisAuthEnv("AUTH", cr)...),
 		Resources:       getRedisHAProxyResources(cr),
-		VolumeMounts: []corev1.VolumeMount{
+		VolumeMounts: append([]corev1.VolumeMount{
 			{
 				Name:      "config-volume",
 				MountPath: "/readonly",
@@ -747,7 +765,7 @@ func (r *ReconcileArgoCD) reconcileRedisHAProxyDeployment(cr *argoprojv1a1.ArgoC
 				Name:      "data",
 				MountPath: "/data",
 			},
-		},
+		}, getRedisTLSVolumeMounts(cr)...),
 	}}
 
 	deploy.Spec.Template.Spec.Volumes = []corev1.Volume{
@@ -774,6 +792,7 @@ func (r *ReconcileArgoCD) reconcileRedisHAProxyDeployment(cr *argoprojv1a1.ArgoC
 			},
 		},
 	}
+	deploy.Spec.Template.Spec.Volumes = append(deploy.Spec.Template.Spec.Volumes, getRedisTLSVolumes(cr)...)
 
 	deploy.Spec.Template.Spec.ServiceAccountName = fmt.Sprintf("%s-%s", cr.Name, "argocd-redis-ha")
 
@@ -781,6 +800,55 @@ func (r *ReconcileArgoCD) reconcileRedisHAProxyDeployment(cr *argoprojv1a1.ArgoC
 		return err
 	}
 
+	applyPodScheduling(&deploy.Spec.Template.Spec, cr.Spec.HA.Affinity, cr.Spec.HA.TopologySpreadConstraints, cr.Spec.HA.PriorityClassName)
+
+	if err := applyPodTemplateOverride(&deploy.Spec.Template, cr.Spec.HA.PodTemplateOverride); err != nil {
+		return err
+	}
+
+	existing := newDeploymentWithSuffix("redis-ha-haproxy", "redis", cr)
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
+		if !isRedisHAEnabled(cr) {
+			// Deployment exists but HA has been disabled or an external Redis is used, delete the Deployment
+			return r.Client.Delete(context.TODO(), existing)
+		}
+		changed := false
+		actualImage := existing.Spec.Template.Spec.Containers[0].Image
+		desiredImage := deploy.Spec.Template.Spec.Containers[0].Image
+
+		if actualImage != desiredImage {
+			existing.Spec.Template.Spec.Containers[0].Image = desiredImage
+			existing.Spec.Template.ObjectMeta.Labels["image.upgraded"] = time.Now().UTC().Format("01022006-150406-MST")
+			changed = true
+		}
+		updateNodePlacement(existing, deploy, &changed)
+		updateDeploymentReplicas(existing, deploy, &changed)
+		if !reflect.DeepEqual(deploy.Spec.Template.Spec.Volumes, existing.Spec.Template.Spec.Volumes) {
+			existing.Spec.Template.Spec.Volumes = deploy.Spec.Template.Spec.Volumes
+			changed = true
+		}
+		if !reflect.DeepEqual(deploy.Spec.Template.Spec.Containers[0].VolumeMounts,
+			existing.Spec.Template.Spec.Containers[0].VolumeMounts) {
+			existing.Spec.Template.Spec.Containers[0].VolumeMounts = deploy.Spec.Template.Spec.Containers[0].VolumeMounts
+			changed = true
+		}
+		if !reflect.DeepEqual(deploy.Spec.Template.Spec.InitContainers, existing.Spec.Template.Spec.InitContainers) {
+			existing.Spec.Template.Spec.InitContainers = deploy.Spec.Template.Spec.InitContainers
+			changed = true
+		}
+		if err := updatePodTemplateOverride(&existing.Spec.Template, &deploy.Spec.Template, cr.Spec.HA.PodTemplateOverride, &changed); err != nil {
+			return err
+		}
+		if changed {
+			return r.Client.Update(context.TODO(), existing)
+		}
+		return nil // Deployment found, do nothing
+	}
+
+	if !isRedisHAEnabled(cr) {
+		return nil // HA not enabled or external Redis used, do nothing.
+	}
+
 	if err := controllerutil.SetControllerReference(cr, deploy, r.Scheme); err != nil {
 		return err
 	}
@@ -790,6 +858,7 @@ func (r *ReconcileArgoCD) reconcileRedisHAProxyDeployment(cr *argoprojv1a1.ArgoC
 // reconcileRepoDeployment will ensure the Deployment resource is present for the ArgoCD Repo component.
 func (r *ReconcileArgoCD) reconcileRepoDeployment(cr *argoprojv1a1.ArgoCD) error {
 	deploy := newDeploymentWithSuffix("repo-server", "repo-server", cr)
+	deploy.Spec.Replicas = getArgoRepoReplicas(cr)
 	automountToken := false
 	if cr.Spec.Repo.MountSAToken {
 		automountToken = cr.Spec.Repo.MountSAToken
@@ -808,6 +877,7 @@ func (r *ReconcileArgoCD) reconcileRepoDeployment(cr *argoprojv1a1.ArgoCD) error
 	if cr.Spec.Repo.ExecTimeout != nil {
 		repoEnv = argoutil.EnvMerge(repoEnv, []corev1.EnvVar{{Name: "ARGOCD_EXEC_TIMEOUT", Value: fmt.Sprintf("%d", *cr.Spec.Repo.ExecTimeout)}}, true)
 	}
+	repoEnv = argoutil.EnvMerge(repoEnv, getRedisAuthEnv("REDIS_PASSWORD", cr), true)
 
 	deploy.Spec.Template.Spec.Containers = []corev1.Container{{
 		Command:         getArgoRepoCommand(cr),
@@ -914,18 +984,51 @@ func (r *ReconcileArgoCD) reconcileRepoDeployment(cr *argoprojv1a1.ArgoCD) error
 			},
 		},
 	}
+	deploy.Spec.Template.Spec.Volumes = append(deploy.Spec.Template.Spec.Volumes, getRedisTLSVolumes(cr)...)
+	deploy.Spec.Template.Spec.Containers[0].VolumeMounts = append(deploy.Spec.Template.Spec.Containers[0].VolumeMounts, getRedisTLSVolumeMounts(cr)...)
+
+	// Config Management Plugins run as sidecars, sharing the argocd-cmp-server binary and plugin sockets with the repo server
+	if len(cr.Spec.Repo.Plugins) > 0 {
+		checksum, err := getCMPPluginsChecksum(cr)
+		if err != nil {
+			return err
+		}
+		deploy.Spec.Template.ObjectMeta.Annotations = map[string]string{common.ArgoCDCMPPluginsChecksumAnnotation: checksum}
+		deploy.Spec.Template.Spec.Volumes = append(deploy.Spec.Template.Spec.Volumes, getCMPPluginVolumes(cr)...)
+		deploy.Spec.Template.Spec.Containers[0].VolumeMounts = append(deploy.Spec.Template.Spec.Containers[0].VolumeMounts, getCMPRepoServerVolumeMounts()...)
+		deploy.Spec.Template.Spec.InitContainers = append(deploy.Spec.Template.Spec.InitContainers, getCMPCopyUtilContainer(cr))
+		deploy.Spec.Template.Spec.Containers = append(deploy.Spec.Template.Spec.Containers, getCMPPluginContainers(cr)...)
+	}
+
+	// Extra volumes, init containers and sidecars specified in the CR are added to the generated ones
+	deploy.Spec.Template.Spec.Volumes = append(deploy.Spec.Template.Spec.Volumes, cr.Spec.Repo.Volumes...)
+	deploy.Spec.Template.Spec.Containers[0].VolumeMounts = append(deploy.Spec.Template.Spec.Containers[0].VolumeMounts, cr.Spec.Repo.VolumeMounts...)
+	deploy.Spec.Template.Spec.InitContainers = append(deploy.Spec.Template.Spec.InitContainers, cr.Spec.Repo.InitContainers...)
+	deploy.Spec.Template.Spec.Containers = append(deploy.Spec.Template.Spec.Containers, cr.Spec.Repo.SidecarContainers...)
+
+	deploy.Spec.Template.Spec.TopologySpreadConstraints = getDefaultTopologySpreadConstraints(cr, deploy.Spec.Selector)
+	applyPodScheduling(&deploy.Spec.Template.Spec, cr.Spec.Repo.Affinity, cr.Spec.Repo.TopologySpreadConstraints, cr.Spec.Repo.PriorityClassName)
+
+	if err := applyPodTemplateOverride(&deploy.Spec.Template, cr.Spec.Repo.PodTemplateOverride); err != nil {
+		return err
+	}
 
 	existing := newDeploymentWithSuffix("repo-server", "repo-server", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
 		changed := false
 		actualImage := existing.Spec.Template.Spec.Containers[0].Image
-		desiredImage := getRepoServerContainerImage(cr)
+		desiredImage := deploy.Spec.Template.Spec.Containers[0].Image
 		if actualImage != desiredImage {
 			existing.Spec.Template.Spec.Containers[0].Image = desiredImage
 			existing.Spec.Template.ObjectMeta.Labels["image.upgraded"] = time.Now().UTC().Format("01022006-150406-MST")
 			changed = true
 		}
 		updateNodePlacement(existing, deploy, &changed)
+		if !reflect.DeepEqual(existing.Spec.Template.Spec.Containers[0].Command,
+			deploy.Spec.Template.Spec.Containers[0].Command) {
+			existing.Spec.Template.Spec.Containers[0].Command = deploy.Spec.Template.Spec.Containers[0].Command
+			changed = true
+		}
 		if !reflect.DeepEqual(deploy.Spec.Template.Spec.Volumes, existing.Spec.Template.Spec.Volumes) {
 			existing.Spec.Template.Spec.Volumes = deploy.Spec.Template.Spec.Volumes
 			changed = true
@@ -935,7 +1038,27 @@ func (r *ReconcileArgoCD) reconcileRepoDeployment(cr *argoprojv1a1.ArgoCD) error
 			existing.Spec.Template.Spec.Containers[0].VolumeMounts = deploy.Spec.Template.Spec.Containers[0].VolumeMounts
 			changed = true
 		}
-		if !reflect.DeepEqual(deploy.Spec.Template.Spec.Containers[0].Env,
+		desiredChecksum := deploy.Spec.Template.Annotations[common.ArgoCDCMPPluginsChecksumAnnotation]
+		if existing.Spec.Template.Annotations[common.ArgoCDCMPPluginsChecksumAnnotation] != desiredChecksum {
+			if existing.Spec.Template.Annotations == nil {
+				existing.Spec.Template.Annotations = map[string]string{}
+			}
+			existing.Spec.Template.Annotations[common.ArgoCDCMPPluginsChecksumAnnotation] = desiredChecksum
+			if desiredChecksum == "" {
+				delete(existing.Spec.Template.Annotations, common.ArgoCDCMPPluginsChecksumAnnotation)
+			}
+			changed = true
+		}
+		if !containersEqual(deploy.Spec.Template.Spec.InitContainers, existing.Spec.Template.Spec.InitContainers) {
+			existing.Spec.Template.Spec.InitContainers = deploy.Spec.Template.Spec.InitContainers
+			changed = true
+		}
+		if !containersEqual(deploy.Spec.Template.Spec.Containers[1:], existing.Spec.Template.Spec.Containers[1:]) {
+			existing.Spec.Template.Spec.Containers = append(existing.Spec.Template.Spec.Containers[:1],
+				deploy.Spec.Template.Spec.Containers[1:]...)
+			changed = true
+		}
+		if !equality.Semantic.DeepEqual(deploy.Spec.Template.Spec.Containers[0].Env,
 			existing.Spec.Template.Spec.Containers[0].Env) {
 			existing.Spec.Template.Spec.Containers[0].Env = deploy.Spec.Template.Spec.Containers[0].Env
 			changed = true
@@ -945,6 +1068,10 @@ func (r *ReconcileArgoCD) reconcileRepoDeployment(cr *argoprojv1a1.ArgoCD) error
 			changed = true
 		}
 
+		updateDeploymentReplicas(existing, deploy, &changed)
+		if err := updatePodTemplateOverride(&existing.Spec.Template, &deploy.Spec.Template, cr.Spec.Repo.PodTemplateOverride, &changed); err != nil {
+			return err
+		}
 		if changed {
 			return r.Client.Update(context.TODO(), existing)
 		}
@@ -960,8 +1087,10 @@ func (r *ReconcileArgoCD) reconcileRepoDeployment(cr *argoprojv1a1.ArgoCD) error
 // reconcileServerDeployment will ensure the Deployment resource is present for the ArgoCD Server component.
 func (r *ReconcileArgoCD) reconcileServerDeployment(cr *argoprojv1a1.ArgoCD) error {
 	deploy := newDeploymentWithSuffix("server", "server", cr)
+	deploy.Spec.Replicas = getArgoServerReplicas(cr)
 	serverEnv := cr.Spec.Server.Env
 	serverEnv = argoutil.EnvMerge(serverEnv, proxyEnvVars(), false)
+	serverEnv = argoutil.EnvMerge(serverEnv, getRedisAuthEnv("REDIS_PASSWORD", cr), true)
 	deploy.Spec.Template.Spec.Containers = []corev1.Container{{
 		Command:         getArgoServerCommand(cr),
 		Image:           getArgoContainerImage(cr),
@@ -1040,11 +1169,20 @@ func (r *ReconcileArgoCD) reconcileServerDeployment(cr *argoprojv1a1.ArgoCD) err
 			},
 		},
 	}
+	deploy.Spec.Template.Spec.Volumes = append(deploy.Spec.Template.Spec.Volumes, getRedisTLSVolumes(cr)...)
+	deploy.Spec.Template.Spec.Containers[0].VolumeMounts = append(deploy.Spec.Template.Spec.Containers[0].VolumeMounts, getRedisTLSVolumeMounts(cr)...)
+
+	deploy.Spec.Template.Spec.TopologySpreadConstraints = getDefaultTopologySpreadConstraints(cr, deploy.Spec.Selector)
+	applyPodScheduling(&deploy.Spec.Template.Spec, cr.Spec.Server.Affinity, cr.Spec.Server.TopologySpreadConstraints, cr.Spec.Server.PriorityClassName)
+
+	if err := applyPodTemplateOverride(&deploy.Spec.Template, cr.Spec.Server.PodTemplateOverride); err != nil {
+		return err
+	}
 
 	existing := newDeploymentWithSuffix("server", "server", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
 		actualImage := existing.Spec.Template.Spec.Containers[0].Image
-		desiredImage := getArgoContainerImage(cr)
+		desiredImage := deploy.Spec.Template.Spec.Containers[0].Image
 		changed := false
 		if actualImage != desiredImage {
 			existing.Spec.Template.Spec.Containers[0].Image = desiredImage
@@ -1076,6 +1214,10 @@ func (r *ReconcileArgoCD) reconcileServerDeployment(cr *argoprojv1a1.ArgoCD) err
 			existing.Spec.Template.Spec.Containers[0].Resources = deploy.Spec.Template.Spec.Containers[0].Resources
 			changed = true
 		}
+		updateDeploymentReplicas(existing, deploy, &changed)
+		if err := updatePodTemplateOverride(&existing.Spec.Template, &deploy.Spec.Template, cr.Spec.Server.PodTemplateOverride, &changed); err != nil {
+			return err
+		}
 		if changed {
 			return r.Client.Update(context.TODO(), existing)
 		}
@@ -1131,7 +1273,7 @@ func isDexDisabled() bool {
 	return false
 }
 
-// to update nodeSelector and tolerations in reconciler
+// to update nodeSelector, tolerations, affinity, topology spread constraints and priority class in reconciler
 func updateNodePlacement(existing *appsv1.Deployment, deploy *appsv1.Deployment, changed *bool) {
 	if !reflect.DeepEqual(existing.Spec.Template.Spec.NodeSelector, deploy.Spec.Template.Spec.NodeSelector) {
 		existing.Spec.Template.Spec.NodeSelector = deploy.Spec.Template.Spec.NodeSelector
@@ -1141,4 +1283,175 @@ func updateNodePlacement(existing *appsv1.Deployment, deploy *appsv1.Deployment,
 		existing.Spec.Template.Spec.Tolerations = deploy.Spec.Template.Spec.Tolerations
 		*changed = true
 	}
+	updatePodScheduling(&existing.Spec.Template.Spec, &deploy.Spec.Template.Spec, changed)
+}
+
+// updatePodScheduling will update the affinity, topology spread constraints and priority class of the existing pod spec.
+func updatePodScheduling(existing *corev1.PodSpec, desired *corev1.PodSpec, changed *bool) {
+	if !reflect.DeepEqual(existing.Affinity, desired.Affinity) {
+		existing.Affinity = desired.Affinity
+		*changed = true
+	}
+	if !reflect.DeepEqual(existing.TopologySpreadConstraints, desired.TopologySpreadConstraints) {
+		existing.TopologySpreadConstraints = desired.TopologySpreadConstraints
+		*changed = true
+	}
+	if existing.PriorityClassName != desired.PriorityClassName {
+		existing.PriorityClassName = desired.PriorityClassName
+		*changed = true
+	}
+}
+
+// applyPodScheduling will set the scheduling constraints configured for a component on the given pod spec.
+// The affinity replaces the one set by the operator and the topology spread constraints replace the defaults.
+func applyPodScheduling(podSpec *corev1.PodSpec, affinity *corev1.Affinity, constraints []corev1.TopologySpreadConstraint, priorityClassName string) {
+	if affinity != nil {
+		podSpec.Affinity = affinity
+	}
+	if len(constraints) > 0 {
+		podSpec.TopologySpreadConstraints = constraints
+	}
+	if priorityClassName != "" {
+		podSpec.PriorityClassName = priorityClassName
+	}
+}
+
+// getDefaultTopologySpreadConstraints returns the constraints spreading the pods matched by the given selector
+// across zones, when HA is enabled.
+func getDefaultTopologySpreadConstraints(cr *argoprojv1a1.ArgoCD, selector *metav1.LabelSelector) []corev1.TopologySpreadConstraint {
+	if !cr.Spec.HA.Enabled {
+		return nil
+	}
+	return []corev1.TopologySpreadConstraint{{
+		MaxSkew:           1,
+		TopologyKey:       common.ArgoCDKeyFailureDomainZone,
+		WhenUnsatisfiable: corev1.ScheduleAnyway,
+		LabelSelector:     selector,
+	}}
+}
+
+// updateDeploymentReplicas will update the replica count of the existing Deployment, when it is managed by the operator.
+func updateDeploymentReplicas(existing *appsv1.Deployment, deploy *appsv1.Deployment, changed *bool) {
+	if deploy.Spec.Replicas != nil && !reflect.DeepEqual(existing.Spec.Replicas, deploy.Spec.Replicas) {
+		existing.Spec.Replicas = deploy.Spec.Replicas
+		*changed = true
+	}
+}
+
+// containersEqual will return true if the existing containers match the desired containers. Fields that are left
+// empty in the desired containers and defaulted by the API server, like the termination message path, are not compared,
+// so that the existing containers are not updated on every reconciliation.
+func containersEqual(desired []corev1.Container, existing []corev1.Container) bool {
+	if len(desired) != len(existing) {
+		return false
+	}
+	for i := range desired {
+		container := desired[i].DeepCopy()
+		setContainerDefaults(container, &existing[i])
+		if !equality.Semantic.DeepEqual(*container, existing[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// setContainerDefaults will set the fields of the container that are defaulted by the API server, when they are empty,
+// to the values of the existing container.
+func setContainerDefaults(container *corev1.Container, existing *corev1.Container) {
+	if container.TerminationMessagePath == "" {
+		container.TerminationMessagePath = existing.TerminationMessagePath
+	}
+	if container.TerminationMessagePolicy == "" {
+		container.TerminationMessagePolicy = existing.TerminationMessagePolicy
+	}
+	if container.ImagePullPolicy == "" {
+		container.ImagePullPolicy = existing.ImagePullPolicy
+	}
+	for i := range container.Ports {
+		if container.Ports[i].Protocol == "" && i < len(existing.Ports) {
+			container.Ports[i].Protocol = existing.Ports[i].Protocol
+		}
+	}
+	for i := range container.Env {
+		if container.Env[i].ValueFrom != nil && container.Env[i].ValueFrom.FieldRef != nil &&
+			container.Env[i].ValueFrom.FieldRef.APIVersion == "" && i < len(existing.Env) &&
+			existing.Env[i].ValueFrom != nil && existing.Env[i].ValueFrom.FieldRef != nil {
+			container.Env[i].ValueFrom.FieldRef.APIVersion = existing.Env[i].ValueFrom.FieldRef.APIVersion
+		}
+	}
+	setProbeDefaults(container.LivenessProbe, existing.LivenessProbe)
+	setProbeDefaults(container.ReadinessProbe, existing.ReadinessProbe)
+	setProbeDefaults(container.StartupProbe, existing.StartupProbe)
+}
+
+// setProbeDefaults will set the fields of the probe that are defaulted by the API server, when they are empty, to the
+// values of the existing probe.
+func setProbeDefaults(probe *corev1.Probe, existing *corev1.Probe) {
+	if probe == nil || existing == nil {
+		return
+	}
+	if probe.TimeoutSeconds == 0 {
+		probe.TimeoutSeconds = existing.TimeoutSeconds
+	}
+	if probe.PeriodSeconds == 0 {
+		probe.PeriodSeconds = existing.PeriodSeconds
+	}
+	if probe.SuccessThreshold == 0 {
+		probe.SuccessThreshold = existing.SuccessThreshold
+	}
+	if probe.FailureThreshold == 0 {
+		probe.FailureThreshold = existing.FailureThreshold
+	}
+	if probe.HTTPGet != nil && existing.HTTPGet != nil && probe.HTTPGet.Scheme == "" {
+		probe.HTTPGet.Scheme = existing.HTTPGet.Scheme
+	}
+}
+
+// applyPodTemplateOverride will apply the given override to the pod template as a strategic merge patch.
+func applyPodTemplateOverride(template *corev1.PodTemplateSpec, override *runtime.RawExtension) error {
+	if override == nil || len(override.Raw) == 0 {
+		return nil
+	}
+
+	original, err := json.Marshal(template)
+	if err != nil {
+		return err
+	}
+
+	patched, err := strategicpatch.StrategicMergePatch(original, override.Raw, corev1.PodTemplateSpec{})
+	if err != nil {
+		return fmt.Errorf("unable to apply pod template override: %w", err)
+	}
+
+	result := corev1.PodTemplateSpec{}
+	if err := json.Unmarshal(patched, &result); err != nil {
+		return fmt.Errorf("unable to apply pod template override: %w", err)
+	}
+	if result.Annotations == nil {
+		result.Annotations = map[string]string{}
+	}
+	result.Annotations[common.ArgoCDPodTemplateOverrideChecksumAnnotation] = fmt.Sprintf("%x", sha256.Sum256(override.Raw))
+	*template = result
+	return nil
+}
+
+// updatePodTemplateOverride will update the existing pod template with the given override. When the override was added,
+// changed or removed since it was last applied, the existing pod template is replaced with the desired one, so that the
+// fields set by the previous override do not remain on the pods.
+func updatePodTemplateOverride(existing *corev1.PodTemplateSpec, desired *corev1.PodTemplateSpec, override *runtime.RawExtension, changed *bool) error {
+	if existing.Annotations[common.ArgoCDPodTemplateOverrideChecksumAnnotation] != desired.Annotations[common.ArgoCDPodTemplateOverrideChecksumAnnotation] {
+		*existing = *desired.DeepCopy()
+		*changed = true
+		return nil
+	}
+
+	patched := existing.DeepCopy()
+	if err := applyPodTemplateOverride(patched, override); err != nil {
+		return err
+	}
+	if !equality.Semantic.DeepEqual(existing, patched) {
+		*existing = *patched
+		*changed = true
+	}
+	return nil
 }
@@ -12,6 +12,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	resourcev1 "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -950,6 +951,194 @@ func TestReconcileArgoCD_reconcileRedisDeployment_with_error(t *testing.T) {
 	assert.Error(t, r.reconcileRedisDeployment(cr), "this is a test error")
 }
 
+func TestReconcileArgoCD_reconcileRepoDeployment_extraVolumesAndContainers(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	r := makeTestReconciler(t, a)
+
+	assert.NoError(t, r.reconcileRepoDeployment(a))
+
+	// Extra volumes and containers added to an existing Deployment are detected as an update.
+	a.Spec.Repo.Volumes = []corev1.Volume{{
+		Name:         "custom-tools",
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	}}
+	a.Spec.Repo.VolumeMounts = []corev1.VolumeMount{{Name: "custom-tools", MountPath: "/usr/local/bin/sops", SubPath: "sops"}}
+	a.Spec.Repo.InitContainers = []corev1.Container{{
+		Name:         "download-tools",
+		Image:        "alpine:3.14",
+		Command:      []string{"sh", "-c", "wget -qO /custom-tools/sops https://example.com/sops"},
+		VolumeMounts: []corev1.VolumeMount{{Name: "custom-tools", MountPath: "/custom-tools"}},
+	}}
+	a.Spec.Repo.SidecarContainers = []corev1.Container{{Name: "helm-secrets", Image: "example.com/helm-secrets:latest"}}
+	assert.NoError(t, r.reconcileRepoDeployment(a))
+
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, deployment))
+
+	podSpec := deployment.Spec.Template.Spec
+	assert.Equal(t, append(repoServerDefaultVolumes(), a.Spec.Repo.Volumes...), podSpec.Volumes)
+	assert.Equal(t, append(repoServerDefaultVolumeMounts(), a.Spec.Repo.VolumeMounts...), podSpec.Containers[0].VolumeMounts)
+	assert.Equal(t, a.Spec.Repo.InitContainers, podSpec.InitContainers)
+	assert.Len(t, podSpec.Containers, 2)
+	assert.Equal(t, "argocd-repo-server", podSpec.Containers[0].Name)
+	assert.Equal(t, a.Spec.Repo.SidecarContainers[0], podSpec.Containers[1])
+
+	// Removing them from the CR removes them from the Deployment.
+	a.Spec.Repo.Volumes = nil
+	a.Spec.Repo.VolumeMounts = nil
+	a.Spec.Repo.InitContainers = nil
+	a.Spec.Repo.SidecarContainers = nil
+	assert.NoError(t, r.reconcileRepoDeployment(a))
+
+	deployment = &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, deployment))
+	podSpec = deployment.Spec.Template.Spec
+	assert.Equal(t, repoServerDefaultVolumes(), podSpec.Volumes)
+	assert.Equal(t, repoServerDefaultVolumeMounts(), podSpec.Containers[0].VolumeMounts)
+	assert.Empty(t, podSpec.InitContainers)
+	assert.Len(t, podSpec.Containers, 1)
+}
+
+func TestReconcileArgoCD_reconcileRepoDeployment_defaultedContainers(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(withRepoPlugins(makeTestRepoPlugin("sops")), func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Repo.InitContainers = []corev1.Container{{Name: "download-tools", Image: "alpine:3.14"}}
+		a.Spec.Repo.SidecarContainers = []corev1.Container{{
+			Name:  "helm-secrets",
+			Image: "example.com/helm-secrets:latest",
+			Ports: []corev1.ContainerPort{{ContainerPort: 8080}},
+		}}
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NoError(t, r.reconcileRepoDeployment(a))
+
+	// Default the container fields left empty by the operator, as the API server does.
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, deployment))
+	podSpec := &deployment.Spec.Template.Spec
+	assert.Len(t, podSpec.InitContainers, 2)
+	assert.Len(t, podSpec.Containers, 3)
+	for i := range podSpec.InitContainers {
+		setTestContainerDefaults(&podSpec.InitContainers[i])
+	}
+	for i := range podSpec.Containers {
+		setTestContainerDefaults(&podSpec.Containers[i])
+	}
+	assert.NoError(t, r.Client.Update(context.TODO(), deployment))
+
+	// Reconciling again does not update the Deployment.
+	resourceVersion := deployment.ResourceVersion
+	assert.NoError(t, r.reconcileRepoDeployment(a))
+	deployment = &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, deployment))
+	assert.Equal(t, resourceVersion, deployment.ResourceVersion)
+
+	// Changes to the containers are still detected.
+	a.Spec.Repo.SidecarContainers[0].Ports[0].ContainerPort = 9090
+	assert.NoError(t, r.reconcileRepoDeployment(a))
+	deployment = &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, deployment))
+	assert.NotEqual(t, resourceVersion, deployment.ResourceVersion)
+	assert.Equal(t, int32(9090), deployment.Spec.Template.Spec.Containers[2].Ports[0].ContainerPort)
+}
+
+func setTestContainerDefaults(container *corev1.Container) {
+	container.TerminationMessagePath = corev1.TerminationMessagePathDefault
+	container.TerminationMessagePolicy = corev1.TerminationMessageReadFile
+	if container.ImagePullPolicy == "" {
+		container.ImagePullPolicy = corev1.PullIfNotPresent
+	}
+	for i := range container.Ports {
+		if container.Ports[i].Protocol == "" {
+			container.Ports[i].Protocol = corev1.ProtocolTCP
+		}
+	}
+}
+
+func TestReconcileArgoCD_reconcileRepoDeployment_podTemplateOverride(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Repo.Env = []corev1.EnvVar{{Name: "FOO", Value: "bar"}}
+		a.Spec.Repo.PodTemplateOverride = &runtime.RawExtension{Raw: []byte(`{
+			"metadata": {"annotations": {"example.com/scrape": "true"}},
+			"spec": {
+				"hostAliases": [{"ip": "10.0.0.1", "hostnames": ["git.example.com"]}],
+				"securityContext": {"runAsNonRoot": true},
+				"containers": [{
+					"name": "argocd-repo-server",
+					"env": [{"name": "GIT_TOKEN", "valueFrom": {"secretKeyRef": {"name": "git", "key": "token"}}}]
+				}]
+			}
+		}`)}
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NoError(t, r.reconcileRepoDeployment(a))
+
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, deployment))
+
+	template := deployment.Spec.Template
+	assert.Equal(t, "true", template.Annotations["example.com/scrape"])
+	assert.Equal(t, []corev1.HostAlias{{IP: "10.0.0.1", Hostnames: []string{"git.example.com"}}}, template.Spec.HostAliases)
+	assert.True(t, *template.Spec.SecurityContext.RunAsNonRoot)
+	// The override is merged into the operator built container, not replacing it.
+	assert.Len(t, template.Spec.Containers, 1)
+	assert.Equal(t, getRepoServerContainerImage(a), template.Spec.Containers[0].Image)
+	assert.Len(t, template.Spec.Containers[0].VolumeMounts, 5)
+	assert.Equal(t, []string{"GIT_TOKEN", "FOO"}, envNames(template.Spec.Containers[0].Env))
+
+	// Reconciling again does not fight the override.
+	resourceVersion := deployment.ResourceVersion
+	assert.NoError(t, r.reconcileRepoDeployment(a))
+	deployment = &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, deployment))
+	assert.Equal(t, resourceVersion, deployment.ResourceVersion)
+
+	// Drift from the override is reverted.
+	deployment.Spec.Template.Spec.HostAliases = nil
+	assert.NoError(t, r.Client.Update(context.TODO(), deployment))
+	assert.NoError(t, r.reconcileRepoDeployment(a))
+	deployment = &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, deployment))
+	assert.Len(t, deployment.Spec.Template.Spec.HostAliases, 1)
+
+	// Removing the override removes the fields it set.
+	a.Spec.Repo.PodTemplateOverride = nil
+	assert.NoError(t, r.reconcileRepoDeployment(a))
+	deployment = &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, deployment))
+	template = deployment.Spec.Template
+	assert.NotContains(t, template.Annotations, "example.com/scrape")
+	assert.NotContains(t, template.Annotations, common.ArgoCDPodTemplateOverrideChecksumAnnotation)
+	assert.Empty(t, template.Spec.HostAliases)
+	assert.Nil(t, template.Spec.SecurityContext)
+	assert.Equal(t, []string{"FOO"}, envNames(template.Spec.Containers[0].Env))
+}
+
+func TestReconcileArgoCD_reconcileRepoDeployment_invalidPodTemplateOverride(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Repo.PodTemplateOverride = &runtime.RawExtension{Raw: []byte(`{"spec": {"containers": "argocd-repo-server"}}`)}
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.Error(t, r.reconcileRepoDeployment(a))
+
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, &appsv1.Deployment{})
+	assertNotFound(t, err)
+}
+
+func envNames(env []corev1.EnvVar) []string {
+	names := []string{}
+	for _, e := range env {
+		names = append(names, e.Name)
+	}
+	return names
+}
+
 func restoreEnv(t *testing.T) {
 	keys := []string{
 		"HTTP_PROXY", "HTTPS_PROXY", "NO_PROXY",
@@ -972,6 +1161,69 @@ func operationProcessors(n int32) argoCDOpt {
 	}
 }
 
+func TestReconcileArgoCD_reconcileServerDeployment_scheduling(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.HA.Enabled = true
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NoError(t, r.reconcileServerDeployment(a))
+
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: testNamespace}, deployment))
+
+	// Server pods are spread across zones by default when HA is enabled.
+	assert.Equal(t, []corev1.TopologySpreadConstraint{{
+		MaxSkew:           1,
+		TopologyKey:       common.ArgoCDKeyFailureDomainZone,
+		WhenUnsatisfiable: corev1.ScheduleAnyway,
+		LabelSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{common.ArgoCDKeyName: "argocd-server"}},
+	}}, deployment.Spec.Template.Spec.TopologySpreadConstraints)
+	assert.Nil(t, deployment.Spec.Template.Spec.Affinity)
+	assert.Empty(t, deployment.Spec.Template.Spec.PriorityClassName)
+
+	// Configured constraints replace the defaults on the existing Deployment.
+	affinity := &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+					MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "node-role.kubernetes.io/infra", Operator: corev1.NodeSelectorOpExists}},
+				}},
+			},
+		},
+	}
+	constraints := []corev1.TopologySpreadConstraint{{
+		MaxSkew:           2,
+		TopologyKey:       common.ArgoCDKeyHostname,
+		WhenUnsatisfiable: corev1.DoNotSchedule,
+		LabelSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{common.ArgoCDKeyName: "argocd-server"}},
+	}}
+	a.Spec.Server.Affinity = affinity
+	a.Spec.Server.TopologySpreadConstraints = constraints
+	a.Spec.Server.PriorityClassName = "system-cluster-critical"
+	assert.NoError(t, r.reconcileServerDeployment(a))
+
+	deployment = &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: testNamespace}, deployment))
+	assert.Equal(t, affinity, deployment.Spec.Template.Spec.Affinity)
+	assert.Equal(t, constraints, deployment.Spec.Template.Spec.TopologySpreadConstraints)
+	assert.Equal(t, "system-cluster-critical", deployment.Spec.Template.Spec.PriorityClassName)
+
+	// Removing the configuration reverts to the operator defaults.
+	a.Spec.HA.Enabled = false
+	a.Spec.Server.Affinity = nil
+	a.Spec.Server.TopologySpreadConstraints = nil
+	a.Spec.Server.PriorityClassName = ""
+	assert.NoError(t, r.reconcileServerDeployment(a))
+
+	deployment = &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: testNamespace}, deployment))
+	assert.Nil(t, deployment.Spec.Template.Spec.Affinity)
+	assert.Nil(t, deployment.Spec.Template.Spec.TopologySpreadConstraints)
+	assert.Empty(t, deployment.Spec.Template.Spec.PriorityClassName)
+}
+
 func appSync(d time.Duration) argoCDOpt {
 	return func(a *argoprojv1alpha1.ArgoCD) {
 		a.Spec.Controller.AppSync = &metav1.Duration{Duration: d}
@@ -1217,3 +1469,227 @@ func serverDefaultVolumeMounts() []corev1.VolumeMount {
 	}
 	return mounts
 }
+
+func TestReconcileArgoCD_reconcileServerDeployment_replicas(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Server.Replicas = int32Ptr(2)
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NoError(t, r.reconcileServerDeployment(a))
+
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: testNamespace}, deployment))
+	assert.Equal(t, int32(2), *deployment.Spec.Replicas)
+
+	// Manual scaling is reverted to the configured replicas.
+	deployment.Spec.Replicas = int32Ptr(5)
+	assert.NoError(t, r.Client.Update(context.TODO(), deployment))
+	assert.NoError(t, r.reconcileServerDeployment(a))
+
+	deployment = &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: testNamespace}, deployment))
+	assert.Equal(t, int32(2), *deployment.Spec.Replicas)
+
+	// The configured replicas are ignored when autoscaling is enabled.
+	a.Spec.Server.Autoscale.Enabled = true
+	deployment.Spec.Replicas = int32Ptr(4)
+	assert.NoError(t, r.Client.Update(context.TODO(), deployment))
+	assert.NoError(t, r.reconcileServerDeployment(a))
+
+	deployment = &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: testNamespace}, deployment))
+	assert.Equal(t, int32(4), *deployment.Spec.Replicas)
+}
+
+func TestReconcileArgoCD_reconcileDexDeployment_replicas(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Dex.Replicas = int32Ptr(2)
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NoError(t, r.reconcileDexDeployment(a))
+
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-dex-server", Namespace: testNamespace}, deployment))
+	assert.Equal(t, int32(2), *deployment.Spec.Replicas)
+}
+
+func TestReconcileArgoCD_reconcileRedisDeployment_authAndTLS(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	r := makeTestReconciler(t, a)
+
+	assert.NoError(t, r.reconcileRedisDeployment(a))
+
+	// Enabling authentication and TLS is applied to the existing Deployment.
+	a.Spec.Redis.AuthEnabled = true
+	a.Spec.Redis.TLSEnabled = true
+	assert.NoError(t, r.reconcileRedisDeployment(a))
+
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis", Namespace: testNamespace}, deployment))
+
+	container := deployment.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, []string{
+		"--save", "", "--appendonly", "no",
+		"--requirepass", "$(REDIS_PASSWORD)",
+		"--port", "0",
+		"--tls-port", "6379",
+		"--tls-cert-file", "/app/config/redis/tls/tls.crt",
+		"--tls-key-file", "/app/config/redis/tls/tls.key",
+		"--tls-ca-cert-file", "/app/config/redis/tls/ca.crt",
+		"--tls-auth-clients", "no",
+	}, container.Args)
+	assert.Equal(t, getRedisAuthEnv("REDIS_PASSWORD", a), container.Env)
+	assert.Equal(t, "argocd-redis", container.Env[0].ValueFrom.SecretKeyRef.Name)
+	assert.Equal(t, getRedisTLSVolumeMounts(a), container.VolumeMounts)
+	assert.Equal(t, getRedisTLSVolumes(a), deployment.Spec.Template.Spec.Volumes)
+
+	// Disabling them again restores the default Deployment.
+	a.Spec.Redis.AuthEnabled = false
+	a.Spec.Redis.TLSEnabled = false
+	assert.NoError(t, r.reconcileRedisDeployment(a))
+	deployment = &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis", Namespace: testNamespace}, deployment))
+	assert.Equal(t, []string{"--save", "", "--appendonly", "no"}, deployment.Spec.Template.Spec.Containers[0].Args)
+	assert.Empty(t, deployment.Spec.Template.Spec.Containers[0].VolumeMounts)
+	assert.Empty(t, deployment.Spec.Template.Spec.Volumes)
+}
+
+func TestReconcileArgoCD_reconcileServerDeployment_redisAuthAndTLS(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Redis.AuthEnabled = true
+		a.Spec.Redis.TLSEnabled = true
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NoError(t, r.reconcileServerDeployment(a))
+	assert.NoError(t, r.reconcileRepoDeployment(a))
+
+	for _, name := range []string{"argocd-server", "argocd-repo-server"} {
+		deployment := &appsv1.Deployment{}
+		assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: testNamespace}, deployment))
+
+		container := deployment.Spec.Template.Spec.Containers[0]
+		assert.Contains(t, strings.Join(container.Command, " "), "--redis-use-tls --redis-ca-certificate /app/config/redis/tls/ca.crt", name)
+		assert.Contains(t, container.Env, getRedisAuthEnv("REDIS_PASSWORD", a)[0], name)
+		assert.Contains(t, container.VolumeMounts, getRedisTLSVolumeMounts(a)[0], name)
+		assert.Contains(t, deployment.Spec.Template.Spec.Volumes, getRedisTLSVolumes(a)[0], name)
+	}
+}
+
+func TestReconcileArgoCD_reconcileRedisHAProxyDeployment_authAndTLS(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.HA.Enabled = true
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NoError(t, r.reconcileRedisHAProxyDeployment(a))
+
+	a.Spec.Redis.AuthEnabled = true
+	a.Spec.Redis.TLSEnabled = true
+	assert.NoError(t, r.reconcileRedisHAProxyDeployment(a))
+
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis-ha-haproxy", Namespace: testNamespace}, deployment))
+
+	podSpec := deployment.Spec.Template.Spec
+	assert.Equal(t, getRedisAuthEnv("AUTH", a), podSpec.InitContainers[0].Env)
+	assert.Contains(t, podSpec.InitContainers[0].VolumeMounts, getRedisTLSVolumeMounts(a)[0])
+	assert.Contains(t, podSpec.Containers[0].VolumeMounts, getRedisTLSVolumeMounts(a)[0])
+	assert.Contains(t, podSpec.Volumes, getRedisTLSVolumes(a)[0])
+}
+
+func TestReconcileArgoCD_reconcileRedisDeployment_externalRedis(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	r := makeTestReconciler(t, a)
+
+	assert.NoError(t, r.reconcileRedisDeployment(a))
+
+	// Switching to an external Redis removes the Redis Deployment.
+	a.Spec.Redis.External = &argoprojv1alpha1.ArgoCDRedisExternalSpec{Address: "redis.cache.example.com:6379"}
+	assert.NoError(t, r.reconcileRedisDeployment(a))
+	assert.NoError(t, r.reconcileRedisHAProxyDeployment(a))
+
+	for _, name := range []string{"argocd-redis", "argocd-redis-ha-haproxy"} {
+		err := r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: testNamespace}, &appsv1.Deployment{})
+		assert.True(t, apierrors.IsNotFound(err), name)
+	}
+
+	// HA does not deploy Redis either when an external Redis is used.
+	a.Spec.HA.Enabled = true
+	assert.NoError(t, r.reconcileRedisHAProxyDeployment(a))
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis-ha-haproxy", Namespace: testNamespace}, &appsv1.Deployment{})
+	assert.True(t, apierrors.IsNotFound(err))
+
+	// Nor are the Redis Services created.
+	assert.NoError(t, r.reconcileServices(a))
+	for _, name := range []string{"argocd-redis", "argocd-redis-ha", "argocd-redis-ha-haproxy"} {
+		err := r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: testNamespace}, &corev1.Service{})
+		assert.True(t, apierrors.IsNotFound(err), name)
+	}
+}
+
+func TestReconcileArgoCD_reconcileServerDeployment_externalRedis(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	password := &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "redis-credentials"}, Key: "password"}
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Redis.External = &argoprojv1alpha1.ArgoCDRedisExternalSpec{
+			Address:            "redis-sentinel.cache:26379",
+			SentinelMasterName: "argocd",
+			PasswordSecretRef:  password,
+			TLSEnabled:         true,
+			CASecretRef:        &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "redis-ca"}, Key: "root.pem"},
+		}
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NoError(t, r.reconcileServerDeployment(a))
+	assert.NoError(t, r.reconcileRepoDeployment(a))
+
+	for _, name := range []string{"argocd-server", "argocd-repo-server"} {
+		deployment := &appsv1.Deployment{}
+		assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: testNamespace}, deployment))
+
+		container := deployment.Spec.Template.Spec.Containers[0]
+		command := strings.Join(container.Command, " ")
+		assert.Contains(t, command, "--sentinel redis-sentinel.cache:26379 --sentinelmaster argocd --redis-use-tls --redis-ca-certificate /app/config/redis/tls/ca.crt", name)
+		assert.NotContains(t, container.Command, "--redis", name)
+		assert.Contains(t, container.Env, corev1.EnvVar{Name: "REDIS_PASSWORD", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: password}}, name)
+		assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{Name: "argocd-redis-external-ca", MountPath: "/app/config/redis/tls", ReadOnly: true}, name)
+		assert.Contains(t, deployment.Spec.Template.Spec.Volumes, corev1.Volume{
+			Name: "argocd-redis-external-ca",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: "redis-ca",
+					Items:      []corev1.KeyToPath{{Key: "root.pem", Path: "ca.crt"}},
+				},
+			},
+		}, name)
+	}
+}
+
+func TestReconcileArgoCD_reconcileRedisHAProxyDeployment_replicas(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.HA.Enabled = true
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NoError(t, r.reconcileRedisHAProxyDeployment(a))
+	deployment := &appsv1.Deployment{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis-ha-haproxy", Namespace: testNamespace}, deployment))
+	assert.Nil(t, deployment.Spec.Replicas)
+
+	// Changes to the replicas are applied to the existing Deployment.
+	a.Spec.HA.RedisProxyReplicas = int32Ptr(3)
+	assert.NoError(t, r.reconcileRedisHAProxyDeployment(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis-ha-haproxy", Namespace: testNamespace}, deployment))
+	assert.Equal(t, int32(3), *deployment.Spec.Replicas)
+}
@@ -0,0 +1,322 @@
+// Copyright 2021 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	argoprojv1a1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// httpRouteGVK is the GroupVersionKind of the Gateway API HTTPRoute resource.
+var httpRouteGVK = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "HTTPRoute"}
+
+// grpcRouteGVK is the GroupVersionKind of the Gateway API GRPCRoute resource.
+var grpcRouteGVK = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "GRPCRoute"}
+
+var gatewayAPIFound = false
+
+var grpcRouteAPIFound = false
+
+// IsGatewayAPIAvailable returns true if the Gateway API is present.
+func IsGatewayAPIAvailable() bool {
+	return gatewayAPIFound
+}
+
+// IsGRPCRouteAPIAvailable returns true if the Gateway API GRPCRoute resource is present.
+func IsGRPCRouteAPIAvailable() bool {
+	return grpcRouteAPIFound
+}
+
+// verifyGatewayAPI will verify that the Gateway API is present.
+// GRPCRoutes are verified separately, as they are only part of the v1 API since Gateway API v1.1.
+func verifyGatewayAPI() error {
+	found, err := argoutil.VerifyAPI(httpRouteGVK.Group, httpRouteGVK.Version)
+	if err != nil {
+		return err
+	}
+	gatewayAPIFound = found
+	if !found {
+		return nil
+	}
+
+	found, err = argoutil.VerifyAPIResource(grpcRouteGVK.Group, grpcRouteGVK.Version, grpcRouteGVK.Kind)
+	if err != nil {
+		return err
+	}
+	grpcRouteAPIFound = found
+	return nil
+}
+
+// gatewayRouteTarget describes a Gateway API route of an Argo CD component.
+type gatewayRouteTarget struct {
+	// suffix is the name suffix of the route.
+	suffix string
+	// gvk is the kind of the route, either HTTPRoute or GRPCRoute.
+	gvk schema.GroupVersionKind
+	// gateway is the desired state of the route.
+	gateway argoprojv1a1.ArgoCDGatewaySpec
+	// host is the hostname of the route when no hostnames are given.
+	host string
+	// service is the name of the Service the route forwards to.
+	service string
+	// port is the port of the Service the route forwards to.
+	port int64
+	// deployed reports whether the component is managed for the given ArgoCD, and can be reached by the routes.
+	deployed bool
+}
+
+// getGatewayRouteTargets returns the Gateway API routes of the Argo CD components.
+func getGatewayRouteTargets(cr *argoprojv1a1.ArgoCD) []gatewayRouteTarget {
+	return []gatewayRouteTarget{
+		{
+			suffix:   "server",
+			gvk:      httpRouteGVK,
+			gateway:  cr.Spec.Server.Gateway,
+			host:     getArgoServerHost(cr),
+			service:  nameWithSuffix("server", cr),
+			port:     80,
+			deployed: cr.Spec.Server.Insecure, // The routes forward plain HTTP, which a TLS server redirects to HTTPS.
+		},
+		{
+			suffix:   "grpc",
+			gvk:      grpcRouteGVK,
+			gateway:  cr.Spec.Server.GRPC.Gateway,
+			host:     getArgoServerGRPCHost(cr),
+			service:  nameWithSuffix("server", cr),
+			port:     80,
+			deployed: cr.Spec.Server.Insecure, // The routes forward plain HTTP, which a TLS server redirects to HTTPS.
+		},
+		{
+			suffix:   "grafana",
+			gvk:      httpRouteGVK,
+			gateway:  cr.Spec.Grafana.Gateway,
+			host:     getGrafanaHost(cr),
+			service:  nameWithSuffix("grafana", cr),
+			port:     80,
+			deployed: cr.Spec.Grafana.Enabled,
+		},
+		{
+			suffix:   "prometheus",
+			gvk:      httpRouteGVK,
+			gateway:  cr.Spec.Prometheus.Gateway,
+			host:     getPrometheusHost(cr),
+			service:  "prometheus-operated",
+			port:     common.ArgoCDDefaultPrometheusPort,
+			deployed: cr.Spec.Prometheus.Enabled,
+		},
+	}
+}
+
+// newGatewayRouteWithSuffix returns a new Gateway API route of the given kind for the given ArgoCD using the given suffix.
+func newGatewayRouteWithSuffix(suffix string, gvk schema.GroupVersionKind, cr *argoprojv1a1.ArgoCD) *unstructured.Unstructured {
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(gvk)
+	route.SetName(nameWithSuffix(suffix, cr))
+	route.SetNamespace(cr.Namespace)
+
+	labels := argoutil.LabelsForCluster(cr)
+	labels[common.ArgoCDKeyName] = route.GetName()
+	route.SetLabels(labels)
+	return route
+}
+
+// getGatewayParentRefs returns the parent references of a Gateway API route attached to the given listener.
+func getGatewayParentRefs(gateway argoprojv1a1.ArgoCDGatewaySpec, sectionName string) []interface{} {
+	parentRef := map[string]interface{}{
+		"group": httpRouteGVK.Group,
+		"kind":  "Gateway",
+		"name":  gateway.ParentRef.Name,
+	}
+	if gateway.ParentRef.Namespace != "" {
+		parentRef["namespace"] = gateway.ParentRef.Namespace
+	}
+	if sectionName != "" {
+		parentRef["sectionName"] = sectionName
+	}
+	return []interface{}{parentRef}
+}
+
+// getGatewayHostnames returns the hostnames of the Gateway API routes of the given target.
+func getGatewayHostnames(target gatewayRouteTarget) []interface{} {
+	if len(target.gateway.Hostnames) == 0 {
+		return []interface{}{target.host}
+	}
+
+	hostnames := []interface{}{}
+	for _, hostname := range target.gateway.Hostnames {
+		hostnames = append(hostnames, hostname)
+	}
+	return hostnames
+}
+
+// getGatewayPathMatches returns the HTTPRoute matches of the path prefix of the given target.
+func getGatewayPathMatches(target gatewayRouteTarget) []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			"path": map[string]interface{}{
+				"type":  "PathPrefix",
+				"value": getPathOrDefault(target.gateway.Path),
+			},
+		},
+	}
+}
+
+// getGatewayRouteSpec returns the Gateway API route spec for the given target.
+func getGatewayRouteSpec(target gatewayRouteTarget) map[string]interface{} {
+	sectionName := target.gateway.ParentRef.SectionName
+	if target.gateway.TLS != nil {
+		// TLS is terminated by the HTTPS listener of the Gateway.
+		sectionName = target.gateway.TLS.SectionName
+	}
+
+	rule := map[string]interface{}{
+		"backendRefs": []interface{}{
+			map[string]interface{}{
+				"group":  "",
+				"kind":   "Service",
+				"name":   target.service,
+				"port":   target.port,
+				"weight": int64(1),
+			},
+		},
+	}
+	if target.gvk == httpRouteGVK {
+		rule["matches"] = getGatewayPathMatches(target)
+	}
+
+	return map[string]interface{}{
+		"parentRefs": getGatewayParentRefs(target.gateway, sectionName),
+		"hostnames":  getGatewayHostnames(target),
+		"rules":      []interface{}{rule},
+	}
+}
+
+// getGatewayRedirectRouteSpec returns the spec of the HTTPRoute redirecting plain HTTP requests of the given target to HTTPS.
+func getGatewayRedirectRouteSpec(target gatewayRouteTarget) map[string]interface{} {
+	return map[string]interface{}{
+		"parentRefs": getGatewayParentRefs(target.gateway, target.gateway.ParentRef.SectionName),
+		"hostnames":  getGatewayHostnames(target),
+		"rules": []interface{}{
+			map[string]interface{}{
+				"matches": getGatewayPathMatches(target),
+				"filters": []interface{}{
+					map[string]interface{}{
+						"type": "RequestRedirect",
+						"requestRedirect": map[string]interface{}{
+							"scheme":     "https",
+							"statusCode": int64(301),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// reconcileGatewayRoute will ensure that the Gateway API route with the given suffix is present with the given spec,
+// or absent when the spec is nil.
+func (r *ReconcileArgoCD) reconcileGatewayRoute(suffix string, gvk schema.GroupVersionKind, gateway argoprojv1a1.ArgoCDGatewaySpec, spec map[string]interface{}, cr *argoprojv1a1.ArgoCD) error {
+	route := newGatewayRouteWithSuffix(suffix, gvk, cr)
+
+	// Allow override of the Labels for the route.
+	labels := route.GetLabels()
+	for key, val := range gateway.Labels {
+		labels[key] = val
+	}
+	route.SetLabels(labels)
+
+	// Allow override of the Annotations for the route.
+	if len(gateway.Annotations) > 0 {
+		route.SetAnnotations(gateway.Annotations)
+	}
+
+	existing := newGatewayRouteWithSuffix(suffix, gvk, cr)
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: existing.GetName(), Namespace: cr.Namespace}, existing)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	if err == nil {
+		if spec == nil {
+			return r.Client.Delete(context.TODO(), existing) // Route found but disabled, delete it.
+		}
+
+		if !reflect.DeepEqual(existing.Object["spec"], spec) ||
+			!reflect.DeepEqual(existing.GetLabels(), route.GetLabels()) ||
+			!reflect.DeepEqual(existing.GetAnnotations(), route.GetAnnotations()) {
+			existing.Object["spec"] = spec
+			existing.SetLabels(route.GetLabels())
+			existing.SetAnnotations(route.GetAnnotations())
+			return r.Client.Update(context.TODO(), existing)
+		}
+		return nil // Route found with nothing to do, move along...
+	}
+
+	if spec == nil {
+		return nil // Route not enabled, move along...
+	}
+
+	route.Object["spec"] = spec
+	if err := controllerutil.SetControllerReference(cr, route, r.Scheme); err != nil {
+		return err
+	}
+	return r.Client.Create(context.TODO(), route)
+}
+
+// reconcileGatewayRoutes will ensure that the Gateway API routes of the Argo CD components are present or absent.
+func (r *ReconcileArgoCD) reconcileGatewayRoutes(cr *argoprojv1a1.ArgoCD) error {
+	for _, target := range getGatewayRouteTargets(cr) {
+		enabled := target.deployed && target.gateway.Enabled
+
+		if target.gvk == grpcRouteGVK && !IsGRPCRouteAPIAvailable() {
+			if enabled {
+				log.Info(fmt.Sprintf("GRPCRoute API not found, skipping gateway route [%s]", nameWithSuffix(target.suffix, cr)))
+			}
+			continue // GRPCRoutes not supported by the Gateway API, nothing to clean up.
+		}
+
+		var spec map[string]interface{}
+		if enabled {
+			spec = getGatewayRouteSpec(target)
+		}
+		if err := r.reconcileGatewayRoute(target.suffix, target.gvk, target.gateway, spec, cr); err != nil {
+			return err
+		}
+
+		if target.gvk != httpRouteGVK {
+			continue
+		}
+
+		var redirectSpec map[string]interface{}
+		if enabled && target.gateway.TLS != nil && target.gateway.TLS.InsecureRedirect {
+			redirectSpec = getGatewayRedirectRouteSpec(target)
+		}
+		if err := r.reconcileGatewayRoute(target.suffix+"-redirect", httpRouteGVK, target.gateway, redirectSpec, cr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"gotest.tools/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoprojv1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+)
+
+func getTestGatewayRoute(t *testing.T, r *ReconcileArgoCD, gvk schema.GroupVersionKind, name string) (*unstructured.Unstructured, error) {
+	t.Helper()
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(gvk)
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: testNamespace}, route)
+	return route, err
+}
+
+func TestReconcileArgoCD_reconcileGatewayRoutes(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	gatewayAPIFound = true
+	grpcRouteAPIFound = true
+	defer func() {
+		gatewayAPIFound = false
+		grpcRouteAPIFound = false
+	}()
+
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Server.Insecure = true
+		a.Spec.Server.Gateway = argoprojv1alpha1.ArgoCDGatewaySpec{
+			Enabled:   true,
+			Labels:    map[string]string{"team": "platform"},
+			ParentRef: argoprojv1alpha1.ArgoCDGatewayParentRef{Name: "shared", Namespace: "gateways", SectionName: "http"},
+			TLS:       &argoprojv1alpha1.ArgoCDGatewayTLSSpec{SectionName: "https", InsecureRedirect: true},
+		}
+		a.Spec.Server.GRPC.Gateway = argoprojv1alpha1.ArgoCDGatewaySpec{
+			Enabled:   true,
+			ParentRef: argoprojv1alpha1.ArgoCDGatewayParentRef{Name: "shared", Namespace: "gateways"},
+		}
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NilError(t, r.reconcileGatewayRoutes(a))
+
+	server, err := getTestGatewayRoute(t, r, httpRouteGVK, "argocd-server")
+	assert.NilError(t, err)
+	assert.Equal(t, server.GetOwnerReferences()[0].Name, a.Name)
+	assert.Equal(t, server.GetLabels()["team"], "platform")
+	parentRefs, _, _ := unstructured.NestedSlice(server.Object, "spec", "parentRefs")
+	assert.DeepEqual(t, parentRefs, []interface{}{map[string]interface{}{
+		"group": "gateway.networking.k8s.io", "kind": "Gateway", "name": "shared", "namespace": "gateways", "sectionName": "https",
+	}})
+	hostnames, _, _ := unstructured.NestedStringSlice(server.Object, "spec", "hostnames")
+	assert.DeepEqual(t, hostnames, []string{"argocd"})
+	rules, _, _ := unstructured.NestedSlice(server.Object, "spec", "rules")
+	backendRefs, _, _ := unstructured.NestedSlice(rules[0].(map[string]interface{}), "backendRefs")
+	port, _, _ := unstructured.NestedInt64(backendRefs[0].(map[string]interface{}), "port")
+	assert.Equal(t, port, int64(80))
+
+	// Plain HTTP requests on the listener of the parent reference are redirected to HTTPS.
+	redirect, err := getTestGatewayRoute(t, r, httpRouteGVK, "argocd-server-redirect")
+	assert.NilError(t, err)
+	parentRefs, _, _ = unstructured.NestedSlice(redirect.Object, "spec", "parentRefs")
+	assert.Equal(t, parentRefs[0].(map[string]interface{})["sectionName"], "http")
+
+	grpc, err := getTestGatewayRoute(t, r, grpcRouteGVK, "argocd-grpc")
+	assert.NilError(t, err)
+	hostnames, _, _ = unstructured.NestedStringSlice(grpc.Object, "spec", "hostnames")
+	assert.DeepEqual(t, hostnames, []string{"argocd-grpc"})
+
+	// Changes to the gateway options are applied to the existing routes.
+	a.Spec.Server.Gateway.Hostnames = []string{"argocd.example.com"}
+	a.Spec.Server.Gateway.TLS.InsecureRedirect = false
+	assert.NilError(t, r.reconcileGatewayRoutes(a))
+
+	server, err = getTestGatewayRoute(t, r, httpRouteGVK, "argocd-server")
+	assert.NilError(t, err)
+	hostnames, _, _ = unstructured.NestedStringSlice(server.Object, "spec", "hostnames")
+	assert.DeepEqual(t, hostnames, []string{"argocd.example.com"})
+	_, err = getTestGatewayRoute(t, r, httpRouteGVK, "argocd-server-redirect")
+	assert.Assert(t, apierrors.IsNotFound(err))
+
+	// Disabling the gateway options removes the routes.
+	a.Spec.Server.Gateway.Enabled = false
+	a.Spec.Server.GRPC.Gateway.Enabled = false
+	assert.NilError(t, r.reconcileGatewayRoutes(a))
+
+	_, err = getTestGatewayRoute(t, r, httpRouteGVK, "argocd-server")
+	assert.Assert(t, apierrors.IsNotFound(err))
+	_, err = getTestGatewayRoute(t, r, grpcRouteGVK, "argocd-grpc")
+	assert.Assert(t, apierrors.IsNotFound(err))
+
+	// Routes are not created for a server that requires TLS.
+	a.Spec.Server.Insecure = false
+	a.Spec.Server.Gateway.Enabled = true
+	a.Spec.Server.GRPC.Gateway.Enabled = true
+	assert.NilError(t, r.reconcileGatewayRoutes(a))
+
+	_, err = getTestGatewayRoute(t, r, httpRouteGVK, "argocd-server")
+	assert.Assert(t, apierrors.IsNotFound(err))
+	_, err = getTestGatewayRoute(t, r, grpcRouteGVK, "argocd-grpc")
+	assert.Assert(t, apierrors.IsNotFound(err))
+}
+
+func TestReconcileArgoCD_reconcileGatewayRoutes_components(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	gatewayAPIFound = true
+	defer func() {
+		gatewayAPIFound = false
+	}()
+
+	gateway := argoprojv1alpha1.ArgoCDGatewaySpec{
+		Enabled:   true,
+		ParentRef: argoprojv1alpha1.ArgoCDGatewayParentRef{Name: "shared"},
+		Path:      "/metrics",
+	}
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Server.Insecure = true
+		a.Spec.Server.GRPC.Gateway = gateway
+		a.Spec.Grafana.Gateway = gateway
+		a.Spec.Prometheus.Enabled = true
+		a.Spec.Prometheus.Gateway = gateway
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NilError(t, r.reconcileGatewayRoutes(a))
+
+	// Routes are only created for deployed components and supported kinds.
+	_, err := getTestGatewayRoute(t, r, httpRouteGVK, "argocd-grafana")
+	assert.Assert(t, apierrors.IsNotFound(err))
+	_, err = getTestGatewayRoute(t, r, grpcRouteGVK, "argocd-grpc")
+	assert.Assert(t, apierrors.IsNotFound(err))
+
+	prometheus, err := getTestGatewayRoute(t, r, httpRouteGVK, "argocd-prometheus")
+	assert.NilError(t, err)
+	rules, _, _ := unstructured.NestedSlice(prometheus.Object, "spec", "rules")
+	rule := rules[0].(map[string]interface{})
+	path, _, _ := unstructured.NestedString(rule["matches"].([]interface{})[0].(map[string]interface{}), "path", "value")
+	assert.Equal(t, path, "/metrics")
+	backendRef := rule["backendRefs"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, backendRef["name"], "prometheus-operated")
+	assert.Equal(t, backendRef["port"], int64(9090))
+}
@@ -16,15 +16,42 @@ package argocd
 
 import (
 	"context"
+	"fmt"
 
 	autoscaling "k8s.io/api/autoscaling/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	argoprojv1a1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
 	"github.com/argoproj-labs/argocd-operator/common"
 	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
 )
 
+var autoscalingV2beta2APIFound = false
+
+// IsAutoscalingV2beta2APIAvailable returns true if the autoscaling/v2beta2 API is present.
+func IsAutoscalingV2beta2APIAvailable() bool {
+	return autoscalingV2beta2APIFound
+}
+
+// verifyAutoscalingV2beta2API will verify that the autoscaling/v2beta2 API is present. It was removed in Kubernetes 1.26.
+func verifyAutoscalingV2beta2API() error {
+	found, err := argoutil.VerifyAPI(autoscalingv2beta2.SchemeGroupVersion.Group, autoscalingv2beta2.SchemeGroupVersion.Version)
+	if err != nil {
+		return err
+	}
+	autoscalingV2beta2APIFound = found
+	return nil
+}
+
+// isDeploymentAutoscaled returns whether a HorizontalPodAutoscaler is managed for the Deployment with the given autoscale options.
+func isDeploymentAutoscaled(autoscale argoprojv1a1.ArgoCDAutoscaleSpec) bool {
+	return autoscale.Enabled && IsAutoscalingV2beta2APIAvailable()
+}
+
 func newHorizontalPodAutoscaler(cr *argoprojv1a1.ArgoCD) *autoscaling.HorizontalPodAutoscaler {
 	return &autoscaling.HorizontalPodAutoscaler{
 		ObjectMeta: metav1.ObjectMeta{
@@ -50,6 +77,158 @@ func newHorizontalPodAutoscalerWithSuffix(suffix string, cr *argoprojv1a1.ArgoCD
 	return newHorizontalPodAutoscalerWithName(nameWithSuffix(suffix, cr), cr)
 }
 
+// newHorizontalPodAutoscalerV2WithSuffix returns a new autoscaling/v2beta2 HorizontalPodAutoscaler for the given ArgoCD using the given suffix.
+func newHorizontalPodAutoscalerV2WithSuffix(suffix string, cr *argoprojv1a1.ArgoCD) *autoscalingv2beta2.HorizontalPodAutoscaler {
+	name := nameWithSuffix(suffix, cr)
+
+	lbls := argoutil.LabelsForCluster(cr)
+	lbls[common.ArgoCDKeyName] = name
+
+	return &autoscalingv2beta2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: cr.Namespace,
+			Labels:    lbls,
+		},
+	}
+}
+
+// getHorizontalPodAutoscalerSpec will return the HorizontalPodAutoscaler spec scaling the Deployment with the given suffix.
+func getHorizontalPodAutoscalerSpec(suffix string, autoscale argoprojv1a1.ArgoCDAutoscaleSpec, cr *argoprojv1a1.ArgoCD) autoscalingv2beta2.HorizontalPodAutoscalerSpec {
+	minReplicas := common.ArgoCDDefaultAutoscaleMinReplicas
+	if autoscale.MinReplicas != nil {
+		minReplicas = *autoscale.MinReplicas
+	}
+
+	maxReplicas := common.ArgoCDDefaultAutoscaleMaxReplicas
+	if autoscale.MaxReplicas > 0 {
+		maxReplicas = autoscale.MaxReplicas
+	}
+
+	metrics := autoscale.Metrics
+	if len(metrics) == 0 {
+		targetCPUUtilization := common.ArgoCDDefaultAutoscaleTargetCPUUtilization
+		metrics = []autoscalingv2beta2.MetricSpec{{
+			Type: autoscalingv2beta2.ResourceMetricSourceType,
+			Resource: &autoscalingv2beta2.ResourceMetricSource{
+				Name: corev1.ResourceCPU,
+				Target: autoscalingv2beta2.MetricTarget{
+					Type:               autoscalingv2beta2.UtilizationMetricType,
+					AverageUtilization: &targetCPUUtilization,
+				},
+			},
+		}}
+	}
+
+	return autoscalingv2beta2.HorizontalPodAutoscalerSpec{
+		ScaleTargetRef: autoscalingv2beta2.CrossVersionObjectReference{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+			Name:       nameWithSuffix(suffix, cr),
+		},
+		MinReplicas: &minReplicas,
+		MaxReplicas: maxReplicas,
+		Metrics:     metrics,
+		Behavior:    getHorizontalPodAutoscalerBehavior(autoscale.Behavior),
+	}
+}
+
+// getHorizontalPodAutoscalerBehavior will return the given scaling behavior with the rules that are not set filled in
+// with the defaults of the API server, so that a partial behavior does not differ from the one stored in the cluster.
+func getHorizontalPodAutoscalerBehavior(behavior *autoscalingv2beta2.HorizontalPodAutoscalerBehavior) *autoscalingv2beta2.HorizontalPodAutoscalerBehavior {
+	if behavior == nil {
+		return nil
+	}
+
+	maxPolicy := autoscalingv2beta2.MaxPolicySelect
+	scaleUpStabilizationSeconds := int32(0)
+	scaleUp := &autoscalingv2beta2.HPAScalingRules{
+		StabilizationWindowSeconds: &scaleUpStabilizationSeconds,
+		SelectPolicy:               &maxPolicy,
+		Policies: []autoscalingv2beta2.HPAScalingPolicy{
+			{Type: autoscalingv2beta2.PodsScalingPolicy, Value: 4, PeriodSeconds: 15},
+			{Type: autoscalingv2beta2.PercentScalingPolicy, Value: 100, PeriodSeconds: 15},
+		},
+	}
+	scaleDown := &autoscalingv2beta2.HPAScalingRules{
+		SelectPolicy: &maxPolicy,
+		Policies: []autoscalingv2beta2.HPAScalingPolicy{
+			{Type: autoscalingv2beta2.PercentScalingPolicy, Value: 100, PeriodSeconds: 15},
+		},
+	}
+
+	return &autoscalingv2beta2.HorizontalPodAutoscalerBehavior{
+		ScaleUp:   mergeHPAScalingRules(behavior.ScaleUp, scaleUp),
+		ScaleDown: mergeHPAScalingRules(behavior.ScaleDown, scaleDown),
+	}
+}
+
+// mergeHPAScalingRules will return the default scaling rules with the fields that are set in the given rules.
+func mergeHPAScalingRules(rules *autoscalingv2beta2.HPAScalingRules, defaults *autoscalingv2beta2.HPAScalingRules) *autoscalingv2beta2.HPAScalingRules {
+	if rules == nil {
+		return defaults
+	}
+	if rules.StabilizationWindowSeconds != nil {
+		defaults.StabilizationWindowSeconds = rules.StabilizationWindowSeconds
+	}
+	if rules.SelectPolicy != nil {
+		defaults.SelectPolicy = rules.SelectPolicy
+	}
+	if rules.Policies != nil {
+		defaults.Policies = rules.Policies
+	}
+	return defaults
+}
+
+// reconcileDeploymentHPA will ensure that the HorizontalPodAutoscaler for the Deployment with the given suffix
+// is present when autoscaling is enabled, and absent otherwise.
+func (r *ReconcileArgoCD) reconcileDeploymentHPA(suffix string, autoscale argoprojv1a1.ArgoCDAutoscaleSpec, cr *argoprojv1a1.ArgoCD) error {
+	if !IsAutoscalingV2beta2APIAvailable() {
+		if autoscale.Enabled {
+			log.Info(fmt.Sprintf("autoscaling/v2beta2 API not found, skipping HorizontalPodAutoscaler for %s", nameWithSuffix(suffix, cr)))
+		}
+		return nil // autoscaling/v2beta2 not served, nothing to clean up.
+	}
+
+	hpa := newHorizontalPodAutoscalerV2WithSuffix(suffix, cr)
+	hpa.Spec = getHorizontalPodAutoscalerSpec(suffix, autoscale, cr)
+
+	existing := newHorizontalPodAutoscalerV2WithSuffix(suffix, cr)
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
+		if !autoscale.Enabled {
+			return r.Client.Delete(context.TODO(), existing) // HorizontalPodAutoscaler found but disabled, delete it.
+		}
+		if !equality.Semantic.DeepEqual(existing.Spec, hpa.Spec) {
+			existing.Spec = hpa.Spec
+			return r.Client.Update(context.TODO(), existing)
+		}
+		return nil // HorizontalPodAutoscaler found with nothing to do, move along...
+	}
+
+	if !autoscale.Enabled {
+		return nil // AutoScale not enabled, move along...
+	}
+
+	if err := controllerutil.SetControllerReference(cr, hpa, r.Scheme); err != nil {
+		return err
+	}
+	return r.Client.Create(context.TODO(), hpa)
+}
+
+// reconcileRepoServerHPA will ensure that the HorizontalPodAutoscaler is present for the Repo Server component.
+func (r *ReconcileArgoCD) reconcileRepoServerHPA(cr *argoprojv1a1.ArgoCD) error {
+	return r.reconcileDeploymentHPA("repo-server", cr.Spec.Repo.Autoscale, cr)
+}
+
+// reconcileApplicationSetHPA will ensure that the HorizontalPodAutoscaler is present for the ApplicationSet controller.
+func (r *ReconcileArgoCD) reconcileApplicationSetHPA(cr *argoprojv1a1.ArgoCD) error {
+	autoscale := argoprojv1a1.ArgoCDAutoscaleSpec{}
+	if cr.Spec.ApplicationSet != nil {
+		autoscale = cr.Spec.ApplicationSet.Autoscale
+	}
+	return r.reconcileDeploymentHPA("applicationset-controller", autoscale, cr)
+}
+
 // reconcileServerHPA will ensure that the HorizontalPodAutoscaler is present for the Argo CD Server component.
 func (r *ReconcileArgoCD) reconcileServerHPA(cr *argoprojv1a1.ArgoCD) error {
 	hpa := newHorizontalPodAutoscalerWithSuffix("server", cr)
@@ -90,5 +269,13 @@ func (r *ReconcileArgoCD) reconcileAutoscalers(cr *argoprojv1a1.ArgoCD) error {
 	if err := r.reconcileServerHPA(cr); err != nil {
 		return err
 	}
+
+	if err := r.reconcileRepoServerHPA(cr); err != nil {
+		return err
+	}
+
+	if err := r.reconcileApplicationSetHPA(cr); err != nil {
+		return err
+	}
 	return nil
 }
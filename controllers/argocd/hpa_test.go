@@ -0,0 +1,174 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"gotest.tools/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoprojv1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+)
+
+func TestReconcileArgoCD_reconcileRepoServerHPA(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	autoscalingV2beta2APIFound = true
+	defer func() {
+		autoscalingV2beta2APIFound = false
+	}()
+
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Repo.Autoscale.Enabled = true
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NilError(t, r.reconcileAutoscalers(a))
+
+	hpa := &autoscalingv2beta2.HorizontalPodAutoscaler{}
+	assert.NilError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, hpa))
+	assert.DeepEqual(t, hpa.Spec.ScaleTargetRef, autoscalingv2beta2.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: "argocd-repo-server"})
+	assert.Equal(t, *hpa.Spec.MinReplicas, int32(1))
+	assert.Equal(t, hpa.Spec.MaxReplicas, int32(3))
+	assert.Equal(t, hpa.Spec.Metrics[0].Resource.Name, corev1.ResourceCPU)
+	assert.Equal(t, *hpa.Spec.Metrics[0].Resource.Target.AverageUtilization, int32(50))
+
+	// Changes to the autoscale options are applied to the existing HorizontalPodAutoscaler.
+	averageValue := resource.MustParse("1Gi")
+	a.Spec.Repo.Autoscale.MaxReplicas = 10
+	a.Spec.Repo.Autoscale.Metrics = []autoscalingv2beta2.MetricSpec{{
+		Type: autoscalingv2beta2.ResourceMetricSourceType,
+		Resource: &autoscalingv2beta2.ResourceMetricSource{
+			Name:   corev1.ResourceMemory,
+			Target: autoscalingv2beta2.MetricTarget{Type: autoscalingv2beta2.AverageValueMetricType, AverageValue: &averageValue},
+		},
+	}}
+	assert.NilError(t, r.reconcileAutoscalers(a))
+
+	hpa = &autoscalingv2beta2.HorizontalPodAutoscaler{}
+	assert.NilError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, hpa))
+	assert.Equal(t, hpa.Spec.MaxReplicas, int32(10))
+	assert.DeepEqual(t, hpa.Spec.Metrics, a.Spec.Repo.Autoscale.Metrics)
+
+	// Disabling autoscaling removes the HorizontalPodAutoscaler.
+	a.Spec.Repo.Autoscale.Enabled = false
+	assert.NilError(t, r.reconcileAutoscalers(a))
+
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, hpa)
+	assert.Assert(t, apierrors.IsNotFound(err))
+}
+
+func TestReconcileArgoCD_reconcileApplicationSetHPA(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	autoscalingV2beta2APIFound = true
+	defer func() {
+		autoscalingV2beta2APIFound = false
+	}()
+
+	a := makeTestArgoCD()
+	r := makeTestReconciler(t, a)
+
+	assert.NilError(t, r.reconcileAutoscalers(a))
+
+	hpa := &autoscalingv2beta2.HorizontalPodAutoscaler{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-applicationset-controller", Namespace: testNamespace}, hpa)
+	assert.Assert(t, apierrors.IsNotFound(err))
+
+	a.Spec.ApplicationSet = &argoprojv1alpha1.ArgoCDApplicationSet{
+		Autoscale: argoprojv1alpha1.ArgoCDAutoscaleSpec{Enabled: true, MinReplicas: int32Ptr(2), MaxReplicas: 4},
+	}
+	assert.NilError(t, r.reconcileAutoscalers(a))
+
+	assert.NilError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-applicationset-controller", Namespace: testNamespace}, hpa))
+	assert.Equal(t, hpa.Spec.ScaleTargetRef.Name, "argocd-applicationset-controller")
+	assert.Equal(t, *hpa.Spec.MinReplicas, int32(2))
+	assert.Equal(t, hpa.Spec.MaxReplicas, int32(4))
+}
+
+func TestReconcileArgoCD_reconcileRepoServerHPA_partialBehavior(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	autoscalingV2beta2APIFound = true
+	defer func() {
+		autoscalingV2beta2APIFound = false
+	}()
+
+	stabilizationWindowSeconds := int32(600)
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Repo.Autoscale.Enabled = true
+		a.Spec.Repo.Autoscale.Behavior = &autoscalingv2beta2.HorizontalPodAutoscalerBehavior{
+			ScaleDown: &autoscalingv2beta2.HPAScalingRules{StabilizationWindowSeconds: &stabilizationWindowSeconds},
+		}
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NilError(t, r.reconcileAutoscalers(a))
+
+	// The rules that are not set are filled in with the defaults of the API server.
+	hpa := &autoscalingv2beta2.HorizontalPodAutoscaler{}
+	assert.NilError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, hpa))
+	assert.Equal(t, *hpa.Spec.Behavior.ScaleDown.StabilizationWindowSeconds, int32(600))
+	assert.Equal(t, *hpa.Spec.Behavior.ScaleDown.SelectPolicy, autoscalingv2beta2.MaxPolicySelect)
+	assert.Equal(t, len(hpa.Spec.Behavior.ScaleDown.Policies), 1)
+	assert.Equal(t, len(hpa.Spec.Behavior.ScaleUp.Policies), 2)
+
+	// Reconciling again does not update the HorizontalPodAutoscaler.
+	assert.NilError(t, r.reconcileAutoscalers(a))
+	updated := &autoscalingv2beta2.HorizontalPodAutoscaler{}
+	assert.NilError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, updated))
+	assert.Equal(t, updated.ResourceVersion, hpa.ResourceVersion)
+}
+
+func TestReconcileArgoCD_reconcileAutoscalers_withoutAutoscalingV2beta2API(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Repo.Autoscale.Enabled = true
+		a.Spec.Repo.Replicas = int32Ptr(2)
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NilError(t, r.reconcileAutoscalers(a))
+
+	hpa := &autoscalingv2beta2.HorizontalPodAutoscaler{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, hpa)
+	assert.Assert(t, apierrors.IsNotFound(err))
+
+	// The replica count stays managed by the operator without a HorizontalPodAutoscaler.
+	assert.Equal(t, *getArgoRepoReplicas(a), int32(2))
+}
+
+func TestReconcileArgoCD_reconcileRepoDeployment_autoscaledReplicas(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	autoscalingV2beta2APIFound = true
+	defer func() {
+		autoscalingV2beta2APIFound = false
+	}()
+
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Repo.Autoscale.Enabled = true
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NilError(t, r.reconcileRepoDeployment(a))
+
+	// The replicas set by the HorizontalPodAutoscaler are kept on later reconciliations.
+	deployment := &appsv1.Deployment{}
+	assert.NilError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, deployment))
+	deployment.Spec.Replicas = int32Ptr(3)
+	assert.NilError(t, r.Client.Update(context.TODO(), deployment))
+
+	a.Spec.Repo.LogLevel = "debug"
+	assert.NilError(t, r.reconcileRepoDeployment(a))
+
+	deployment = &appsv1.Deployment{}
+	assert.NilError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, deployment))
+	assert.Equal(t, *deployment.Spec.Replicas, int32(3))
+}
+
+func int32Ptr(val int32) *int32 {
+	return &val
+}
@@ -17,6 +17,7 @@ package argocd
 import (
 	"context"
 	"fmt"
+	"reflect"
 
 	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -27,10 +28,13 @@ import (
 	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
 )
 
-// getDefaultIngressAnnotations will return the default Ingress Annotations for the given ArgoCD.
-func getDefaultIngressAnnotations(cr *argoprojv1a1.ArgoCD) map[string]string {
+// getDefaultIngressAnnotations will return the default Ingress Annotations for the given Ingress options.
+// The deprecated ingress class annotation is only added when no IngressClass is given.
+func getDefaultIngressAnnotations(options argoprojv1a1.ArgoCDIngressSpec) map[string]string {
 	annotations := make(map[string]string)
-	annotations[common.ArgoCDKeyIngressClass] = "nginx"
+	if options.IngressClassName == nil {
+		annotations[common.ArgoCDKeyIngressClass] = "nginx"
+	}
 	return annotations
 }
 
@@ -43,6 +47,82 @@ func getPathOrDefault(path string) string {
 	return result
 }
 
+// getIngressHosts will return the given host of the component followed by the additional hosts of the given Ingress options.
+func getIngressHosts(host string, options argoprojv1a1.ArgoCDIngressSpec) []string {
+	return append([]string{host}, options.Hosts...)
+}
+
+// getIngressRules will return the Ingress rules forwarding the path of the given Ingress options on each of the given
+// hosts to the given backend.
+func getIngressRules(hosts []string, options argoprojv1a1.ArgoCDIngressSpec, backend networkingv1.IngressServiceBackend) []networkingv1.IngressRule {
+	pathType := networkingv1.PathTypeImplementationSpecific
+	if options.PathType != nil {
+		pathType = *options.PathType
+	}
+
+	rules := []networkingv1.IngressRule{}
+	for _, host := range hosts {
+		service := backend
+		rules = append(rules, networkingv1.IngressRule{
+			Host: host,
+			IngressRuleValue: networkingv1.IngressRuleValue{
+				HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: []networkingv1.HTTPIngressPath{
+						{
+							Path: getPathOrDefault(options.Path),
+							Backend: networkingv1.IngressBackend{
+								Service: &service,
+							},
+							PathType: &pathType,
+						},
+					},
+				},
+			},
+		})
+	}
+	return rules
+}
+
+// getIngressTLSSecretName will return the name of the TLS Secret generated for the Ingress with the given name.
+func getIngressTLSSecretName(name string) string {
+	return fmt.Sprintf("%s-ingress-tls", name)
+}
+
+// getIngressTLS will return the TLS options for the Ingress with the given name and hosts, falling back to the given
+// default TLS options. TLS options without a secret name use the TLS Secret generated for the Ingress, the hosts
+// that certificate must be valid for are returned as well.
+func getIngressTLS(name string, hosts []string, options argoprojv1a1.ArgoCDIngressSpec, defaultTLS []networkingv1.IngressTLS) ([]networkingv1.IngressTLS, []string) {
+	if len(options.TLS) == 0 {
+		return defaultTLS, nil
+	}
+
+	tls := []networkingv1.IngressTLS{}
+	generatedHosts := []string{}
+	for _, entry := range options.TLS {
+		entry := *entry.DeepCopy()
+		if entry.SecretName == "" {
+			entry.SecretName = getIngressTLSSecretName(name)
+			if len(entry.Hosts) > 0 {
+				generatedHosts = appendUnique(generatedHosts, entry.Hosts...)
+			} else {
+				generatedHosts = appendUnique(generatedHosts, hosts...)
+			}
+		}
+		tls = append(tls, entry)
+	}
+	return tls, generatedHosts
+}
+
+// appendUnique will append the given values missing from the given list.
+func appendUnique(list []string, values ...string) []string {
+	for _, value := range values {
+		if !containsString(list, value) {
+			list = append(list, value)
+		}
+	}
+	return list
+}
+
 // newIngress returns a new Ingress instance for the given ArgoCD.
 func newIngress(cr *argoprojv1a1.ArgoCD) *networkingv1.Ingress {
 	return &networkingv1.Ingress{
@@ -73,6 +153,10 @@ func newIngressWithSuffix(suffix string, cr *argoprojv1a1.ArgoCD) *networkingv1.
 
 // reconcileIngresses will ensure that all ArgoCD Ingress resources are present.
 func (r *ReconcileArgoCD) reconcileIngresses(cr *argoprojv1a1.ArgoCD) error {
+	if err := r.reconcileApplicationSetWebhookIngress(cr); err != nil {
+		return err
+	}
+
 	if err := r.reconcileArgoServerIngress(cr); err != nil {
 		return err
 	}
@@ -91,296 +175,221 @@ func (r *ReconcileArgoCD) reconcileIngresses(cr *argoprojv1a1.ArgoCD) error {
 	return nil
 }
 
-// reconcileArgoServerIngress will ensure that the ArgoCD Server Ingress is present.
-func (r *ReconcileArgoCD) reconcileArgoServerIngress(cr *argoprojv1a1.ArgoCD) error {
-	ingress := newIngressWithSuffix("server", cr)
-	if argoutil.IsObjectFound(r.Client, cr.Namespace, ingress.Name, ingress) {
-		if !cr.Spec.Server.Ingress.Enabled {
+// reconcileIngress will ensure that the given Ingress for the given hosts is present with the given options, or absent
+// when not enabled. The given Ingress carries the default annotations, rules and TLS options of the component.
+func (r *ReconcileArgoCD) reconcileIngress(ingress *networkingv1.Ingress, hosts []string, options argoprojv1a1.ArgoCDIngressSpec, enabled bool, cr *argoprojv1a1.ArgoCD) error {
+	existing := newIngressWithName(ingress.Name, cr)
+	found := argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing)
+	if !enabled {
+		if err := r.deleteIngressTLSSecret(getIngressTLSSecretName(ingress.Name), cr); err != nil {
+			return err
+		}
+		if found {
 			// Ingress exists but enabled flag has been set to false, delete the Ingress
-			return r.Client.Delete(context.TODO(), ingress)
+			return r.Client.Delete(context.TODO(), existing)
 		}
-		return nil // Ingress found and enabled, do nothing
+		return nil // Ingress not enabled, move along...
 	}
 
-	if !cr.Spec.Server.Ingress.Enabled {
-		return nil // Ingress not enabled, move along...
+	// Override default annotations if specified
+	if len(options.Annotations) > 0 {
+		ingress.ObjectMeta.Annotations = options.Annotations
+	}
+
+	// Allow override of the Labels for the Ingress.
+	for key, val := range options.Labels {
+		ingress.ObjectMeta.Labels[key] = val
+	}
+
+	ingress.Spec.IngressClassName = options.IngressClassName
+
+	// Allow override of TLS options if specified, generating a TLS secret when no secret is given.
+	tls, generatedHosts := getIngressTLS(ingress.Name, hosts, options, ingress.Spec.TLS)
+	ingress.Spec.TLS = tls
+	if len(generatedHosts) > 0 {
+		if err := r.reconcileIngressTLSSecret(getIngressTLSSecretName(ingress.Name), generatedHosts, cr); err != nil {
+			return err
+		}
+	} else if err := r.deleteIngressTLSSecret(getIngressTLSSecretName(ingress.Name), cr); err != nil {
+		return err
+	}
+
+	if !found {
+		if err := controllerutil.SetControllerReference(cr, ingress, r.Scheme); err != nil {
+			return err
+		}
+		return r.Client.Create(context.TODO(), ingress)
+	}
+
+	// The IngressClass of an Ingress without one may be defaulted by the cluster, keep it.
+	if ingress.Spec.IngressClassName == nil {
+		ingress.Spec.IngressClassName = existing.Spec.IngressClassName
+	}
+
+	if !reflect.DeepEqual(existing.Spec, ingress.Spec) ||
+		!reflect.DeepEqual(existing.Labels, ingress.Labels) ||
+		!reflect.DeepEqual(existing.Annotations, ingress.Annotations) {
+		existing.Spec = ingress.Spec
+		existing.Labels = ingress.Labels
+		existing.Annotations = ingress.Annotations
+		return r.Client.Update(context.TODO(), existing)
+	}
+	return nil // Ingress found with nothing to do, move along...
+}
+
+// reconcileApplicationSetWebhookIngress will ensure that the ApplicationSet controller webhook server Ingress is present.
+func (r *ReconcileArgoCD) reconcileApplicationSetWebhookIngress(cr *argoprojv1a1.ArgoCD) error {
+	ingress := newIngressWithSuffix("applicationset-controller", cr)
+	options := argoprojv1a1.ArgoCDIngressSpec{}
+	if cr.Spec.ApplicationSet != nil {
+		options = cr.Spec.ApplicationSet.WebhookServer.Ingress
 	}
 
 	// Add annotations
-	atns := getDefaultIngressAnnotations(cr)
+	atns := getDefaultIngressAnnotations(options)
 	atns[common.ArgoCDKeyIngressSSLRedirect] = "true"
 	atns[common.ArgoCDKeyIngressBackendProtocol] = "HTTP"
+	ingress.ObjectMeta.Annotations = atns
 
-	// Override default annotations if specified
-	if len(cr.Spec.Server.Ingress.Annotations) > 0 {
-		atns = cr.Spec.Server.Ingress.Annotations
+	// Add rules
+	hosts := getIngressHosts(getApplicationSetWebhookServerHost(cr), options)
+	ingress.Spec.Rules = getIngressRules(hosts, options, networkingv1.IngressServiceBackend{
+		Name: nameWithSuffix("applicationset-controller", cr),
+		Port: networkingv1.ServiceBackendPort{
+			Name: "webhook",
+		},
+	})
+
+	// Add default TLS options
+	ingress.Spec.TLS = []networkingv1.IngressTLS{
+		{
+			Hosts:      hosts,
+			SecretName: common.ArgoCDSecretName,
+		},
 	}
 
+	return r.reconcileIngress(ingress, hosts, options, cr.Spec.ApplicationSet != nil && options.Enabled, cr)
+}
+
+// reconcileArgoServerIngress will ensure that the ArgoCD Server Ingress is present.
+func (r *ReconcileArgoCD) reconcileArgoServerIngress(cr *argoprojv1a1.ArgoCD) error {
+	ingress := newIngressWithSuffix("server", cr)
+	options := cr.Spec.Server.Ingress
+
+	// Add annotations
+	atns := getDefaultIngressAnnotations(options)
+	atns[common.ArgoCDKeyIngressSSLRedirect] = "true"
+	atns[common.ArgoCDKeyIngressBackendProtocol] = "HTTP"
 	ingress.ObjectMeta.Annotations = atns
 
-	pathType := networkingv1.PathTypeImplementationSpecific
 	// Add rules
-	ingress.Spec.Rules = []networkingv1.IngressRule{
-		{
-			Host: getArgoServerHost(cr),
-			IngressRuleValue: networkingv1.IngressRuleValue{
-				HTTP: &networkingv1.HTTPIngressRuleValue{
-					Paths: []networkingv1.HTTPIngressPath{
-						{
-							Path: getPathOrDefault(cr.Spec.Server.Ingress.Path),
-							Backend: networkingv1.IngressBackend{
-								Service: &networkingv1.IngressServiceBackend{
-									Name: nameWithSuffix("server", cr),
-									Port: networkingv1.ServiceBackendPort{
-										Name: "http",
-									},
-								},
-							},
-							PathType: &pathType,
-						},
-					},
-				},
-			},
+	hosts := getIngressHosts(getArgoServerHost(cr), options)
+	ingress.Spec.Rules = getIngressRules(hosts, options, networkingv1.IngressServiceBackend{
+		Name: nameWithSuffix("server", cr),
+		Port: networkingv1.ServiceBackendPort{
+			Name: "http",
 		},
-	}
+	})
 
 	// Add default TLS options
 	ingress.Spec.TLS = []networkingv1.IngressTLS{
 		{
-			Hosts: []string{
-				getArgoServerHost(cr),
-			},
+			Hosts:      hosts,
 			SecretName: common.ArgoCDSecretName,
 		},
 	}
 
-	// Allow override of TLS options if specified
-	if len(cr.Spec.Server.Ingress.TLS) > 0 {
-		ingress.Spec.TLS = cr.Spec.Server.Ingress.TLS
-	}
-
-	if err := controllerutil.SetControllerReference(cr, ingress, r.Scheme); err != nil {
-		return err
-	}
-	return r.Client.Create(context.TODO(), ingress)
+	return r.reconcileIngress(ingress, hosts, options, options.Enabled, cr)
 }
 
 // reconcileArgoServerGRPCIngress will ensure that the ArgoCD Server GRPC Ingress is present.
 func (r *ReconcileArgoCD) reconcileArgoServerGRPCIngress(cr *argoprojv1a1.ArgoCD) error {
 	ingress := newIngressWithSuffix("grpc", cr)
-	if argoutil.IsObjectFound(r.Client, cr.Namespace, ingress.Name, ingress) {
-		if !cr.Spec.Server.GRPC.Ingress.Enabled {
-			// Ingress exists but enabled flag has been set to false, delete the Ingress
-			return r.Client.Delete(context.TODO(), ingress)
-		}
-		return nil // Ingress found and enabled, do nothing
-	}
-
-	if !cr.Spec.Server.GRPC.Ingress.Enabled {
-		return nil // Ingress not enabled, move along...
-	}
+	options := cr.Spec.Server.GRPC.Ingress
 
 	// Add annotations
-	atns := getDefaultIngressAnnotations(cr)
+	atns := getDefaultIngressAnnotations(options)
 	atns[common.ArgoCDKeyIngressBackendProtocol] = "GRPC"
-
-	// Override default annotations if specified
-	if len(cr.Spec.Server.GRPC.Ingress.Annotations) > 0 {
-		atns = cr.Spec.Server.GRPC.Ingress.Annotations
-	}
-
 	ingress.ObjectMeta.Annotations = atns
 
-	pathType := networkingv1.PathTypeImplementationSpecific
 	// Add rules
-	ingress.Spec.Rules = []networkingv1.IngressRule{
-		{
-			Host: getArgoServerGRPCHost(cr),
-			IngressRuleValue: networkingv1.IngressRuleValue{
-				HTTP: &networkingv1.HTTPIngressRuleValue{
-					Paths: []networkingv1.HTTPIngressPath{
-						{
-							Path: getPathOrDefault(cr.Spec.Server.GRPC.Ingress.Path),
-							Backend: networkingv1.IngressBackend{
-								Service: &networkingv1.IngressServiceBackend{
-									Name: nameWithSuffix("server", cr),
-									Port: networkingv1.ServiceBackendPort{
-										Name: "https",
-									},
-								},
-							},
-							PathType: &pathType,
-						},
-					},
-				},
-			},
+	hosts := getIngressHosts(getArgoServerGRPCHost(cr), options)
+	ingress.Spec.Rules = getIngressRules(hosts, options, networkingv1.IngressServiceBackend{
+		Name: nameWithSuffix("server", cr),
+		Port: networkingv1.ServiceBackendPort{
+			Name: "https",
 		},
-	}
+	})
 
 	// Add TLS options
 	ingress.Spec.TLS = []networkingv1.IngressTLS{
 		{
-			Hosts: []string{
-				getArgoServerGRPCHost(cr),
-			},
+			Hosts:      hosts,
 			SecretName: common.ArgoCDSecretName,
 		},
 	}
 
-	// Allow override of TLS options if specified
-	if len(cr.Spec.Server.GRPC.Ingress.TLS) > 0 {
-		ingress.Spec.TLS = cr.Spec.Server.GRPC.Ingress.TLS
-	}
-
-	if err := controllerutil.SetControllerReference(cr, ingress, r.Scheme); err != nil {
-		return err
-	}
-	return r.Client.Create(context.TODO(), ingress)
+	return r.reconcileIngress(ingress, hosts, options, options.Enabled, cr)
 }
 
 // reconcileGrafanaIngress will ensure that the ArgoCD Server GRPC Ingress is present.
 func (r *ReconcileArgoCD) reconcileGrafanaIngress(cr *argoprojv1a1.ArgoCD) error {
 	ingress := newIngressWithSuffix("grafana", cr)
-	if argoutil.IsObjectFound(r.Client, cr.Namespace, ingress.Name, ingress) {
-		if !cr.Spec.Grafana.Enabled || !cr.Spec.Grafana.Ingress.Enabled {
-			// Ingress exists but enabled flag has been set to false, delete the Ingress
-			return r.Client.Delete(context.TODO(), ingress)
-		}
-		return nil // Ingress found and enabled, do nothing
-	}
-
-	if !cr.Spec.Grafana.Enabled || !cr.Spec.Grafana.Ingress.Enabled {
-		return nil // Grafana itself or Ingress not enabled, move along...
-	}
+	options := cr.Spec.Grafana.Ingress
 
 	// Add annotations
-	atns := getDefaultIngressAnnotations(cr)
+	atns := getDefaultIngressAnnotations(options)
 	atns[common.ArgoCDKeyIngressSSLRedirect] = "true"
 	atns[common.ArgoCDKeyIngressBackendProtocol] = "HTTP"
-
-	// Override default annotations if specified
-	if len(cr.Spec.Grafana.Ingress.Annotations) > 0 {
-		atns = cr.Spec.Grafana.Ingress.Annotations
-	}
-
 	ingress.ObjectMeta.Annotations = atns
 
-	pathType := networkingv1.PathTypeImplementationSpecific
 	// Add rules
-	ingress.Spec.Rules = []networkingv1.IngressRule{
-		{
-			Host: getGrafanaHost(cr),
-			IngressRuleValue: networkingv1.IngressRuleValue{
-				HTTP: &networkingv1.HTTPIngressRuleValue{
-					Paths: []networkingv1.HTTPIngressPath{
-						{
-							Path: getPathOrDefault(cr.Spec.Grafana.Ingress.Path),
-							Backend: networkingv1.IngressBackend{
-								Service: &networkingv1.IngressServiceBackend{
-									Name: nameWithSuffix("grafana", cr),
-									Port: networkingv1.ServiceBackendPort{
-										Name: "http",
-									},
-								},
-							},
-							PathType: &pathType,
-						},
-					},
-				},
-			},
+	hosts := getIngressHosts(getGrafanaHost(cr), options)
+	ingress.Spec.Rules = getIngressRules(hosts, options, networkingv1.IngressServiceBackend{
+		Name: nameWithSuffix("grafana", cr),
+		Port: networkingv1.ServiceBackendPort{
+			Name: "http",
 		},
-	}
+	})
 
 	// Add TLS options
 	ingress.Spec.TLS = []networkingv1.IngressTLS{
 		{
-			Hosts: []string{
-				cr.Name,
-				getGrafanaHost(cr),
-			},
+			Hosts:      append([]string{cr.Name}, hosts...),
 			SecretName: common.ArgoCDSecretName,
 		},
 	}
 
-	// Allow override of TLS options if specified
-	if len(cr.Spec.Grafana.Ingress.TLS) > 0 {
-		ingress.Spec.TLS = cr.Spec.Grafana.Ingress.TLS
-	}
-
-	if err := controllerutil.SetControllerReference(cr, ingress, r.Scheme); err != nil {
-		return err
-	}
-	return r.Client.Create(context.TODO(), ingress)
+	return r.reconcileIngress(ingress, hosts, options, cr.Spec.Grafana.Enabled && options.Enabled, cr)
 }
 
 // reconcilePrometheusIngress will ensure that the Prometheus Ingress is present.
 func (r *ReconcileArgoCD) reconcilePrometheusIngress(cr *argoprojv1a1.ArgoCD) error {
 	ingress := newIngressWithSuffix("prometheus", cr)
-	if argoutil.IsObjectFound(r.Client, cr.Namespace, ingress.Name, ingress) {
-		if !cr.Spec.Prometheus.Enabled || !cr.Spec.Prometheus.Ingress.Enabled {
-			// Ingress exists but enabled flag has been set to false, delete the Ingress
-			return r.Client.Delete(context.TODO(), ingress)
-		}
-		return nil // Ingress found and enabled, do nothing
-	}
-
-	if !cr.Spec.Prometheus.Enabled || !cr.Spec.Prometheus.Ingress.Enabled {
-		return nil // Prometheus itself or Ingress not enabled, move along...
-	}
+	options := cr.Spec.Prometheus.Ingress
 
 	// Add annotations
-	atns := getDefaultIngressAnnotations(cr)
+	atns := getDefaultIngressAnnotations(options)
 	atns[common.ArgoCDKeyIngressSSLRedirect] = "true"
 	atns[common.ArgoCDKeyIngressBackendProtocol] = "HTTP"
-
-	// Override default annotations if specified
-	if len(cr.Spec.Prometheus.Ingress.Annotations) > 0 {
-		atns = cr.Spec.Prometheus.Ingress.Annotations
-	}
-
 	ingress.ObjectMeta.Annotations = atns
 
-	pathType := networkingv1.PathTypeImplementationSpecific
 	// Add rules
-	ingress.Spec.Rules = []networkingv1.IngressRule{
-		{
-			Host: getPrometheusHost(cr),
-			IngressRuleValue: networkingv1.IngressRuleValue{
-				HTTP: &networkingv1.HTTPIngressRuleValue{
-					Paths: []networkingv1.HTTPIngressPath{
-						{
-							Path: getPathOrDefault(cr.Spec.Prometheus.Ingress.Path),
-							Backend: networkingv1.IngressBackend{
-								Service: &networkingv1.IngressServiceBackend{
-									Name: "prometheus-operated",
-									Port: networkingv1.ServiceBackendPort{
-										Name: "web",
-									},
-								},
-							},
-							PathType: &pathType,
-						},
-					},
-				},
-			},
+	hosts := getIngressHosts(getPrometheusHost(cr), options)
+	ingress.Spec.Rules = getIngressRules(hosts, options, networkingv1.IngressServiceBackend{
+		Name: "prometheus-operated",
+		Port: networkingv1.ServiceBackendPort{
+			Name: "web",
 		},
-	}
+	})
 
 	// Add TLS options
 	ingress.Spec.TLS = []networkingv1.IngressTLS{
 		{
-			Hosts:      []string{cr.Name},
+			Hosts:      append([]string{cr.Name}, options.Hosts...),
 			SecretName: common.ArgoCDSecretName,
 		},
 	}
 
-	// Allow override of TLS options if specified
-	if len(cr.Spec.Prometheus.Ingress.TLS) > 0 {
-		ingress.Spec.TLS = cr.Spec.Prometheus.Ingress.TLS
-	}
-
-	if err := controllerutil.SetControllerReference(cr, ingress, r.Scheme); err != nil {
-		return err
-	}
-	return r.Client.Create(context.TODO(), ingress)
+	return r.reconcileIngress(ingress, hosts, options, cr.Spec.Prometheus.Enabled && options.Enabled, cr)
 }
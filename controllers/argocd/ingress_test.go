@@ -0,0 +1,150 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoprojv1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+func getTestIngress(t *testing.T, r *ReconcileArgoCD, name string) (*networkingv1.Ingress, error) {
+	t.Helper()
+	ingress := &networkingv1.Ingress{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: testNamespace}, ingress)
+	return ingress, err
+}
+
+func getTestIngressHosts(ingress *networkingv1.Ingress) []string {
+	hosts := []string{}
+	for _, rule := range ingress.Spec.Rules {
+		hosts = append(hosts, rule.Host)
+	}
+	return hosts
+}
+
+func TestReconcileArgoCD_reconcileArgoServerIngress(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Server.Ingress.Enabled = true
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NilError(t, r.reconcileArgoServerIngress(a))
+
+	ingress, err := getTestIngress(t, r, "argocd-server")
+	assert.NilError(t, err)
+	assert.Equal(t, ingress.Annotations[common.ArgoCDKeyIngressClass], "nginx")
+	assert.Assert(t, ingress.Spec.IngressClassName == nil)
+	assert.DeepEqual(t, getTestIngressHosts(ingress), []string{"argocd"})
+	assert.Equal(t, *ingress.Spec.Rules[0].HTTP.Paths[0].PathType, networkingv1.PathTypeImplementationSpecific)
+	assert.DeepEqual(t, ingress.Spec.TLS, []networkingv1.IngressTLS{{Hosts: []string{"argocd"}, SecretName: common.ArgoCDSecretName}})
+
+	// Changes to the Ingress options are applied to the existing Ingress.
+	className := "internal"
+	pathType := networkingv1.PathTypePrefix
+	a.Spec.Server.Ingress.IngressClassName = &className
+	a.Spec.Server.Ingress.Hosts = []string{"argocd.example.com"}
+	a.Spec.Server.Ingress.PathType = &pathType
+	a.Spec.Server.Ingress.Labels = map[string]string{"team": "platform"}
+	assert.NilError(t, r.reconcileArgoServerIngress(a))
+
+	ingress, err = getTestIngress(t, r, "argocd-server")
+	assert.NilError(t, err)
+	_, found := ingress.Annotations[common.ArgoCDKeyIngressClass]
+	assert.Assert(t, !found)
+	assert.Equal(t, *ingress.Spec.IngressClassName, "internal")
+	assert.Equal(t, ingress.Labels["team"], "platform")
+	assert.DeepEqual(t, getTestIngressHosts(ingress), []string{"argocd", "argocd.example.com"})
+	assert.Equal(t, *ingress.Spec.Rules[1].HTTP.Paths[0].PathType, networkingv1.PathTypePrefix)
+	assert.Equal(t, ingress.Spec.Rules[1].HTTP.Paths[0].Backend.Service.Name, "argocd-server")
+	assert.DeepEqual(t, ingress.Spec.TLS[0].Hosts, []string{"argocd", "argocd.example.com"})
+
+	// Disabling the Ingress removes it.
+	a.Spec.Server.Ingress.Enabled = false
+	assert.NilError(t, r.reconcileArgoServerIngress(a))
+
+	_, err = getTestIngress(t, r, "argocd-server")
+	assert.Assert(t, apierrors.IsNotFound(err))
+}
+
+func TestReconcileArgoCD_reconcileIngresses_generatedTLSSecret(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Grafana.Enabled = true
+		a.Spec.Grafana.Ingress = argoprojv1alpha1.ArgoCDIngressSpec{
+			Enabled: true,
+			Hosts:   []string{"grafana.example.com"},
+			TLS: []networkingv1.IngressTLS{
+				{},
+				{Hosts: []string{"metrics.example.com"}, SecretName: "metrics-tls"},
+			},
+		}
+	})
+	r := makeTestReconciler(t, a)
+	assert.NilError(t, r.reconcileClusterCASecret(a))
+
+	assert.NilError(t, r.reconcileIngresses(a))
+
+	// TLS options without a secret name use a TLS secret generated for the hosts of the Ingress.
+	ingress, err := getTestIngress(t, r, "argocd-grafana")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, ingress.Spec.TLS, []networkingv1.IngressTLS{
+		{SecretName: "argocd-grafana-ingress-tls"},
+		{Hosts: []string{"metrics.example.com"}, SecretName: "metrics-tls"},
+	})
+
+	ca := getTestSecretCertificate(t, r, "argocd-ca")
+	cert := getTestSecretCertificate(t, r, "argocd-grafana-ingress-tls")
+	assert.NilError(t, cert.CheckSignatureFrom(ca))
+	assert.DeepEqual(t, cert.DNSNames, []string{"argocd-grafana", "grafana.example.com"})
+
+	// The certificate is re-issued when the hosts change.
+	a.Spec.Grafana.Ingress.Hosts = []string{"dashboards.example.com"}
+	assert.NilError(t, r.reconcileIngresses(a))
+
+	cert = getTestSecretCertificate(t, r, "argocd-grafana-ingress-tls")
+	assert.DeepEqual(t, cert.DNSNames, []string{"argocd-grafana", "dashboards.example.com"})
+
+	// The generated certificate is deleted once every TLS option names a secret.
+	a.Spec.Grafana.Ingress.TLS[0].SecretName = "grafana-tls"
+	assert.NilError(t, r.reconcileIngresses(a))
+
+	secret := &corev1.Secret{}
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-grafana-ingress-tls", Namespace: testNamespace}, secret)
+	assert.Assert(t, apierrors.IsNotFound(err))
+}
+
+func TestReconcileArgoCD_reconcileIngresses_disabledDeletesGeneratedTLSSecret(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Server.Ingress = argoprojv1alpha1.ArgoCDIngressSpec{
+			Enabled: true,
+			TLS:     []networkingv1.IngressTLS{{}},
+		}
+	})
+	userSecret := argoutil.NewSecretWithName(a, "argocd-grpc-ingress-tls")
+	r := makeTestReconciler(t, a, userSecret)
+	assert.NilError(t, r.reconcileClusterCASecret(a))
+
+	assert.NilError(t, r.reconcileIngresses(a))
+	getTestSecretCertificate(t, r, "argocd-server-ingress-tls")
+
+	a.Spec.Server.Ingress.Enabled = false
+	assert.NilError(t, r.reconcileIngresses(a))
+
+	secret := &corev1.Secret{}
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server-ingress-tls", Namespace: testNamespace}, secret)
+	assert.Assert(t, apierrors.IsNotFound(err))
+
+	// Secrets not created by the operator are left alone.
+	assert.NilError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-grpc-ingress-tls", Namespace: testNamespace}, secret))
+}
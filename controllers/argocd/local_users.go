@@ -0,0 +1,485 @@
+// Copyright 2021 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	argopass "github.com/argoproj/argo-cd/util/password"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	argoprojv1a1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+const (
+	// localUserCapabilityAPIKey is the Argo CD account capability to generate API tokens.
+	localUserCapabilityAPIKey = "apiKey"
+
+	// localUserCapabilityLogin is the Argo CD account capability to login with a password.
+	localUserCapabilityLogin = "login"
+
+	// localUserTokenIssuer is the issuer of Argo CD API tokens.
+	localUserTokenIssuer = "argocd"
+)
+
+// localUserToken is the argocd-secret representation of an API token issued for a local user.
+type localUserToken struct {
+	ID        string `json:"id"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp,omitempty"`
+}
+
+// localUserTokenClaims are the JWT claims of an API token issued for a local user, as expected by the Argo CD server.
+type localUserTokenClaims struct {
+	ID        string `json:"jti"`
+	Issuer    string `json:"iss"`
+	Subject   string `json:"sub"`
+	IssuedAt  int64  `json:"iat"`
+	NotBefore int64  `json:"nbf"`
+	ExpiresAt int64  `json:"exp,omitempty"`
+}
+
+// renewAt returns the time after which the token should be replaced, or the zero time if the token does not expire.
+func (c localUserTokenClaims) renewAt() time.Time {
+	if c.ExpiresAt == 0 {
+		return time.Time{}
+	}
+	return time.Unix(c.IssuedAt+(c.ExpiresAt-c.IssuedAt)*4/5, 0)
+}
+
+// localUserTokenHeader is the encoded JWT header of the API tokens issued for local users.
+var localUserTokenHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// isLocalUserEnabled returns true if the given local user can authenticate.
+func isLocalUserEnabled(user argoprojv1a1.LocalUserSpec) bool {
+	return user.Enabled == nil || *user.Enabled
+}
+
+// isLocalUserAPIKeyEnabled returns true if the given local user has the apiKey capability.
+func isLocalUserAPIKeyEnabled(user argoprojv1a1.LocalUserSpec) bool {
+	return user.APIKey == nil || *user.APIKey
+}
+
+// isLocalUserTokenAutoRenewed returns true if the API token of the given local user is replaced before it expires.
+func isLocalUserTokenAutoRenewed(user argoprojv1a1.LocalUserSpec) bool {
+	return user.AutoRenewToken == nil || *user.AutoRenewToken
+}
+
+// getLocalUserTokenLifetime returns the lifetime of the API token for the given local user, zero if it does not expire.
+func getLocalUserTokenLifetime(user argoprojv1a1.LocalUserSpec) time.Duration {
+	if user.TokenLifetime == nil {
+		return 0
+	}
+	return user.TokenLifetime.Duration
+}
+
+// getLocalUserSecretName returns the name of the Secret holding the generated credentials of the given local user.
+func getLocalUserSecretName(cr *argoprojv1a1.ArgoCD, name string) string {
+	return fmt.Sprintf("%s-local-user-%s", cr.Name, name)
+}
+
+// getLocalUserAccountKey returns the argocd-cm and argocd-secret key for the given local user and property.
+func getLocalUserAccountKey(name string, property string) string {
+	key := fmt.Sprintf("%s.%s", common.ArgoCDKeyAccounts, name)
+	if property != "" {
+		key = fmt.Sprintf("%s.%s", key, property)
+	}
+	return key
+}
+
+// getLocalUserAccounts will return the argocd-cm account keys for the local users of the given ArgoCD.
+func getLocalUserAccounts(cr *argoprojv1a1.ArgoCD) map[string]string {
+	data := make(map[string]string)
+	for _, user := range cr.Spec.LocalUsers {
+		capabilities := make([]string, 0)
+		if isLocalUserAPIKeyEnabled(user) {
+			capabilities = append(capabilities, localUserCapabilityAPIKey)
+		}
+		if user.Login {
+			capabilities = append(capabilities, localUserCapabilityLogin)
+		}
+		data[getLocalUserAccountKey(user.Name, "")] = strings.Join(capabilities, ", ")
+		if !isLocalUserEnabled(user) {
+			data[getLocalUserAccountKey(user.Name, "enabled")] = "false"
+		}
+	}
+	return data
+}
+
+// reconcileLocalUserAccounts will ensure that the argocd-cm accounts match the local users of the given ArgoCD.
+// Accounts of local users that were removed from the ArgoCD are deleted, accounts that were not created by the
+// operator are left alone. Returns true if the ConfigMap was changed.
+func reconcileLocalUserAccounts(cm *corev1.ConfigMap, cr *argoprojv1a1.ArgoCD) bool {
+	changed := false
+	desired := getLocalUserAccounts(cr)
+
+	names := make([]string, 0, len(cr.Spec.LocalUsers))
+	for _, user := range cr.Spec.LocalUsers {
+		names = append(names, user.Name)
+	}
+	sort.Strings(names)
+
+	for _, name := range strings.Split(cm.Annotations[common.ArgoCDLocalUsersAnnotation], ",") {
+		if name == "" {
+			continue
+		}
+		for _, key := range []string{getLocalUserAccountKey(name, ""), getLocalUserAccountKey(name, "enabled")} {
+			if _, ok := cm.Data[key]; ok {
+				if _, ok := desired[key]; !ok {
+					delete(cm.Data, key)
+					changed = true
+				}
+			}
+		}
+	}
+
+	for key, value := range desired {
+		if current, ok := cm.Data[key]; !ok || current != value {
+			cm.Data[key] = value
+			changed = true
+		}
+	}
+
+	if cm.Annotations[common.ArgoCDLocalUsersAnnotation] != strings.Join(names, ",") {
+		if len(names) == 0 {
+			delete(cm.Annotations, common.ArgoCDLocalUsersAnnotation)
+		} else {
+			if cm.Annotations == nil {
+				cm.Annotations = make(map[string]string)
+			}
+			cm.Annotations[common.ArgoCDLocalUsersAnnotation] = strings.Join(names, ",")
+		}
+		changed = true
+	}
+	return changed
+}
+
+// reconcileLocalUsers will ensure that the generated credentials of the local users of the given ArgoCD are present
+// in their Secrets and in the given argocd-secret. Returns true if the argocd-secret data was changed.
+func (r *ReconcileArgoCD) reconcileLocalUsers(cr *argoprojv1a1.ArgoCD, argoSecret *corev1.Secret) (bool, error) {
+	changed := false
+	now := time.Now()
+
+	desired := make(map[string]bool)
+	for _, user := range cr.Spec.LocalUsers {
+		desired[getLocalUserSecretName(cr, user.Name)] = true
+		userChanged, err := r.reconcileLocalUser(cr, user, argoSecret, now)
+		if err != nil {
+			return false, err
+		}
+		changed = changed || userChanged
+	}
+
+	secrets := &corev1.SecretList{}
+	if err := r.Client.List(context.TODO(), secrets, client.InNamespace(cr.Namespace),
+		client.MatchingLabels{common.ArgoCDKeyManagedBy: cr.Name}, client.HasLabels{common.ArgoCDLocalUserLabel}); err != nil {
+		return false, err
+	}
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+		if desired[secret.Name] || !metav1.IsControlledBy(secret, cr) {
+			continue
+		}
+
+		name := secret.Labels[common.ArgoCDLocalUserLabel]
+		for _, property := range []string{"password", "passwordMtime", "tokens"} {
+			if _, ok := argoSecret.Data[getLocalUserAccountKey(name, property)]; ok {
+				delete(argoSecret.Data, getLocalUserAccountKey(name, property))
+				changed = true
+			}
+		}
+
+		log.Info(fmt.Sprintf("deleting secret [%s] of removed local user [%s]", secret.Name, name))
+		if err := r.Client.Delete(context.TODO(), secret); err != nil {
+			return false, err
+		}
+	}
+
+	return changed, nil
+}
+
+// reconcileLocalUser will ensure that the generated password and API token of the given local user are present and
+// valid. Returns true if the argocd-secret data was changed.
+func (r *ReconcileArgoCD) reconcileLocalUser(cr *argoprojv1a1.ArgoCD, user argoprojv1a1.LocalUserSpec, argoSecret *corev1.Secret, now time.Time) (bool, error) {
+	changed := false
+	secretChanged := false
+
+	secret := argoutil.NewSecretWithName(cr, getLocalUserSecretName(cr, user.Name))
+	found := argoutil.IsObjectFound(r.Client, cr.Namespace, secret.Name, secret)
+	if secret.Data == nil {
+		secret.Data = make(map[string][]byte)
+	}
+	if secret.Labels[common.ArgoCDLocalUserLabel] != user.Name {
+		secret.Labels[common.ArgoCDLocalUserLabel] = user.Name
+		secretChanged = true
+	}
+	if string(secret.Data[common.ArgoCDKeyLocalUserUsername]) != user.Name {
+		secret.Data[common.ArgoCDKeyLocalUserUsername] = []byte(user.Name)
+		secretChanged = true
+	}
+
+	// Password
+	passwordKey := getLocalUserAccountKey(user.Name, "password")
+	if user.Login {
+		password := secret.Data[common.ArgoCDKeyLocalUserPassword]
+		if len(password) == 0 {
+			generated, err := generateArgoAdminPassword()
+			if err != nil {
+				return false, err
+			}
+			password = generated
+			secret.Data[common.ArgoCDKeyLocalUserPassword] = password
+			secretChanged = true
+		}
+
+		if valid, _ := argopass.VerifyPassword(string(password), string(argoSecret.Data[passwordKey])); !valid {
+			hashedPassword, err := argopass.HashPassword(string(password))
+			if err != nil {
+				return false, err
+			}
+			argoSecret.Data[passwordKey] = []byte(hashedPassword)
+			argoSecret.Data[getLocalUserAccountKey(user.Name, "passwordMtime")] = nowBytes()
+			changed = true
+		}
+	} else {
+		if _, ok := secret.Data[common.ArgoCDKeyLocalUserPassword]; ok {
+			delete(secret.Data, common.ArgoCDKeyLocalUserPassword)
+			secretChanged = true
+		}
+		for _, key := range []string{passwordKey, getLocalUserAccountKey(user.Name, "passwordMtime")} {
+			if _, ok := argoSecret.Data[key]; ok {
+				delete(argoSecret.Data, key)
+				changed = true
+			}
+		}
+	}
+
+	// API Token
+	tokensKey := getLocalUserAccountKey(user.Name, "tokens")
+	tokens, err := getLocalUserTokens(argoSecret.Data[tokensKey])
+	if err != nil {
+		return false, err
+	}
+	signingKey := argoSecret.Data[common.ArgoCDKeyServerSecretKey]
+	current := string(secret.Data[common.ArgoCDKeyLocalUserAPIToken])
+
+	if isLocalUserAPIKeyEnabled(user) {
+		if needsLocalUserToken(user, current, tokens, signingKey, now) {
+			token, claims, err := newLocalUserToken(user.Name, getLocalUserTokenLifetime(user), signingKey, now)
+			if err != nil {
+				return false, err
+			}
+			tokens = append(removeLocalUserToken(tokens, current), localUserToken{ID: claims.ID, IssuedAt: claims.IssuedAt, ExpiresAt: claims.ExpiresAt})
+			if err := setLocalUserTokens(argoSecret, tokensKey, tokens); err != nil {
+				return false, err
+			}
+			secret.Data[common.ArgoCDKeyLocalUserAPIToken] = []byte(token)
+			changed = true
+			secretChanged = true
+		}
+	} else if current != "" {
+		if err := setLocalUserTokens(argoSecret, tokensKey, removeLocalUserToken(tokens, current)); err != nil {
+			return false, err
+		}
+		delete(secret.Data, common.ArgoCDKeyLocalUserAPIToken)
+		changed = true
+		secretChanged = true
+	}
+
+	if !found {
+		if err := controllerutil.SetControllerReference(cr, secret, r.Scheme); err != nil {
+			return false, err
+		}
+		return changed, r.Client.Create(context.TODO(), secret)
+	}
+	if secretChanged {
+		log.Info(fmt.Sprintf("updating secret [%s] of local user [%s]", secret.Name, user.Name))
+		return changed, r.Client.Update(context.TODO(), secret)
+	}
+	return changed, nil
+}
+
+// getLocalUserTokens will decode the argocd-secret list of API tokens issued for a local user.
+func getLocalUserTokens(data []byte) ([]localUserToken, error) {
+	tokens := make([]localUserToken, 0)
+	if len(data) == 0 {
+		return tokens, nil
+	}
+	if err := json.Unmarshal(data, &tokens); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// setLocalUserTokens will encode the given list of API tokens into the given argocd-secret key.
+func setLocalUserTokens(argoSecret *corev1.Secret, key string, tokens []localUserToken) error {
+	if len(tokens) == 0 {
+		delete(argoSecret.Data, key)
+		return nil
+	}
+	data, err := json.Marshal(tokens)
+	if err != nil {
+		return err
+	}
+	argoSecret.Data[key] = data
+	return nil
+}
+
+// removeLocalUserToken will return the given list of API tokens without the entry for the given JWT. Tokens issued
+// by other means, e.g. the argocd CLI, are kept.
+func removeLocalUserToken(tokens []localUserToken, token string) []localUserToken {
+	claims, err := decodeLocalUserToken(token)
+	if err != nil {
+		return tokens
+	}
+	result := make([]localUserToken, 0, len(tokens))
+	for _, t := range tokens {
+		if t.ID != claims.ID {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// needsLocalUserToken returns true if the given API token of a local user must be replaced with a new one. This is
+// the case when the token is missing, was not signed with the current server key, has been revoked, does not match
+// the configured lifetime or is due for renewal.
+func needsLocalUserToken(user argoprojv1a1.LocalUserSpec, token string, tokens []localUserToken, signingKey []byte, now time.Time) bool {
+	claims, err := verifyLocalUserToken(token, signingKey)
+	if err != nil {
+		return true
+	}
+	if claims.Subject != fmt.Sprintf("%s:%s", user.Name, localUserCapabilityAPIKey) {
+		return true
+	}
+
+	issued := false
+	for _, t := range tokens {
+		if t.ID == claims.ID {
+			issued = true
+		}
+	}
+	if !issued {
+		return true
+	}
+
+	lifetime := int64(getLocalUserTokenLifetime(user).Seconds())
+	if claims.ExpiresAt == 0 && lifetime != 0 || claims.ExpiresAt != 0 && claims.ExpiresAt-claims.IssuedAt != lifetime {
+		return true
+	}
+
+	return isLocalUserTokenAutoRenewed(user) && claims.ExpiresAt != 0 && !now.Before(claims.renewAt())
+}
+
+// newLocalUserToken will return a new API token for the given local user, signed with the given server key.
+func newLocalUserToken(name string, lifetime time.Duration, signingKey []byte, now time.Time) (string, localUserTokenClaims, error) {
+	claims := localUserTokenClaims{
+		ID:        string(uuid.NewUUID()),
+		Issuer:    localUserTokenIssuer,
+		Subject:   fmt.Sprintf("%s:%s", name, localUserCapabilityAPIKey),
+		IssuedAt:  now.Unix(),
+		NotBefore: now.Unix(),
+	}
+	if lifetime > 0 {
+		claims.ExpiresAt = now.Add(lifetime).Unix()
+	}
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", claims, err
+	}
+	unsigned := localUserTokenHeader + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return unsigned + "." + signLocalUserToken(unsigned, signingKey), claims, nil
+}
+
+// signLocalUserToken returns the encoded HS256 signature of the given unsigned JWT.
+func signLocalUserToken(unsigned string, signingKey []byte) string {
+	mac := hmac.New(sha256.New, signingKey)
+	mac.Write([]byte(unsigned))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// verifyLocalUserToken will return the claims of the given API token if it was signed with the given server key.
+func verifyLocalUserToken(token string, signingKey []byte) (*localUserTokenClaims, error) {
+	idx := strings.LastIndex(token, ".")
+	if idx < 0 {
+		return nil, errors.New("malformed token")
+	}
+	if !hmac.Equal([]byte(token[idx+1:]), []byte(signLocalUserToken(token[:idx], signingKey))) {
+		return nil, errors.New("invalid token signature")
+	}
+	return decodeLocalUserToken(token)
+}
+
+// decodeLocalUserToken will return the claims of the given API token without verifying its signature.
+func decodeLocalUserToken(token string) (*localUserTokenClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != localUserTokenHeader {
+		return nil, errors.New("malformed token")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, err
+	}
+	claims := &localUserTokenClaims{}
+	if err := json.Unmarshal(payload, claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// getLocalUserTokenRequeueAfter will return the time until the next API token of the given ArgoCD is due for renewal,
+// or zero if no token needs to be renewed.
+func (r *ReconcileArgoCD) getLocalUserTokenRequeueAfter(cr *argoprojv1a1.ArgoCD) time.Duration {
+	var requeueAfter time.Duration
+	for _, user := range cr.Spec.LocalUsers {
+		if !isLocalUserAPIKeyEnabled(user) || !isLocalUserTokenAutoRenewed(user) || getLocalUserTokenLifetime(user) == 0 {
+			continue
+		}
+
+		secret := argoutil.NewSecretWithName(cr, getLocalUserSecretName(cr, user.Name))
+		if !argoutil.IsObjectFound(r.Client, cr.Namespace, secret.Name, secret) {
+			continue
+		}
+		claims, err := decodeLocalUserToken(string(secret.Data[common.ArgoCDKeyLocalUserAPIToken]))
+		if err != nil || claims.ExpiresAt == 0 {
+			continue
+		}
+
+		d := time.Until(claims.renewAt())
+		if d < time.Second {
+			d = time.Second
+		}
+		if requeueAfter == 0 || d < requeueAfter {
+			requeueAfter = d
+		}
+	}
+	return requeueAfter
+}
@@ -0,0 +1,167 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	argopass "github.com/argoproj/argo-cd/util/password"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoprojv1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func withLocalUsers(users ...argoprojv1alpha1.LocalUserSpec) argoCDOpt {
+	return func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.LocalUsers = users
+	}
+}
+
+func getTestSecret(t *testing.T, r *ReconcileArgoCD, name string) *corev1.Secret {
+	t.Helper()
+	secret := &corev1.Secret{}
+	assert.NilError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: testNamespace}, secret))
+	return secret
+}
+
+func TestReconcileArgoCD_reconcileLocalUsers(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(withLocalUsers(
+		argoprojv1alpha1.LocalUserSpec{Name: "ci-bot", TokenLifetime: &metav1.Duration{Duration: 24 * time.Hour}},
+		argoprojv1alpha1.LocalUserSpec{Name: "alice", APIKey: boolPtr(false), Login: true, Enabled: boolPtr(false)},
+	))
+	r := makeTestReconciler(t, a)
+
+	assert.NilError(t, r.reconcileSecrets(a))
+	assert.NilError(t, r.reconcileArgoConfigMap(a))
+
+	argoSecret := getTestSecret(t, r, common.ArgoCDSecretName)
+
+	// The API token is signed with the server key and registered in argocd-secret.
+	botSecret := getTestSecret(t, r, "argocd-local-user-ci-bot")
+	assert.Equal(t, string(botSecret.Data[common.ArgoCDKeyLocalUserUsername]), "ci-bot")
+	_, found := botSecret.Data[common.ArgoCDKeyLocalUserPassword]
+	assert.Assert(t, !found)
+	claims, err := verifyLocalUserToken(string(botSecret.Data[common.ArgoCDKeyLocalUserAPIToken]), argoSecret.Data[common.ArgoCDKeyServerSecretKey])
+	assert.NilError(t, err)
+	assert.Equal(t, claims.Subject, "ci-bot:apiKey")
+	assert.Equal(t, claims.ExpiresAt-claims.IssuedAt, int64(24*time.Hour/time.Second))
+	tokens, err := getLocalUserTokens(argoSecret.Data["accounts.ci-bot.tokens"])
+	assert.NilError(t, err)
+	assert.DeepEqual(t, tokens, []localUserToken{{ID: claims.ID, IssuedAt: claims.IssuedAt, ExpiresAt: claims.ExpiresAt}})
+
+	// The password is hashed into argocd-secret.
+	aliceSecret := getTestSecret(t, r, "argocd-local-user-alice")
+	_, found = aliceSecret.Data[common.ArgoCDKeyLocalUserAPIToken]
+	assert.Assert(t, !found)
+	valid, _ := argopass.VerifyPassword(string(aliceSecret.Data[common.ArgoCDKeyLocalUserPassword]), string(argoSecret.Data["accounts.alice.password"]))
+	assert.Assert(t, valid)
+
+	cm := &corev1.ConfigMap{}
+	assert.NilError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDConfigMapName, Namespace: testNamespace}, cm))
+	assert.Equal(t, cm.Data["accounts.ci-bot"], "apiKey")
+	assert.Equal(t, cm.Data["accounts.alice"], "login")
+	assert.Equal(t, cm.Data["accounts.alice.enabled"], "false")
+	assert.Equal(t, cm.Annotations[common.ArgoCDLocalUsersAnnotation], "alice,ci-bot")
+
+	// A second reconciliation keeps the generated credentials.
+	assert.NilError(t, r.reconcileSecrets(a))
+	assert.DeepEqual(t, getTestSecret(t, r, "argocd-local-user-ci-bot").Data, botSecret.Data)
+	assert.DeepEqual(t, getTestSecret(t, r, "argocd-local-user-alice").Data, aliceSecret.Data)
+
+	// Removing a user deletes its credentials and account, other accounts are kept.
+	a.Spec.LocalUsers = a.Spec.LocalUsers[1:]
+	cm.Data["accounts.manual"] = "apiKey"
+	assert.NilError(t, r.Client.Update(context.TODO(), cm))
+	assert.NilError(t, r.reconcileSecrets(a))
+	assert.NilError(t, r.reconcileArgoConfigMap(a))
+
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-local-user-ci-bot", Namespace: testNamespace}, &corev1.Secret{})
+	assert.Assert(t, apierrors.IsNotFound(err))
+	_, found = getTestSecret(t, r, common.ArgoCDSecretName).Data["accounts.ci-bot.tokens"]
+	assert.Assert(t, !found)
+
+	cm = &corev1.ConfigMap{}
+	assert.NilError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDConfigMapName, Namespace: testNamespace}, cm))
+	_, found = cm.Data["accounts.ci-bot"]
+	assert.Assert(t, !found)
+	assert.Equal(t, cm.Data["accounts.alice"], "login")
+	assert.Equal(t, cm.Data["accounts.manual"], "apiKey")
+	assert.Equal(t, cm.Annotations[common.ArgoCDLocalUsersAnnotation], "alice")
+}
+
+func TestReconcileArgoCD_reconcileLocalUsers_keepsOtherTokens(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(withLocalUsers(argoprojv1alpha1.LocalUserSpec{Name: "ci-bot"}))
+	r := makeTestReconciler(t, a)
+
+	assert.NilError(t, r.reconcileSecrets(a))
+
+	// Tokens generated with the argocd CLI survive the rotation of the operator generated token.
+	argoSecret := getTestSecret(t, r, common.ArgoCDSecretName)
+	tokens, err := getLocalUserTokens(argoSecret.Data["accounts.ci-bot.tokens"])
+	assert.NilError(t, err)
+	tokens = append(tokens, localUserToken{ID: "cli-token", IssuedAt: 1})
+	assert.NilError(t, setLocalUserTokens(argoSecret, "accounts.ci-bot.tokens", tokens))
+	assert.NilError(t, r.Client.Update(context.TODO(), argoSecret))
+
+	a.Spec.LocalUsers[0].TokenLifetime = &metav1.Duration{Duration: time.Hour}
+	assert.NilError(t, r.reconcileSecrets(a))
+
+	argoSecret = getTestSecret(t, r, common.ArgoCDSecretName)
+	claims, err := verifyLocalUserToken(string(getTestSecret(t, r, "argocd-local-user-ci-bot").Data[common.ArgoCDKeyLocalUserAPIToken]), argoSecret.Data[common.ArgoCDKeyServerSecretKey])
+	assert.NilError(t, err)
+	tokens, err = getLocalUserTokens(argoSecret.Data["accounts.ci-bot.tokens"])
+	assert.NilError(t, err)
+	assert.DeepEqual(t, tokens, []localUserToken{{ID: "cli-token", IssuedAt: 1}, {ID: claims.ID, IssuedAt: claims.IssuedAt, ExpiresAt: claims.ExpiresAt}})
+
+	assert.Assert(t, r.getLocalUserTokenRequeueAfter(a) > 47*time.Minute)
+}
+
+func Test_needsLocalUserToken(t *testing.T) {
+	key := []byte("server-key")
+	now := time.Unix(1600000000, 0)
+	user := argoprojv1alpha1.LocalUserSpec{Name: "ci-bot", TokenLifetime: &metav1.Duration{Duration: 10 * time.Hour}}
+
+	token, claims, err := newLocalUserToken("ci-bot", 10*time.Hour, key, now)
+	assert.NilError(t, err)
+	tokens := []localUserToken{{ID: claims.ID, IssuedAt: claims.IssuedAt, ExpiresAt: claims.ExpiresAt}}
+
+	noRenew := user
+	noRenew.AutoRenewToken = boolPtr(false)
+	otherUser := user
+	otherUser.Name = "other"
+	noExpiry := user
+	noExpiry.TokenLifetime = nil
+
+	tests := []struct {
+		name   string
+		user   argoprojv1alpha1.LocalUserSpec
+		token  string
+		tokens []localUserToken
+		key    []byte
+		now    time.Time
+		want   bool
+	}{
+		{"valid", user, token, tokens, key, now.Add(7 * time.Hour), false},
+		{"missing", user, "", tokens, key, now, true},
+		{"rotated server key", user, token, tokens, []byte("other-key"), now, true},
+		{"revoked", user, token, nil, key, now, true},
+		{"other user", otherUser, token, tokens, key, now, true},
+		{"lifetime changed", noExpiry, token, tokens, key, now, true},
+		{"due for renewal", user, token, tokens, key, now.Add(8 * time.Hour), true},
+		{"renewal disabled", noRenew, token, tokens, key, now.Add(11 * time.Hour), false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, needsLocalUserToken(test.user, test.token, test.tokens, test.key, test.now), test.want)
+		})
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright 2021 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"reflect"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	argoprojv1a1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// networkPolicyTarget describes the pods of an Argo CD component protected by a NetworkPolicy.
+type networkPolicyTarget struct {
+	// suffix is the name suffix of the pods selected by the NetworkPolicy.
+	suffix string
+	// ports are all the ports the pods listen on.
+	ports []int
+	// ingress are the rules allowing the other Argo CD components to reach the pods.
+	ingress []networkingv1.NetworkPolicyIngressRule
+	// extra are the additional sources allowed to reach all ports of the pods.
+	extra []networkingv1.NetworkPolicyPeer
+	// deployed reports whether the component is managed for the given ArgoCD.
+	deployed bool
+}
+
+// getNetworkPolicyPorts returns the NetworkPolicy ports for the given TCP ports.
+func getNetworkPolicyPorts(ports ...int) []networkingv1.NetworkPolicyPort {
+	protocol := corev1.ProtocolTCP
+	result := make([]networkingv1.NetworkPolicyPort, 0, len(ports))
+	for _, port := range ports {
+		p := intstr.FromInt(port)
+		result = append(result, networkingv1.NetworkPolicyPort{Protocol: &protocol, Port: &p})
+	}
+	return result
+}
+
+// getNetworkPolicyComponentPeers returns the NetworkPolicy peers selecting the pods of the Argo CD components with the given suffixes.
+func getNetworkPolicyComponentPeers(cr *argoprojv1a1.ArgoCD, suffixes ...string) []networkingv1.NetworkPolicyPeer {
+	peers := make([]networkingv1.NetworkPolicyPeer, 0, len(suffixes))
+	for _, suffix := range suffixes {
+		peers = append(peers, networkingv1.NetworkPolicyPeer{
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					common.ArgoCDKeyName: nameWithSuffix(suffix, cr),
+				},
+			},
+		})
+	}
+	return peers
+}
+
+// getNetworkPolicyTargets returns the pods of all Argo CD components that can have a NetworkPolicy.
+func getNetworkPolicyTargets(cr *argoprojv1a1.ArgoCD) []networkPolicyTarget {
+	spec := cr.Spec.NetworkPolicy
+
+	// The Argo CD components that use the repo server.
+	repoClients := getNetworkPolicyComponentPeers(cr, "server", "application-controller", "applicationset-controller")
+	if cr.Spec.Notifications != nil {
+		repoClients = append(repoClients, getNetworkPolicyComponentPeers(cr, "notifications-controller")...)
+	}
+	// The Argo CD components that use Redis, see getRedisClientArgs.
+	redisClients := getNetworkPolicyComponentPeers(cr, "server", "application-controller", "repo-server")
+	redisHAClients := getNetworkPolicyComponentPeers(cr, "redis-ha-haproxy", "redis-ha")
+
+	return []networkPolicyTarget{
+		{
+			suffix:   "application-controller",
+			ports:    []int{common.ArgoCDDefaultControllerMetricsPort},
+			extra:    spec.Controller,
+			deployed: true,
+		},
+		{
+			suffix:   "applicationset-controller",
+			ports:    []int{common.ArgoCDDefaultApplicationSetWebhookPort, common.ArgoCDDefaultApplicationSetMetricsPort},
+			extra:    spec.ApplicationSet,
+			deployed: cr.Spec.ApplicationSet != nil,
+		},
+		{
+			suffix: "dex-server",
+			ports:  []int{common.ArgoCDDefaultDexHTTPPort, common.ArgoCDDefaultDexGRPCPort},
+			ingress: []networkingv1.NetworkPolicyIngressRule{{
+				From:  getNetworkPolicyComponentPeers(cr, "server"),
+				Ports: getNetworkPolicyPorts(common.ArgoCDDefaultDexHTTPPort, common.ArgoCDDefaultDexGRPCPort),
+			}},
+			extra:    spec.Dex,
+			deployed: !isDexDisabled(),
+		},
+		{
+			suffix:   "notifications-controller",
+			ports:    []int{common.ArgoCDDefaultNotificationsMetricsPort},
+			extra:    spec.Notifications,
+			deployed: cr.Spec.Notifications != nil,
+		},
+		{
+			suffix: "redis",
+			ports:  []int{common.ArgoCDDefaultRedisPort},
+			ingress: []networkingv1.NetworkPolicyIngressRule{{
+				From:  redisClients,
+				Ports: getNetworkPolicyPorts(common.ArgoCDDefaultRedisPort),
+			}},
+			extra:    spec.Redis,
+			deployed: isRedisDeploymentEnabled(cr),
+		},
+		{
+			suffix: "redis-ha-haproxy",
+			ports:  []int{common.ArgoCDDefaultRedisPort},
+			ingress: []networkingv1.NetworkPolicyIngressRule{{
+				From:  redisClients,
+				Ports: getNetworkPolicyPorts(common.ArgoCDDefaultRedisPort),
+			}},
+			extra:    spec.Redis,
+			deployed: isRedisHAEnabled(cr),
+		},
+		{
+			suffix: "redis-ha",
+			ports:  []int{common.ArgoCDDefaultRedisPort, common.ArgoCDDefaultRedisSentinelPort},
+			ingress: []networkingv1.NetworkPolicyIngressRule{{
+				From:  redisHAClients,
+				Ports: getNetworkPolicyPorts(common.ArgoCDDefaultRedisPort, common.ArgoCDDefaultRedisSentinelPort),
+			}},
+			deployed: isRedisHAEnabled(cr),
+		},
+		{
+			suffix: "repo-server",
+			ports:  []int{common.ArgoCDDefaultRepoServerPort, common.ArgoCDDefaultRepoMetricsPort},
+			ingress: []networkingv1.NetworkPolicyIngressRule{{
+				From:  repoClients,
+				Ports: getNetworkPolicyPorts(common.ArgoCDDefaultRepoServerPort),
+			}},
+			extra:    spec.Repo,
+			deployed: true,
+		},
+		{
+			suffix: "server",
+			ports:  []int{common.ArgoCDDefaultServerPort, common.ArgoCDDefaultServerMetricsPort},
+			ingress: []networkingv1.NetworkPolicyIngressRule{{
+				// The Argo CD API and UI are reachable from all sources.
+				Ports: getNetworkPolicyPorts(common.ArgoCDDefaultServerPort),
+			}},
+			extra:    spec.Server,
+			deployed: true,
+		},
+	}
+}
+
+// newNetworkPolicyWithSuffix returns a new NetworkPolicy for the given ArgoCD using the given suffix.
+func newNetworkPolicyWithSuffix(suffix string, cr *argoprojv1a1.ArgoCD) *networkingv1.NetworkPolicy {
+	name := nameWithSuffix(suffix+"-network-policy", cr)
+
+	lbls := argoutil.LabelsForCluster(cr)
+	lbls[common.ArgoCDKeyName] = name
+
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: cr.Namespace,
+			Labels:    lbls,
+		},
+	}
+}
+
+// getNetworkPolicySpec returns the NetworkPolicy spec for the given target.
+func getNetworkPolicySpec(target networkPolicyTarget, cr *argoprojv1a1.ArgoCD) networkingv1.NetworkPolicySpec {
+	ingress := append([]networkingv1.NetworkPolicyIngressRule{}, target.ingress...)
+	if len(target.extra) > 0 {
+		ingress = append(ingress, networkingv1.NetworkPolicyIngressRule{
+			From:  target.extra,
+			Ports: getNetworkPolicyPorts(target.ports...),
+		})
+	}
+
+	return networkingv1.NetworkPolicySpec{
+		PodSelector: metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				common.ArgoCDKeyName: nameWithSuffix(target.suffix, cr),
+			},
+		},
+		Ingress:     ingress,
+		PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+	}
+}
+
+// reconcileNetworkPolicy will ensure that the NetworkPolicy for the given target is present or absent.
+func (r *ReconcileArgoCD) reconcileNetworkPolicy(target networkPolicyTarget, cr *argoprojv1a1.ArgoCD) error {
+	enabled := target.deployed && cr.Spec.NetworkPolicy.Enabled
+
+	policy := newNetworkPolicyWithSuffix(target.suffix, cr)
+	policy.Spec = getNetworkPolicySpec(target, cr)
+
+	existing := newNetworkPolicyWithSuffix(target.suffix, cr)
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
+		if !enabled {
+			return r.Client.Delete(context.TODO(), existing) // NetworkPolicy found but disabled, delete it.
+		}
+
+		if !reflect.DeepEqual(existing.Spec, policy.Spec) {
+			existing.Spec = policy.Spec
+			return r.Client.Update(context.TODO(), existing)
+		}
+		return nil // NetworkPolicy found with nothing to do, move along...
+	}
+
+	if !enabled {
+		return nil // NetworkPolicy not enabled, move along...
+	}
+
+	if err := controllerutil.SetControllerReference(cr, policy, r.Scheme); err != nil {
+		return err
+	}
+	return r.Client.Create(context.TODO(), policy)
+}
+
+// reconcileNetworkPolicies will ensure that the NetworkPolicies of all Argo CD components are present or absent.
+func (r *ReconcileArgoCD) reconcileNetworkPolicies(cr *argoprojv1a1.ArgoCD) error {
+	for _, target := range getNetworkPolicyTargets(cr) {
+		if err := r.reconcileNetworkPolicy(target, cr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoprojv1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+)
+
+func listTestNetworkPolicies(t *testing.T, r *ReconcileArgoCD) map[string]networkingv1.NetworkPolicySpec {
+	t.Helper()
+	list := &networkingv1.NetworkPolicyList{}
+	assert.NilError(t, r.Client.List(context.TODO(), list))
+	policies := map[string]networkingv1.NetworkPolicySpec{}
+	for _, policy := range list.Items {
+		policies[policy.Name] = policy.Spec
+	}
+	return policies
+}
+
+// getTestNetworkPolicyPeerNames returns the name labels of the pods selected by the given peers.
+func getTestNetworkPolicyPeerNames(peers []networkingv1.NetworkPolicyPeer) []string {
+	names := []string{}
+	for _, peer := range peers {
+		names = append(names, peer.PodSelector.MatchLabels["app.kubernetes.io/name"])
+	}
+	return names
+}
+
+func getTestNetworkPolicyPortNumbers(ports []networkingv1.NetworkPolicyPort) []int {
+	numbers := []int{}
+	for _, port := range ports {
+		numbers = append(numbers, port.Port.IntValue())
+	}
+	return numbers
+}
+
+func TestReconcileArgoCD_reconcileNetworkPolicies(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	r := makeTestReconciler(t, a)
+
+	assert.NilError(t, r.reconcileNetworkPolicies(a))
+	assert.Equal(t, len(listTestNetworkPolicies(t, r)), 0)
+
+	a.Spec.NetworkPolicy.Enabled = true
+	assert.NilError(t, r.reconcileNetworkPolicies(a))
+
+	policies := listTestNetworkPolicies(t, r)
+	assert.Equal(t, len(policies), 5)
+	for _, name := range []string{"argocd-application-controller-network-policy", "argocd-dex-server-network-policy", "argocd-redis-network-policy", "argocd-repo-server-network-policy", "argocd-server-network-policy"} {
+		_, ok := policies[name]
+		assert.Assert(t, ok, name)
+	}
+
+	// Only the server, application controller and ApplicationSet controller can reach the repo server.
+	repo := policies["argocd-repo-server-network-policy"]
+	assert.Equal(t, repo.PodSelector.MatchLabels["app.kubernetes.io/name"], "argocd-repo-server")
+	assert.DeepEqual(t, repo.PolicyTypes, []networkingv1.PolicyType{networkingv1.PolicyTypeIngress})
+	assert.Equal(t, len(repo.Ingress), 1)
+	assert.DeepEqual(t, getTestNetworkPolicyPeerNames(repo.Ingress[0].From), []string{"argocd-server", "argocd-application-controller", "argocd-applicationset-controller"})
+	assert.DeepEqual(t, getTestNetworkPolicyPortNumbers(repo.Ingress[0].Ports), []int{8081})
+	assert.Equal(t, *repo.Ingress[0].Ports[0].Protocol, corev1.ProtocolTCP)
+
+	// Only the server, application controller and repo server can reach Redis.
+	redis := policies["argocd-redis-network-policy"]
+	assert.DeepEqual(t, getTestNetworkPolicyPeerNames(redis.Ingress[0].From), []string{"argocd-server", "argocd-application-controller", "argocd-repo-server"})
+	assert.DeepEqual(t, getTestNetworkPolicyPortNumbers(redis.Ingress[0].Ports), []int{6379})
+
+	// The Argo CD API is reachable from all sources, the metrics of the components are not.
+	server := policies["argocd-server-network-policy"]
+	assert.Equal(t, len(server.Ingress), 1)
+	assert.Assert(t, server.Ingress[0].From == nil)
+	assert.DeepEqual(t, getTestNetworkPolicyPortNumbers(server.Ingress[0].Ports), []int{8080})
+	assert.Equal(t, len(policies["argocd-application-controller-network-policy"].Ingress), 0)
+
+	// Disabling the network policies removes them again.
+	a.Spec.NetworkPolicy.Enabled = false
+	assert.NilError(t, r.reconcileNetworkPolicies(a))
+	assert.Equal(t, len(listTestNetworkPolicies(t, r)), 0)
+}
+
+func TestReconcileArgoCD_reconcileNetworkPolicies_HA(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.HA.Enabled = true
+		a.Spec.NetworkPolicy.Enabled = true
+		a.Spec.Notifications = &argoprojv1alpha1.ArgoCDNotifications{}
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NilError(t, r.reconcileNetworkPolicies(a))
+
+	policies := listTestNetworkPolicies(t, r)
+	_, found := policies["argocd-redis-network-policy"]
+	assert.Assert(t, !found)
+
+	haproxy := policies["argocd-redis-ha-haproxy-network-policy"]
+	assert.DeepEqual(t, getTestNetworkPolicyPeerNames(haproxy.Ingress[0].From), []string{"argocd-server", "argocd-application-controller", "argocd-repo-server"})
+	assert.DeepEqual(t, getTestNetworkPolicyPortNumbers(haproxy.Ingress[0].Ports), []int{6379})
+
+	redisHA := policies["argocd-redis-ha-network-policy"]
+	assert.Equal(t, redisHA.PodSelector.MatchLabels["app.kubernetes.io/name"], "argocd-redis-ha")
+	assert.DeepEqual(t, getTestNetworkPolicyPeerNames(redisHA.Ingress[0].From), []string{"argocd-redis-ha-haproxy", "argocd-redis-ha"})
+	assert.DeepEqual(t, getTestNetworkPolicyPortNumbers(redisHA.Ingress[0].Ports), []int{6379, 26379})
+
+	// The notifications controller renders templates with the repo server.
+	repo := policies["argocd-repo-server-network-policy"]
+	assert.DeepEqual(t, getTestNetworkPolicyPeerNames(repo.Ingress[0].From), []string{"argocd-server", "argocd-application-controller", "argocd-applicationset-controller", "argocd-notifications-controller"})
+	_, found = policies["argocd-notifications-controller-network-policy"]
+	assert.Assert(t, found)
+}
+
+func TestReconcileArgoCD_reconcileNetworkPolicies_extraIngress(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	monitoring := networkingv1.NetworkPolicyPeer{
+		NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"kubernetes.io/metadata.name": "monitoring"}},
+	}
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.NetworkPolicy = argoprojv1alpha1.ArgoCDNetworkPolicySpec{
+			Enabled: true,
+			Repo:    []networkingv1.NetworkPolicyPeer{monitoring},
+		}
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NilError(t, r.reconcileNetworkPolicies(a))
+
+	policy := &networkingv1.NetworkPolicy{}
+	assert.NilError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server-network-policy", Namespace: testNamespace}, policy))
+	assert.Equal(t, policy.OwnerReferences[0].Name, a.Name)
+	assert.Equal(t, len(policy.Spec.Ingress), 2)
+	assert.DeepEqual(t, policy.Spec.Ingress[1].From, []networkingv1.NetworkPolicyPeer{monitoring})
+	assert.DeepEqual(t, getTestNetworkPolicyPortNumbers(policy.Spec.Ingress[1].Ports), []int{8081, 8084})
+
+	// Changes to the extra sources are applied to the existing NetworkPolicy.
+	a.Spec.NetworkPolicy.Repo = nil
+	a.Spec.NetworkPolicy.Server = []networkingv1.NetworkPolicyPeer{monitoring}
+	assert.NilError(t, r.reconcileNetworkPolicies(a))
+
+	policies := listTestNetworkPolicies(t, r)
+	assert.Equal(t, len(policies["argocd-repo-server-network-policy"].Ingress), 1)
+	server := policies["argocd-server-network-policy"]
+	assert.Equal(t, len(server.Ingress), 2)
+	assert.DeepEqual(t, server.Ingress[1].Ports[1].Port, intstrPtr(intstr.FromInt(8083)))
+}
+
+func intstrPtr(val intstr.IntOrString) *intstr.IntOrString {
+	return &val
+}
@@ -0,0 +1,550 @@
+// Copyright 2021 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/yaml"
+
+	argoprojv1a1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// notificationsConfigKeyPrefixes are the prefixes of the argocd-notifications-cm keys managed by the operator.
+var notificationsConfigKeyPrefixes = []string{
+	common.ArgoCDKeyNotificationsService,
+	common.ArgoCDKeyNotificationsTemplate,
+	common.ArgoCDKeyNotificationsTrigger,
+}
+
+// getArgoNotificationsCommand will return the command for the Argo CD Notifications component.
+func getArgoNotificationsCommand(cr *argoprojv1a1.ArgoCD) []string {
+	cmd := make([]string, 0)
+
+	cmd = append(cmd, "argocd-notifications")
+
+	cmd = append(cmd, "--argocd-repo-server")
+	cmd = append(cmd, getRepoServerAddress(cr))
+
+	cmd = append(cmd, "--loglevel")
+	cmd = append(cmd, getLogLevel(cr.Spec.Notifications.LogLevel))
+
+	return cmd
+}
+
+func (r *ReconcileArgoCD) reconcileNotificationsController(cr *argoprojv1a1.ArgoCD) error {
+
+	log.Info("reconciling notifications serviceaccounts")
+	sa, err := r.reconcileNotificationsServiceAccount(cr)
+	if err != nil {
+		return err
+	}
+
+	log.Info("reconciling notifications roles")
+	role, err := r.reconcileNotificationsRole(cr)
+	if err != nil {
+		return err
+	}
+
+	log.Info("reconciling notifications role bindings")
+	if err := r.reconcileNotificationsRoleBinding(cr, role, sa); err != nil {
+		return err
+	}
+
+	log.Info("reconciling notifications configmaps")
+	if err := r.reconcileNotificationsConfigMap(cr); err != nil {
+		return err
+	}
+
+	log.Info("reconciling notifications secrets")
+	if err := r.reconcileNotificationsSecret(cr); err != nil {
+		return err
+	}
+
+	log.Info("reconciling notifications deployments")
+	if err := r.reconcileNotificationsDeployment(cr, sa); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// reconcileNotificationsDeployment will ensure the Deployment resource is present for the Argo CD Notifications component.
+func (r *ReconcileArgoCD) reconcileNotificationsDeployment(cr *argoprojv1a1.ArgoCD, sa *corev1.ServiceAccount) error {
+	deploy := newDeploymentWithSuffix("notifications-controller", "controller", cr)
+
+	setNotificationsLabels(&deploy.ObjectMeta)
+
+	podSpec := &deploy.Spec.Template.Spec
+
+	podSpec.ServiceAccountName = sa.ObjectMeta.Name
+
+	podSpec.Volumes = []corev1.Volume{
+		{
+			Name: "tls-certs",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: common.ArgoCDTLSCertsConfigMapName,
+					},
+				},
+			},
+		},
+		{
+			Name: "argocd-repo-server-tls",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: common.ArgoCDRepoServerTLSSecretName,
+					Optional:   boolPtr(true),
+				},
+			},
+		},
+	}
+
+	podSpec.Containers = []corev1.Container{{
+		Command:         getArgoNotificationsCommand(cr),
+		Image:           getNotificationsContainerImage(cr),
+		ImagePullPolicy: corev1.PullAlways,
+		Name:            "argocd-notifications-controller",
+		Resources:       getNotificationsResources(cr),
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      "tls-certs",
+				MountPath: "/app/config/tls",
+			},
+			{
+				Name:      "argocd-repo-server-tls",
+				MountPath: "/app/config/reposerver/tls",
+			},
+		},
+		WorkingDir: "/app",
+	}}
+
+	applyPodScheduling(&deploy.Spec.Template.Spec, cr.Spec.Notifications.Affinity, cr.Spec.Notifications.TopologySpreadConstraints, cr.Spec.Notifications.PriorityClassName)
+
+	if err := applyPodTemplateOverride(&deploy.Spec.Template, cr.Spec.Notifications.PodTemplateOverride); err != nil {
+		return err
+	}
+
+	if existing := newDeploymentWithSuffix("notifications-controller", "controller", cr); argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
+
+		existingSpec := existing.Spec.Template.Spec
+
+		deploymentsDifferent := !reflect.DeepEqual(existingSpec.Containers, podSpec.Containers) ||
+			!reflect.DeepEqual(existingSpec.Volumes, podSpec.Volumes) ||
+			existingSpec.ServiceAccountName != podSpec.ServiceAccountName ||
+			!reflect.DeepEqual(existing.Labels, deploy.Labels) ||
+			!reflect.DeepEqual(existing.Spec.Template.Labels, deploy.Spec.Template.Labels) ||
+			!reflect.DeepEqual(existing.Spec.Selector, deploy.Spec.Selector) ||
+			!reflect.DeepEqual(existing.Spec.Template.Spec.NodeSelector, deploy.Spec.Template.Spec.NodeSelector) ||
+			!reflect.DeepEqual(existing.Spec.Template.Spec.Tolerations, deploy.Spec.Template.Spec.Tolerations)
+
+		// If the Deployment already exists, make sure the values we care about are up-to-date
+		if deploymentsDifferent {
+			existing.Spec.Template.Spec.Containers = podSpec.Containers
+			existing.Spec.Template.Spec.Volumes = podSpec.Volumes
+			existing.Spec.Template.Spec.ServiceAccountName = podSpec.ServiceAccountName
+			existing.Labels = deploy.Labels
+			existing.Spec.Template.Labels = deploy.Spec.Template.Labels
+			existing.Spec.Selector = deploy.Spec.Selector
+			existing.Spec.Template.Spec.NodeSelector = deploy.Spec.Template.Spec.NodeSelector
+			existing.Spec.Template.Spec.Tolerations = deploy.Spec.Template.Spec.Tolerations
+		}
+		updatePodScheduling(&existing.Spec.Template.Spec, &deploy.Spec.Template.Spec, &deploymentsDifferent)
+		if err := updatePodTemplateOverride(&existing.Spec.Template, &deploy.Spec.Template, cr.Spec.Notifications.PodTemplateOverride, &deploymentsDifferent); err != nil {
+			return err
+		}
+		if deploymentsDifferent {
+			return r.Client.Update(context.TODO(), existing)
+		}
+		return nil // Deployment found with nothing to do, move along...
+	}
+
+	if err := controllerutil.SetControllerReference(cr, deploy, r.Scheme); err != nil {
+		return err
+	}
+	return r.Client.Create(context.TODO(), deploy)
+}
+
+func (r *ReconcileArgoCD) reconcileNotificationsServiceAccount(cr *argoprojv1a1.ArgoCD) (*corev1.ServiceAccount, error) {
+
+	sa := newServiceAccountWithName("notifications-controller", cr)
+	setNotificationsLabels(&sa.ObjectMeta)
+
+	exists := true
+	if err := argoutil.FetchObject(r.Client, cr.Namespace, sa.Name, sa); err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, err
+		}
+		exists = false
+	}
+
+	if exists {
+		return sa, nil
+	}
+
+	if err := controllerutil.SetControllerReference(cr, sa, r.Scheme); err != nil {
+		return nil, err
+	}
+
+	err := r.Client.Create(context.TODO(), sa)
+	if err != nil {
+		return nil, err
+	}
+
+	return sa, err
+}
+
+func (r *ReconcileArgoCD) reconcileNotificationsRole(cr *argoprojv1a1.ArgoCD) (*v1.Role, error) {
+
+	policyRules := []v1.PolicyRule{
+
+		// Applications and Projects
+		{
+			APIGroups: []string{"argoproj.io"},
+			Resources: []string{
+				"applications",
+				"appprojects",
+			},
+			Verbs: []string{
+				"get",
+				"list",
+				"patch",
+				"update",
+				"watch",
+			},
+		},
+
+		// Read Secrets/ConfigMaps
+		{
+			APIGroups: []string{""},
+			Resources: []string{
+				"secrets",
+				"configmaps",
+			},
+			Verbs: []string{
+				"get",
+				"list",
+				"watch",
+			},
+		},
+	}
+
+	role := newRole("notifications-controller", policyRules, cr)
+	setNotificationsLabels(&role.ObjectMeta)
+
+	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: role.Name, Namespace: cr.Namespace}, role)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to reconcile the role for the service account associated with %s : %s", role.Name, err)
+		}
+		if err = controllerutil.SetControllerReference(cr, role, r.Scheme); err != nil {
+			return nil, err
+		}
+		return role, r.Client.Create(context.TODO(), role)
+	}
+
+	role.Rules = policyRules
+	if err = controllerutil.SetControllerReference(cr, role, r.Scheme); err != nil {
+		return nil, err
+	}
+	return role, r.Client.Update(context.TODO(), role)
+}
+
+func (r *ReconcileArgoCD) reconcileNotificationsRoleBinding(cr *argoprojv1a1.ArgoCD, role *v1.Role, sa *corev1.ServiceAccount) error {
+
+	name := "notifications-controller"
+
+	// get expected name
+	roleBinding := newRoleBindingWithname(name, cr)
+
+	// fetch existing rolebinding by name
+	roleBindingExists := true
+	if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: roleBinding.Name, Namespace: cr.Namespace}, roleBinding); err != nil {
+		if !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to get the rolebinding associated with %s : %s", name, err)
+		}
+		roleBindingExists = false
+	}
+
+	setNotificationsLabels(&roleBinding.ObjectMeta)
+
+	roleBinding.RoleRef = v1.RoleRef{
+		APIGroup: v1.GroupName,
+		Kind:     "Role",
+		Name:     role.Name,
+	}
+
+	roleBinding.Subjects = []v1.Subject{
+		{
+			Kind:      v1.ServiceAccountKind,
+			Name:      sa.Name,
+			Namespace: sa.Namespace,
+		},
+	}
+
+	if err := controllerutil.SetControllerReference(cr, roleBinding, r.Scheme); err != nil {
+		return err
+	}
+
+	if roleBindingExists {
+		return r.Client.Update(context.TODO(), roleBinding)
+	}
+
+	return r.Client.Create(context.TODO(), roleBinding)
+}
+
+// getNotificationsServiceKey will return the argocd-notifications-cm key for the given notification service.
+func getNotificationsServiceKey(service argoprojv1a1.NotificationService) string {
+	key := fmt.Sprintf("%s.%s", common.ArgoCDKeyNotificationsService, service.Type)
+	if service.Name != "" {
+		key = fmt.Sprintf("%s.%s", key, service.Name)
+	}
+	return key
+}
+
+// getNotificationsConfig will return the argocd-notifications-cm data for the given ArgoCD.
+func getNotificationsConfig(cr *argoprojv1a1.ArgoCD) (map[string]string, error) {
+	data := make(map[string]string)
+	notifications := cr.Spec.Notifications
+
+	if len(notifications.Context) > 0 {
+		out, err := yaml.Marshal(notifications.Context)
+		if err != nil {
+			return nil, err
+		}
+		data[common.ArgoCDKeyNotificationsContext] = string(out)
+	}
+
+	for _, trigger := range notifications.Triggers {
+		out, err := yaml.Marshal(trigger.Conditions)
+		if err != nil {
+			return nil, err
+		}
+		data[fmt.Sprintf("%s.%s", common.ArgoCDKeyNotificationsTrigger, trigger.Name)] = string(out)
+	}
+
+	for _, template := range notifications.Templates {
+		out, err := yaml.Marshal(template.NotificationTemplateSpec)
+		if err != nil {
+			return nil, err
+		}
+		data[fmt.Sprintf("%s.%s", common.ArgoCDKeyNotificationsTemplate, template.Name)] = string(out)
+	}
+
+	for _, service := range notifications.Services {
+		config := []byte("{}")
+		if service.Config != nil && len(service.Config.Raw) > 0 {
+			config = service.Config.Raw
+		}
+		out, err := yaml.JSONToYAML(config)
+		if err != nil {
+			return nil, err
+		}
+		data[getNotificationsServiceKey(service)] = string(out)
+	}
+
+	return data, nil
+}
+
+// isNotificationsConfigKey returns true if the given argocd-notifications-cm key is managed by the operator.
+func isNotificationsConfigKey(key string) bool {
+	if key == common.ArgoCDKeyNotificationsContext {
+		return true
+	}
+	for _, prefix := range notificationsConfigKeyPrefixes {
+		if strings.HasPrefix(key, prefix+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// reconcileNotificationsConfigMap will ensure that the argocd-notifications-cm ConfigMap matches the triggers,
+// templates and services of the given ArgoCD. Other keys, e.g. subscriptions, are left alone.
+func (r *ReconcileArgoCD) reconcileNotificationsConfigMap(cr *argoprojv1a1.ArgoCD) error {
+	desired, err := getNotificationsConfig(cr)
+	if err != nil {
+		return err
+	}
+
+	cm := newConfigMapWithName(common.ArgoCDNotificationsConfigMapName, cr)
+	if !argoutil.IsObjectFound(r.Client, cr.Namespace, cm.Name, cm) {
+		cm.Data = desired
+		if err := controllerutil.SetControllerReference(cr, cm, r.Scheme); err != nil {
+			return err
+		}
+		return r.Client.Create(context.TODO(), cm)
+	}
+
+	if cm.Data == nil {
+		cm.Data = make(map[string]string)
+	}
+
+	changed := false
+	for key := range cm.Data {
+		if _, ok := desired[key]; !ok && isNotificationsConfigKey(key) {
+			delete(cm.Data, key)
+			changed = true
+		}
+	}
+	for key, value := range desired {
+		if current, ok := cm.Data[key]; !ok || current != value {
+			cm.Data[key] = value
+			changed = true
+		}
+	}
+
+	if changed {
+		return r.Client.Update(context.TODO(), cm)
+	}
+	return nil // ConfigMap exists and nothing to do, move along...
+}
+
+// getNotificationsSecretData will return the argocd-notifications-secret data copied from the Secrets referenced by
+// the notification services of the given ArgoCD. Missing Secrets or keys are skipped.
+func (r *ReconcileArgoCD) getNotificationsSecretData(cr *argoprojv1a1.ArgoCD) map[string][]byte {
+	data := make(map[string][]byte)
+	for _, service := range cr.Spec.Notifications.Services {
+		for _, ref := range service.Secrets {
+			source := &corev1.Secret{}
+			if !argoutil.IsObjectFound(r.Client, cr.Namespace, ref.SecretKeyRef.Name, source) {
+				log.Info(fmt.Sprintf("secret [%s] referenced by notification service [%s] not found", ref.SecretKeyRef.Name, getNotificationsServiceKey(service)))
+				continue
+			}
+			value, ok := source.Data[ref.SecretKeyRef.Key]
+			if !ok {
+				log.Info(fmt.Sprintf("key [%s] not found in secret [%s] referenced by notification service [%s]", ref.SecretKeyRef.Key, ref.SecretKeyRef.Name, getNotificationsServiceKey(service)))
+				continue
+			}
+			data[ref.Key] = value
+		}
+	}
+	return data
+}
+
+// reconcileNotificationsSecret will ensure that the argocd-notifications-secret Secret is present and holds the
+// values referenced by the notification services of the given ArgoCD. Keys that are not managed by the operator are
+// left alone.
+func (r *ReconcileArgoCD) reconcileNotificationsSecret(cr *argoprojv1a1.ArgoCD) error {
+	desired := r.getNotificationsSecretData(cr)
+
+	keys := make([]string, 0, len(desired))
+	for key := range desired {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	secret := argoutil.NewSecretWithName(cr, common.ArgoCDNotificationsSecretName)
+	if !argoutil.IsObjectFound(r.Client, cr.Namespace, secret.Name, secret) {
+		secret.Data = desired
+		if len(keys) > 0 {
+			secret.Annotations = map[string]string{common.ArgoCDManagedKeysAnnotation: strings.Join(keys, ",")}
+		}
+		if err := controllerutil.SetControllerReference(cr, secret, r.Scheme); err != nil {
+			return err
+		}
+		return r.Client.Create(context.TODO(), secret)
+	}
+
+	if secret.Data == nil {
+		secret.Data = make(map[string][]byte)
+	}
+
+	changed := false
+	for _, key := range strings.Split(secret.Annotations[common.ArgoCDManagedKeysAnnotation], ",") {
+		if _, ok := desired[key]; !ok && key != "" {
+			delete(secret.Data, key)
+			changed = true
+		}
+	}
+	for key, value := range desired {
+		if !reflect.DeepEqual(secret.Data[key], value) {
+			secret.Data[key] = value
+			changed = true
+		}
+	}
+
+	if secret.Annotations[common.ArgoCDManagedKeysAnnotation] != strings.Join(keys, ",") {
+		if len(keys) == 0 {
+			delete(secret.Annotations, common.ArgoCDManagedKeysAnnotation)
+		} else {
+			if secret.Annotations == nil {
+				secret.Annotations = make(map[string]string)
+			}
+			secret.Annotations[common.ArgoCDManagedKeysAnnotation] = strings.Join(keys, ",")
+		}
+		changed = true
+	}
+
+	if changed {
+		return r.Client.Update(context.TODO(), secret)
+	}
+	return nil // Secret exists and nothing to do, move along...
+}
+
+func getNotificationsContainerImage(cr *argoprojv1a1.ArgoCD) string {
+	defaultImg, defaultTag := false, false
+
+	img := cr.Spec.Notifications.Image
+	tag := cr.Spec.Notifications.Version
+
+	// If spec is empty, use the defaults
+	if img == "" {
+		img = common.ArgoCDDefaultNotificationsImage
+		defaultImg = true
+	}
+	if tag == "" {
+		tag = common.ArgoCDDefaultNotificationsVersion
+		defaultTag = true
+	}
+
+	// If an env var is specified then use that, but don't override the spec values (if they are present)
+	if e := os.Getenv(common.ArgoCDNotificationsEnvName); e != "" && (defaultTag && defaultImg) {
+		return e
+	}
+	return argoutil.CombineImageTag(img, tag)
+}
+
+// getNotificationsResources will return the ResourceRequirements for the Notifications container.
+func getNotificationsResources(cr *argoprojv1a1.ArgoCD) corev1.ResourceRequirements {
+	resources := corev1.ResourceRequirements{}
+
+	// Allow override of resource requirements from CR
+	if cr.Spec.Notifications.Resources != nil {
+		resources = *cr.Spec.Notifications.Resources
+	}
+
+	return resources
+}
+
+func setNotificationsLabels(obj *metav1.ObjectMeta) {
+	obj.Labels["app.kubernetes.io/name"] = "argocd-notifications-controller"
+	obj.Labels["app.kubernetes.io/part-of"] = "argocd-notifications"
+	obj.Labels["app.kubernetes.io/component"] = "controller"
+}
@@ -0,0 +1,236 @@
+// Copyright 2021 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"gotest.tools/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+func makeTestNotifications() *v1alpha1.ArgoCDNotifications {
+	return &v1alpha1.ArgoCDNotifications{
+		Context: map[string]string{"argocdUrl": "https://argocd.example.com"},
+		Triggers: []v1alpha1.NotificationTrigger{{
+			Name: "on-sync-failed",
+			Conditions: []v1alpha1.NotificationTriggerCondition{{
+				When:    "app.status.operationState.phase in ['Error', 'Failed']",
+				Send:    []string{"app-sync-failed"},
+				OncePer: "app.status.sync.revision",
+			}},
+		}},
+		Templates: []v1alpha1.NotificationTemplate{{
+			Name: "app-sync-failed",
+			NotificationTemplateSpec: v1alpha1.NotificationTemplateSpec{
+				Message: "Application {{.app.metadata.name}} failed to sync.",
+				Email:   &v1alpha1.NotificationEmailTemplate{Subject: "Sync failed"},
+			},
+		}},
+		Services: []v1alpha1.NotificationService{
+			{
+				Type:    "slack",
+				Config:  &runtime.RawExtension{Raw: []byte(`{"token":"$slack-token"}`)},
+				Secrets: []v1alpha1.NotificationServiceSecret{{Key: "slack-token", SecretKeyRef: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "slack"}, Key: "token"}}},
+			},
+			{Type: "webhook", Name: "github", Config: &runtime.RawExtension{Raw: []byte(`{"url":"https://api.github.com"}`)}},
+		},
+	}
+}
+
+func notificationsAssertExpectedLabels(t *testing.T, meta *metav1.ObjectMeta) {
+	assert.Equal(t, meta.Labels["app.kubernetes.io/name"], "argocd-notifications-controller")
+	assert.Equal(t, meta.Labels["app.kubernetes.io/part-of"], "argocd-notifications")
+	assert.Equal(t, meta.Labels["app.kubernetes.io/component"], "controller")
+}
+
+func TestReconcileNotifications_CreateDeployments(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	a.Spec.Notifications = &v1alpha1.ArgoCDNotifications{LogLevel: "debug"}
+
+	r := makeTestReconciler(t, a)
+
+	sa := corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "argocd-notifications-controller"}}
+
+	assert.NilError(t, r.reconcileNotificationsDeployment(a, &sa))
+
+	deployment := &appsv1.Deployment{}
+	assert.NilError(t, r.Client.Get(
+		context.TODO(),
+		types.NamespacedName{
+			Name:      "argocd-notifications-controller",
+			Namespace: a.Namespace,
+		},
+		deployment))
+
+	notificationsAssertExpectedLabels(t, &deployment.ObjectMeta)
+	assert.Equal(t, deployment.Spec.Template.Spec.ServiceAccountName, sa.Name)
+	container := deployment.Spec.Template.Spec.Containers[0]
+	assert.DeepEqual(t, container.Command, []string{"argocd-notifications", "--argocd-repo-server", getRepoServerAddress(a), "--loglevel", "debug"})
+	assert.Equal(t, container.Image, argoutil.CombineImageTag(common.ArgoCDDefaultNotificationsImage, common.ArgoCDDefaultNotificationsVersion))
+
+	// Changes to the spec are rolled out to the existing Deployment.
+	a.Spec.Notifications.Image = "custom/notifications"
+	a.Spec.Notifications.Version = "v2"
+	assert.NilError(t, r.reconcileNotificationsDeployment(a, &sa))
+
+	deployment = &appsv1.Deployment{}
+	assert.NilError(t, r.Client.Get(
+		context.TODO(),
+		types.NamespacedName{
+			Name:      "argocd-notifications-controller",
+			Namespace: a.Namespace,
+		},
+		deployment))
+	assert.Equal(t, deployment.Spec.Template.Spec.Containers[0].Image, "custom/notifications:v2")
+}
+
+func TestReconcileNotifications_Role(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	r := makeTestReconciler(t, a)
+
+	roleRet, err := r.reconcileNotificationsRole(a)
+	assert.NilError(t, err)
+
+	role := &rbacv1.Role{}
+	assert.NilError(t, r.Client.Get(
+		context.TODO(),
+		types.NamespacedName{
+			Name:      "argocd-notifications-controller",
+			Namespace: a.Namespace,
+		},
+		role))
+
+	assert.Equal(t, roleRet.Name, role.Name)
+	notificationsAssertExpectedLabels(t, &role.ObjectMeta)
+
+	expectedResources := []string{
+		"applications",
+		"appprojects",
+		"configmaps",
+		"secrets",
+	}
+
+	foundResources := []string{}
+	for _, rule := range role.Rules {
+		foundResources = append(foundResources, rule.Resources...)
+	}
+	sort.Strings(foundResources)
+
+	assert.DeepEqual(t, expectedResources, foundResources)
+}
+
+func TestReconcileNotifications_ServiceAccountAndRoleBinding(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	r := makeTestReconciler(t, a)
+
+	sa, err := r.reconcileNotificationsServiceAccount(a)
+	assert.NilError(t, err)
+	notificationsAssertExpectedLabels(t, &sa.ObjectMeta)
+
+	role := &rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: "role-name"}}
+	assert.NilError(t, r.reconcileNotificationsRoleBinding(a, role, sa))
+
+	roleBinding := &rbacv1.RoleBinding{}
+	assert.NilError(t, r.Client.Get(
+		context.TODO(),
+		types.NamespacedName{
+			Name:      "argocd-notifications-controller",
+			Namespace: a.Namespace,
+		},
+		roleBinding))
+
+	notificationsAssertExpectedLabels(t, &roleBinding.ObjectMeta)
+	assert.Equal(t, roleBinding.RoleRef.Name, role.Name)
+	assert.Equal(t, roleBinding.Subjects[0].Name, sa.Name)
+}
+
+func TestReconcileNotifications_ConfigMap(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	a.Spec.Notifications = makeTestNotifications()
+	r := makeTestReconciler(t, a)
+
+	assert.NilError(t, r.reconcileNotificationsConfigMap(a))
+
+	cm := &corev1.ConfigMap{}
+	assert.NilError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDNotificationsConfigMapName, Namespace: a.Namespace}, cm))
+	assert.DeepEqual(t, cm.Data, map[string]string{
+		"context":                  "argocdUrl: https://argocd.example.com\n",
+		"trigger.on-sync-failed":   "- oncePer: app.status.sync.revision\n  send:\n  - app-sync-failed\n  when: app.status.operationState.phase in ['Error', 'Failed']\n",
+		"template.app-sync-failed": "email:\n  subject: Sync failed\nmessage: Application {{.app.metadata.name}} failed to sync.\n",
+		"service.slack":            "token: $slack-token\n",
+		"service.webhook.github":   "url: https://api.github.com\n",
+	})
+
+	// Removed triggers are deleted, keys that are not managed by the operator are kept.
+	cm.Data["subscriptions"] = "- recipients: [slack:devops]\n"
+	assert.NilError(t, r.Client.Update(context.TODO(), cm))
+
+	a.Spec.Notifications.Triggers = nil
+	assert.NilError(t, r.reconcileNotificationsConfigMap(a))
+
+	cm = &corev1.ConfigMap{}
+	assert.NilError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDNotificationsConfigMapName, Namespace: a.Namespace}, cm))
+	_, found := cm.Data["trigger.on-sync-failed"]
+	assert.Assert(t, !found)
+	assert.Equal(t, cm.Data["subscriptions"], "- recipients: [slack:devops]\n")
+}
+
+func TestReconcileNotifications_Secret(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	a.Spec.Notifications = makeTestNotifications()
+	slack := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "slack", Namespace: a.Namespace},
+		Data:       map[string][]byte{"token": []byte("xoxb-123")},
+	}
+	r := makeTestReconciler(t, a, slack)
+
+	assert.NilError(t, r.reconcileNotificationsSecret(a))
+
+	secret := &corev1.Secret{}
+	assert.NilError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDNotificationsSecretName, Namespace: a.Namespace}, secret))
+	assert.DeepEqual(t, secret.Data, map[string][]byte{"slack-token": []byte("xoxb-123")})
+	assert.Equal(t, secret.Annotations[common.ArgoCDManagedKeysAnnotation], "slack-token")
+
+	// Removed references are deleted, values that are not managed by the operator are kept.
+	secret.Data["email-password"] = []byte("secret")
+	assert.NilError(t, r.Client.Update(context.TODO(), secret))
+
+	a.Spec.Notifications.Services = a.Spec.Notifications.Services[1:]
+	assert.NilError(t, r.reconcileNotificationsSecret(a))
+
+	secret = &corev1.Secret{}
+	assert.NilError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: common.ArgoCDNotificationsSecretName, Namespace: a.Namespace}, secret))
+	assert.DeepEqual(t, secret.Data, map[string][]byte{"email-password": []byte("secret")})
+	_, found := secret.Annotations[common.ArgoCDManagedKeysAnnotation]
+	assert.Assert(t, !found)
+}
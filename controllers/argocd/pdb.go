@@ -0,0 +1,174 @@
+// Copyright 2021 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"reflect"
+
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	argoprojv1a1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+// podDisruptionBudgetTarget describes a workload protected by a PodDisruptionBudget.
+type podDisruptionBudgetTarget struct {
+	// suffix is the name suffix of both the workload and its PodDisruptionBudget.
+	suffix string
+	// selector is the name label used by the workload to select its pods.
+	selector string
+	spec     *argoprojv1a1.ArgoCDPodDisruptionBudgetSpec
+	// deployed reports whether the workload is managed for the given ArgoCD.
+	deployed bool
+}
+
+// getPodDisruptionBudgetTargets returns the workloads of all Argo CD components that can have a PodDisruptionBudget.
+func getPodDisruptionBudgetTargets(cr *argoprojv1a1.ArgoCD) []podDisruptionBudgetTarget {
+	targets := []podDisruptionBudgetTarget{
+		{suffix: "application-controller", spec: cr.Spec.Controller.PDB, deployed: true},
+		{suffix: "dex-server", spec: cr.Spec.Dex.PDB, deployed: !isDexDisabled()},
+		{suffix: "grafana", spec: cr.Spec.Grafana.PDB, deployed: cr.Spec.Grafana.Enabled},
+		{suffix: "redis", spec: cr.Spec.Redis.PDB, deployed: isRedisDeploymentEnabled(cr)},
+		{suffix: "redis-ha-haproxy", spec: cr.Spec.HA.PDB, deployed: isRedisHAEnabled(cr)},
+		{suffix: "redis-ha-server", selector: "redis-ha", spec: cr.Spec.Redis.PDB, deployed: isRedisHAEnabled(cr)},
+		{suffix: "repo-server", spec: cr.Spec.Repo.PDB, deployed: true},
+		{suffix: "server", spec: cr.Spec.Server.PDB, deployed: true},
+	}
+
+	appSet := podDisruptionBudgetTarget{suffix: "applicationset-controller"}
+	if cr.Spec.ApplicationSet != nil {
+		appSet.spec = cr.Spec.ApplicationSet.PDB
+		appSet.deployed = true
+	}
+
+	notifications := podDisruptionBudgetTarget{suffix: "notifications-controller"}
+	if cr.Spec.Notifications != nil {
+		notifications.spec = cr.Spec.Notifications.PDB
+		notifications.deployed = true
+	}
+
+	return append(targets, appSet, notifications)
+}
+
+// isPodDisruptionBudgetEnabled returns whether a PodDisruptionBudget should be present for the given component spec.
+// PodDisruptionBudgets are enabled by default when HA is enabled.
+func isPodDisruptionBudgetEnabled(cr *argoprojv1a1.ArgoCD, spec *argoprojv1a1.ArgoCDPodDisruptionBudgetSpec) bool {
+	if spec != nil && spec.Enabled != nil {
+		return *spec.Enabled
+	}
+	return cr.Spec.HA.Enabled
+}
+
+// newPodDisruptionBudgetWithSuffix returns a new PodDisruptionBudget for the given ArgoCD using the given suffix.
+func newPodDisruptionBudgetWithSuffix(suffix string, cr *argoprojv1a1.ArgoCD) *policyv1.PodDisruptionBudget {
+	name := nameWithSuffix(suffix, cr)
+
+	lbls := argoutil.LabelsForCluster(cr)
+	lbls[common.ArgoCDKeyName] = name
+
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: cr.Namespace,
+			Labels:    lbls,
+		},
+	}
+}
+
+// getPodDisruptionBudgetSpec returns the PodDisruptionBudget spec for the given target.
+func getPodDisruptionBudgetSpec(target podDisruptionBudgetTarget, cr *argoprojv1a1.ArgoCD) policyv1.PodDisruptionBudgetSpec {
+	selector := target.suffix
+	if target.selector != "" {
+		selector = target.selector
+	}
+
+	spec := policyv1.PodDisruptionBudgetSpec{
+		Selector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				common.ArgoCDKeyName: nameWithSuffix(selector, cr),
+			},
+		},
+	}
+
+	if target.spec != nil {
+		spec.MinAvailable = target.spec.MinAvailable
+		spec.MaxUnavailable = target.spec.MaxUnavailable
+	}
+
+	if spec.MinAvailable == nil && spec.MaxUnavailable == nil {
+		maxUnavailable := intstr.FromInt(common.ArgoCDDefaultPDBMaxUnavailable)
+		spec.MaxUnavailable = &maxUnavailable
+	}
+
+	return spec
+}
+
+// reconcilePodDisruptionBudget will ensure that the PodDisruptionBudget for the given target is present or absent.
+func (r *ReconcileArgoCD) reconcilePodDisruptionBudget(target podDisruptionBudgetTarget, cr *argoprojv1a1.ArgoCD) error {
+	enabled := target.deployed && isPodDisruptionBudgetEnabled(cr, target.spec)
+
+	pdb := newPodDisruptionBudgetWithSuffix(target.suffix, cr)
+	pdb.Spec = getPodDisruptionBudgetSpec(target, cr)
+
+	existing := newPodDisruptionBudgetWithSuffix(target.suffix, cr)
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
+		if !enabled {
+			return r.Client.Delete(context.TODO(), existing) // PodDisruptionBudget found but disabled, delete it.
+		}
+
+		changed := false
+		if !reflect.DeepEqual(existing.Spec.Selector, pdb.Spec.Selector) {
+			existing.Spec.Selector = pdb.Spec.Selector
+			changed = true
+		}
+		if !reflect.DeepEqual(existing.Spec.MinAvailable, pdb.Spec.MinAvailable) {
+			existing.Spec.MinAvailable = pdb.Spec.MinAvailable
+			changed = true
+		}
+		if !reflect.DeepEqual(existing.Spec.MaxUnavailable, pdb.Spec.MaxUnavailable) {
+			existing.Spec.MaxUnavailable = pdb.Spec.MaxUnavailable
+			changed = true
+		}
+
+		if changed {
+			return r.Client.Update(context.TODO(), existing)
+		}
+		return nil // PodDisruptionBudget found with nothing to do, move along...
+	}
+
+	if !enabled {
+		return nil // PodDisruptionBudget not enabled, move along...
+	}
+
+	if err := controllerutil.SetControllerReference(cr, pdb, r.Scheme); err != nil {
+		return err
+	}
+	return r.Client.Create(context.TODO(), pdb)
+}
+
+// reconcilePodDisruptionBudgets will ensure that the PodDisruptionBudgets of all Argo CD components are present or absent.
+func (r *ReconcileArgoCD) reconcilePodDisruptionBudgets(cr *argoprojv1a1.ArgoCD) error {
+	for _, target := range getPodDisruptionBudgetTargets(cr) {
+		if err := r.reconcilePodDisruptionBudget(target, cr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"gotest.tools/assert"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoprojv1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+)
+
+func listTestPodDisruptionBudgets(t *testing.T, r *ReconcileArgoCD) map[string]policyv1.PodDisruptionBudgetSpec {
+	t.Helper()
+	list := &policyv1.PodDisruptionBudgetList{}
+	assert.NilError(t, r.Client.List(context.TODO(), list))
+	pdbs := map[string]policyv1.PodDisruptionBudgetSpec{}
+	for _, pdb := range list.Items {
+		pdbs[pdb.Name] = pdb.Spec
+	}
+	return pdbs
+}
+
+func TestReconcileArgoCD_reconcilePodDisruptionBudgets_HA(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	r := makeTestReconciler(t, a)
+
+	assert.NilError(t, r.reconcilePodDisruptionBudgets(a))
+	assert.Equal(t, len(listTestPodDisruptionBudgets(t, r)), 0)
+
+	// Enabling HA protects all deployed components by default.
+	a.Spec.HA.Enabled = true
+	assert.NilError(t, r.reconcilePodDisruptionBudgets(a))
+
+	pdbs := listTestPodDisruptionBudgets(t, r)
+	assert.Equal(t, len(pdbs), 6)
+	for _, name := range []string{"argocd-application-controller", "argocd-dex-server", "argocd-redis-ha-haproxy", "argocd-redis-ha-server", "argocd-repo-server", "argocd-server"} {
+		spec, ok := pdbs[name]
+		assert.Assert(t, ok, name)
+		assert.Assert(t, spec.MinAvailable == nil)
+		assert.Equal(t, *spec.MaxUnavailable, intstr.FromInt(1))
+	}
+	assert.Equal(t, pdbs["argocd-server"].Selector.MatchLabels["app.kubernetes.io/name"], "argocd-server")
+	assert.Equal(t, pdbs["argocd-redis-ha-server"].Selector.MatchLabels["app.kubernetes.io/name"], "argocd-redis-ha")
+
+	// Disabling HA removes the PodDisruptionBudgets again.
+	a.Spec.HA.Enabled = false
+	assert.NilError(t, r.reconcilePodDisruptionBudgets(a))
+	assert.Equal(t, len(listTestPodDisruptionBudgets(t, r)), 0)
+}
+
+func TestReconcileArgoCD_reconcilePodDisruptionBudgets_component(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	minAvailable := intstr.FromInt(2)
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Server.PDB = &argoprojv1alpha1.ArgoCDPodDisruptionBudgetSpec{
+			Enabled:      boolPtr(true),
+			MinAvailable: &minAvailable,
+		}
+		a.Spec.ApplicationSet = &argoprojv1alpha1.ArgoCDApplicationSet{
+			PDB: &argoprojv1alpha1.ArgoCDPodDisruptionBudgetSpec{Enabled: boolPtr(true)},
+		}
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NilError(t, r.reconcilePodDisruptionBudgets(a))
+
+	pdbs := listTestPodDisruptionBudgets(t, r)
+	assert.Equal(t, len(pdbs), 2)
+	assert.Equal(t, *pdbs["argocd-server"].MinAvailable, intstr.FromInt(2))
+	assert.Assert(t, pdbs["argocd-server"].MaxUnavailable == nil)
+	assert.Equal(t, *pdbs["argocd-applicationset-controller"].MaxUnavailable, intstr.FromInt(1))
+
+	pdb := &policyv1.PodDisruptionBudget{}
+	assert.NilError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: testNamespace}, pdb))
+	assert.Equal(t, pdb.OwnerReferences[0].Name, a.Name)
+
+	// Changes to the budget are applied to the existing PodDisruptionBudget.
+	maxUnavailable := intstr.FromString("50%")
+	a.Spec.Server.PDB.MinAvailable = nil
+	a.Spec.Server.PDB.MaxUnavailable = &maxUnavailable
+	assert.NilError(t, r.reconcilePodDisruptionBudgets(a))
+
+	pdbs = listTestPodDisruptionBudgets(t, r)
+	assert.Assert(t, pdbs["argocd-server"].MinAvailable == nil)
+	assert.Equal(t, *pdbs["argocd-server"].MaxUnavailable, intstr.FromString("50%"))
+
+	// An explicitly disabled budget is removed even when HA is enabled.
+	a.Spec.HA.Enabled = true
+	a.Spec.Server.PDB.Enabled = boolPtr(false)
+	a.Spec.ApplicationSet = nil
+	assert.NilError(t, r.reconcilePodDisruptionBudgets(a))
+
+	pdbs = listTestPodDisruptionBudgets(t, r)
+	_, found := pdbs["argocd-server"]
+	assert.Assert(t, !found)
+	_, found = pdbs["argocd-applicationset-controller"]
+	assert.Assert(t, !found)
+}
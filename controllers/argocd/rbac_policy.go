@@ -0,0 +1,138 @@
+// Copyright 2021 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/argoproj/argo-cd/util/rbac"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	argoprojv1a1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+)
+
+const (
+	// rbacRolePrefix is the prefix of Argo CD role subjects in the RBAC policy.
+	rbacRolePrefix = "role:"
+
+	// rbacPolicyTypePolicy is the policy type of a policy rule line: p, subject, resource, action, object, effect
+	rbacPolicyTypePolicy = "p"
+
+	// rbacPolicyTypeGrouping is the policy type of a role binding line: g, subject, role
+	rbacPolicyTypeGrouping = "g"
+
+	// rbacEffectAllow is the policy rule effect that grants a permission.
+	rbacEffectAllow = "allow"
+
+	// rbacEffectDeny is the policy rule effect that denies a permission.
+	rbacEffectDeny = "deny"
+)
+
+// rbacEffects are the policy rule effects supported by the Argo CD casbin model.
+var rbacEffects = []string{rbacEffectAllow, rbacEffectDeny}
+
+// hasRBACRoles returns true if the given ArgoCD declares typed RBAC roles or bindings.
+func hasRBACRoles(cr *argoprojv1a1.ArgoCD) bool {
+	return len(cr.Spec.RBAC.Roles) > 0 || len(cr.Spec.RBAC.Bindings) > 0
+}
+
+// validateRBACRoles will return the list of problems found in the typed RBAC roles and bindings for the given ArgoCD.
+func validateRBACRoles(cr *argoprojv1a1.ArgoCD) field.ErrorList {
+	allErrs := field.ErrorList{}
+	rbacPath := field.NewPath("spec", "rbac")
+
+	roles := map[string]bool{}
+	for i, role := range cr.Spec.RBAC.Roles {
+		path := rbacPath.Child("roles").Index(i)
+		allErrs = append(allErrs, validateRBACPolicyValue(path.Child("name"), role.Name)...)
+		if roles[role.Name] {
+			allErrs = append(allErrs, field.Duplicate(path.Child("name"), role.Name))
+		}
+		roles[role.Name] = true
+
+		for j, perm := range role.Permissions {
+			permPath := path.Child("permissions").Index(j)
+			allErrs = append(allErrs, validateRBACPolicyValue(permPath.Child("resource"), perm.Resource)...)
+			allErrs = append(allErrs, validateRBACPolicyValue(permPath.Child("action"), perm.Action)...)
+			if perm.Object != "" {
+				allErrs = append(allErrs, validateRBACPolicyValue(permPath.Child("object"), perm.Object)...)
+			}
+			if perm.Effect != "" && !containsString(rbacEffects, perm.Effect) {
+				allErrs = append(allErrs, field.NotSupported(permPath.Child("effect"), perm.Effect, rbacEffects))
+			}
+		}
+	}
+
+	for i, binding := range cr.Spec.RBAC.Bindings {
+		path := rbacPath.Child("bindings").Index(i)
+		allErrs = append(allErrs, validateRBACPolicyValue(path.Child("subject"), binding.Subject)...)
+		allErrs = append(allErrs, validateRBACPolicyValue(path.Child("role"), binding.Role)...)
+	}
+
+	return allErrs
+}
+
+// validateRBACPolicyValue will return an error if the given value can not be rendered as a single policy CSV field.
+func validateRBACPolicyValue(path *field.Path, value string) field.ErrorList {
+	if strings.TrimSpace(value) == "" {
+		return field.ErrorList{field.Required(path, "value is required")}
+	}
+	if strings.ContainsAny(value, ",\"\n") || strings.TrimSpace(value) != value {
+		return field.ErrorList{field.Invalid(path, value, "must not contain commas, quotes, line breaks or surrounding whitespace")}
+	}
+	return nil
+}
+
+// getRBACRolePolicy will return the policy CSV lines rendered from the typed RBAC roles and bindings for the given ArgoCD.
+func getRBACRolePolicy(cr *argoprojv1a1.ArgoCD) string {
+	lines := make([]string, 0)
+	for _, role := range cr.Spec.RBAC.Roles {
+		for _, perm := range role.Permissions {
+			object := perm.Object
+			if object == "" {
+				object = "*"
+			}
+			effect := perm.Effect
+			if effect == "" {
+				effect = rbacEffectAllow
+			}
+			lines = append(lines, strings.Join([]string{rbacPolicyTypePolicy, rbacRolePrefix + role.Name, perm.Resource, perm.Action, object, effect}, ", "))
+		}
+	}
+	for _, binding := range cr.Spec.RBAC.Bindings {
+		lines = append(lines, strings.Join([]string{rbacPolicyTypeGrouping, binding.Subject, rbacRolePrefix + strings.TrimPrefix(binding.Role, rbacRolePrefix)}, ", "))
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// getValidRBACPolicy will return the RBAC policy for the given ArgoCD and update the RBACPolicyValid condition.
+// The returned bool is false when the policy is invalid and must not be written to the RBAC ConfigMap.
+func (r *ReconcileArgoCD) getValidRBACPolicy(cr *argoprojv1a1.ArgoCD) (string, bool, error) {
+	if errs := validateRBACRoles(cr); len(errs) > 0 {
+		log.Info(fmt.Sprintf("invalid RBAC roles for ArgoCD %s in namespace %s: %s", cr.Name, cr.Namespace, errs.ToAggregate()))
+		return "", false, r.reconcileStatusRBACPolicy(cr, errs.ToAggregate())
+	}
+
+	policy := getRBACPolicy(cr)
+	if err := rbac.ValidatePolicy(policy); err != nil {
+		log.Info(fmt.Sprintf("invalid RBAC policy for ArgoCD %s in namespace %s: %s", cr.Name, cr.Namespace, err))
+		return "", false, r.reconcileStatusRBACPolicy(cr, err)
+	}
+	return policy, true, r.reconcileStatusRBACPolicy(cr, nil)
+}
@@ -0,0 +1,257 @@
+// Copyright 2021 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/yaml"
+
+	argoprojv1a1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
+)
+
+const (
+	// cmpServerPath is the path the argocd-cmp-server binary is copied to, shared by the repo server and the plugin sidecars.
+	cmpServerPath = "/var/run/argocd"
+
+	// cmpPluginsPath is the path of the plugin sockets, shared by the repo server and the plugin sidecars.
+	cmpPluginsPath = "/home/argocd/cmp-server/plugins"
+
+	// cmpConfigPath is the path of the plugin definition in a plugin sidecar.
+	cmpConfigPath = "/home/argocd/cmp-server/config/plugin.yaml"
+)
+
+// cmpPluginManifest is the plugin.yaml definition read by the argocd-cmp-server.
+type cmpPluginManifest struct {
+	APIVersion string                                  `json:"apiVersion"`
+	Kind       string                                  `json:"kind"`
+	Metadata   cmpPluginMetadata                       `json:"metadata"`
+	Spec       argoprojv1a1.ArgoCDRepoPluginDefinition `json:"spec"`
+}
+
+// cmpPluginMetadata is the metadata of a plugin.yaml definition.
+type cmpPluginMetadata struct {
+	Name string `json:"name"`
+}
+
+// getCMPPluginConfigMapName will return the name of the ConfigMap holding the definition of the given plugin.
+func getCMPPluginConfigMapName(cr *argoprojv1a1.ArgoCD, plugin argoprojv1a1.ArgoCDRepoPluginSpec) string {
+	return fmt.Sprintf("%s-cmp-%s", cr.Name, plugin.Name)
+}
+
+// getCMPPluginConfig will return the plugin.yaml definition for the given plugin.
+func getCMPPluginConfig(plugin argoprojv1a1.ArgoCDRepoPluginSpec) (string, error) {
+	manifest := cmpPluginManifest{
+		APIVersion: "argoproj.io/v1alpha1",
+		Kind:       "ConfigManagementPlugin",
+		Metadata:   cmpPluginMetadata{Name: plugin.Name},
+		Spec:       plugin.Spec,
+	}
+	out, err := yaml.Marshal(manifest)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// getCMPPluginsChecksum will return a checksum of the definitions of all plugins, or an empty string if there are none.
+func getCMPPluginsChecksum(cr *argoprojv1a1.ArgoCD) (string, error) {
+	if len(cr.Spec.Repo.Plugins) == 0 {
+		return "", nil
+	}
+	hash := sha256.New()
+	for _, plugin := range cr.Spec.Repo.Plugins {
+		config, err := getCMPPluginConfig(plugin)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(hash, "%s\n%s\n", plugin.Name, config)
+	}
+	return fmt.Sprintf("%x", hash.Sum(nil)), nil
+}
+
+// getCMPCopyUtilContainer will return the init container copying the argocd-cmp-server binary into the shared volume.
+func getCMPCopyUtilContainer(cr *argoprojv1a1.ArgoCD) corev1.Container {
+	return corev1.Container{
+		Command: []string{
+			"cp",
+			"-n",
+			"/usr/local/bin/argocd",
+			cmpServerPath + "/argocd-cmp-server",
+		},
+		Image:           getRepoServerContainerImage(cr),
+		ImagePullPolicy: corev1.PullAlways,
+		Name:            "copyutil",
+		Resources:       getArgoRepoResources(cr),
+		VolumeMounts: []corev1.VolumeMount{{
+			Name:      "var-files",
+			MountPath: cmpServerPath,
+		}},
+	}
+}
+
+// getCMPPluginContainers will return the sidecar containers for the plugins of the given ArgoCD.
+func getCMPPluginContainers(cr *argoprojv1a1.ArgoCD) []corev1.Container {
+	containers := []corev1.Container{}
+	for _, plugin := range cr.Spec.Repo.Plugins {
+		resources := corev1.ResourceRequirements{}
+		if plugin.Resources != nil {
+			resources = *plugin.Resources
+		}
+		runAsUser := int64(common.ArgoCDDefaultCMPServerUser)
+
+		containers = append(containers, corev1.Container{
+			Command:   []string{cmpServerPath + "/argocd-cmp-server"},
+			Image:     argoutil.CombineImageTag(plugin.Image, plugin.Version),
+			Name:      plugin.Name,
+			Resources: resources,
+			SecurityContext: &corev1.SecurityContext{
+				RunAsNonRoot: boolPtr(true),
+				RunAsUser:    &runAsUser,
+			},
+			VolumeMounts: []corev1.VolumeMount{
+				{
+					Name:      "var-files",
+					MountPath: cmpServerPath,
+				},
+				{
+					Name:      "plugins",
+					MountPath: cmpPluginsPath,
+				},
+				{
+					Name:      fmt.Sprintf("cmp-%s", plugin.Name),
+					MountPath: cmpConfigPath,
+					SubPath:   common.ArgoCDKeyCMPPluginConfig,
+				},
+				{
+					Name:      fmt.Sprintf("cmp-%s-tmp", plugin.Name),
+					MountPath: "/tmp",
+				},
+			},
+		})
+	}
+	return containers
+}
+
+// getCMPPluginVolumes will return the volumes needed by the plugin sidecars of the given ArgoCD.
+func getCMPPluginVolumes(cr *argoprojv1a1.ArgoCD) []corev1.Volume {
+	volumes := []corev1.Volume{
+		{
+			Name: "var-files",
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		},
+		{
+			Name: "plugins",
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		},
+	}
+	for _, plugin := range cr.Spec.Repo.Plugins {
+		volumes = append(volumes, corev1.Volume{
+			Name: fmt.Sprintf("cmp-%s", plugin.Name),
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: getCMPPluginConfigMapName(cr, plugin),
+					},
+				},
+			},
+		}, corev1.Volume{
+			Name: fmt.Sprintf("cmp-%s-tmp", plugin.Name),
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		})
+	}
+	return volumes
+}
+
+// getCMPRepoServerVolumeMounts will return the volume mounts the repo server needs to reach the plugin sidecars.
+func getCMPRepoServerVolumeMounts() []corev1.VolumeMount {
+	return []corev1.VolumeMount{
+		{
+			Name:      "var-files",
+			MountPath: cmpServerPath,
+		},
+		{
+			Name:      "plugins",
+			MountPath: cmpPluginsPath,
+		},
+	}
+}
+
+// reconcileCMPPluginConfigMaps will ensure a ConfigMap holding the definition is present for each plugin,
+// and remove the ConfigMaps of the plugins that are no longer defined.
+func (r *ReconcileArgoCD) reconcileCMPPluginConfigMaps(cr *argoprojv1a1.ArgoCD) error {
+	desired := map[string]bool{}
+	for _, plugin := range cr.Spec.Repo.Plugins {
+		config, err := getCMPPluginConfig(plugin)
+		if err != nil {
+			return err
+		}
+
+		cm := newConfigMapWithName(getCMPPluginConfigMapName(cr, plugin), cr)
+		desired[cm.Name] = true
+		if argoutil.IsObjectFound(r.Client, cr.Namespace, cm.Name, cm) {
+			if cm.Data[common.ArgoCDKeyCMPPluginConfig] != config {
+				if cm.Data == nil {
+					cm.Data = map[string]string{}
+				}
+				cm.Data[common.ArgoCDKeyCMPPluginConfig] = config
+				if err := r.Client.Update(context.TODO(), cm); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		cm.Labels[common.ArgoCDCMPPluginLabel] = plugin.Name
+		cm.Data = map[string]string{common.ArgoCDKeyCMPPluginConfig: config}
+		if err := controllerutil.SetControllerReference(cr, cm, r.Scheme); err != nil {
+			return err
+		}
+		if err := r.Client.Create(context.TODO(), cm); err != nil {
+			return err
+		}
+	}
+
+	cms := &corev1.ConfigMapList{}
+	if err := r.Client.List(context.TODO(), cms, client.InNamespace(cr.Namespace),
+		client.MatchingLabels{common.ArgoCDKeyManagedBy: cr.Name}, client.HasLabels{common.ArgoCDCMPPluginLabel}); err != nil {
+		return err
+	}
+	for i := range cms.Items {
+		cm := &cms.Items[i]
+		if desired[cm.Name] || !metav1.IsControlledBy(cm, cr) {
+			continue
+		}
+		log.Info(fmt.Sprintf("deleting configmap [%s] of removed plugin [%s]", cm.Name, cm.Labels[common.ArgoCDCMPPluginLabel]))
+		if err := r.Client.Delete(context.TODO(), cm); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"gotest.tools/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoprojv1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func withRepoPlugins(plugins ...argoprojv1alpha1.ArgoCDRepoPluginSpec) argoCDOpt {
+	return func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Repo.Plugins = plugins
+	}
+}
+
+func makeTestRepoPlugin(name string) argoprojv1alpha1.ArgoCDRepoPluginSpec {
+	return argoprojv1alpha1.ArgoCDRepoPluginSpec{
+		Name:    name,
+		Image:   "example.com/" + name,
+		Version: "v1.0.0",
+		Spec: argoprojv1alpha1.ArgoCDRepoPluginDefinition{
+			Generate: argoprojv1alpha1.ArgoCDRepoPluginCommand{Command: []string{name, "generate"}},
+			Discover: &argoprojv1alpha1.ArgoCDRepoPluginDiscover{FileName: "./" + name + ".yaml"},
+		},
+	}
+}
+
+func TestReconcileArgoCD_reconcileCMPPluginConfigMaps(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(withRepoPlugins(makeTestRepoPlugin("sops"), makeTestRepoPlugin("tanka")))
+	r := makeTestReconciler(t, a)
+
+	assert.NilError(t, r.reconcileCMPPluginConfigMaps(a))
+
+	cm := &corev1.ConfigMap{}
+	assert.NilError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-cmp-sops", Namespace: testNamespace}, cm))
+	assert.Equal(t, cm.Labels[common.ArgoCDCMPPluginLabel], "sops")
+	assert.Equal(t, cm.Data[common.ArgoCDKeyCMPPluginConfig], `apiVersion: argoproj.io/v1alpha1
+kind: ConfigManagementPlugin
+metadata:
+  name: sops
+spec:
+  discover:
+    fileName: ./sops.yaml
+  generate:
+    command:
+    - sops
+    - generate
+`)
+
+	// Changes to a plugin are rendered, removed plugins are deleted.
+	a.Spec.Repo.Plugins = a.Spec.Repo.Plugins[:1]
+	a.Spec.Repo.Plugins[0].Spec.LockRepo = true
+	assert.NilError(t, r.reconcileCMPPluginConfigMaps(a))
+
+	cm = &corev1.ConfigMap{}
+	assert.NilError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-cmp-sops", Namespace: testNamespace}, cm))
+	config, err := getCMPPluginConfig(a.Spec.Repo.Plugins[0])
+	assert.NilError(t, err)
+	assert.Equal(t, cm.Data[common.ArgoCDKeyCMPPluginConfig], config)
+
+	err = r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-cmp-tanka", Namespace: testNamespace}, &corev1.ConfigMap{})
+	assert.Assert(t, apierrors.IsNotFound(err))
+}
+
+func TestReconcileArgoCD_reconcileRepoDeployment_plugins(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(withRepoPlugins(makeTestRepoPlugin("sops")))
+	r := makeTestReconciler(t, a)
+
+	assert.NilError(t, r.reconcileRepoDeployment(a))
+
+	deployment := &appsv1.Deployment{}
+	assert.NilError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, deployment))
+
+	podSpec := deployment.Spec.Template.Spec
+	assert.Equal(t, len(podSpec.InitContainers), 1)
+	assert.DeepEqual(t, podSpec.InitContainers[0].Command, []string{"cp", "-n", "/usr/local/bin/argocd", "/var/run/argocd/argocd-cmp-server"})
+	assert.Equal(t, podSpec.InitContainers[0].Image, getRepoServerContainerImage(a))
+
+	assert.Equal(t, len(podSpec.Containers), 2)
+	assert.DeepEqual(t, podSpec.Containers[0].VolumeMounts, append(repoServerDefaultVolumeMounts(), getCMPRepoServerVolumeMounts()...))
+	sidecar := podSpec.Containers[1]
+	assert.Equal(t, sidecar.Name, "sops")
+	assert.Equal(t, sidecar.Image, "example.com/sops:v1.0.0")
+	assert.DeepEqual(t, sidecar.Command, []string{"/var/run/argocd/argocd-cmp-server"})
+	assert.Equal(t, *sidecar.SecurityContext.RunAsUser, int64(999))
+	assert.DeepEqual(t, sidecar.VolumeMounts, []corev1.VolumeMount{
+		{Name: "var-files", MountPath: "/var/run/argocd"},
+		{Name: "plugins", MountPath: "/home/argocd/cmp-server/plugins"},
+		{Name: "cmp-sops", MountPath: "/home/argocd/cmp-server/config/plugin.yaml", SubPath: "plugin.yaml"},
+		{Name: "cmp-sops-tmp", MountPath: "/tmp"},
+	})
+	assert.DeepEqual(t, podSpec.Volumes, append(repoServerDefaultVolumes(), getCMPPluginVolumes(a)...))
+
+	// A change of the plugin definition rolls out the repo server.
+	checksum := deployment.Spec.Template.Annotations[common.ArgoCDCMPPluginsChecksumAnnotation]
+	assert.Assert(t, checksum != "")
+	a.Spec.Repo.Plugins[0].Spec.AllowConcurrency = true
+	assert.NilError(t, r.reconcileRepoDeployment(a))
+
+	deployment = &appsv1.Deployment{}
+	assert.NilError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, deployment))
+	assert.Assert(t, deployment.Spec.Template.Annotations[common.ArgoCDCMPPluginsChecksumAnnotation] != checksum)
+
+	// Removing the plugins removes the sidecars and shared volumes.
+	a.Spec.Repo.Plugins = nil
+	assert.NilError(t, r.reconcileRepoDeployment(a))
+
+	deployment = &appsv1.Deployment{}
+	assert.NilError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-repo-server", Namespace: testNamespace}, deployment))
+	podSpec = deployment.Spec.Template.Spec
+	assert.Equal(t, len(podSpec.InitContainers), 0)
+	assert.Equal(t, len(podSpec.Containers), 1)
+	assert.DeepEqual(t, podSpec.Volumes, repoServerDefaultVolumes())
+	_, found := deployment.Spec.Template.Annotations[common.ArgoCDCMPPluginsChecksumAnnotation]
+	assert.Assert(t, !found)
+}
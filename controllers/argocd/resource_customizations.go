@@ -0,0 +1,228 @@
+// Copyright 2021 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	argoprojv1a1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+// resourceActions is the argocd-cm representation of the actions for a resource group/kind.
+type resourceActions struct {
+	DiscoveryLua string                     `yaml:"discovery.lua,omitempty"`
+	Definitions  []resourceActionDefinition `yaml:"definitions,omitempty"`
+}
+
+// resourceActionDefinition is the argocd-cm representation of a single resource action.
+type resourceActionDefinition struct {
+	Name      string `yaml:"name"`
+	ActionLua string `yaml:"action.lua"`
+}
+
+// resourceIgnoreDifferences is the argocd-cm representation of the ignored differences for a resource group/kind.
+type resourceIgnoreDifferences struct {
+	JSONPointers          []string `yaml:"jsonPointers,omitempty"`
+	JQPathExpressions     []string `yaml:"jqPathExpressions,omitempty"`
+	ManagedFieldsManagers []string `yaml:"managedFieldsManagers,omitempty"`
+}
+
+// knownTypeField is the argocd-cm representation of a known type field for a resource group/kind.
+type knownTypeField struct {
+	Field string `yaml:"field"`
+	Type  string `yaml:"type"`
+}
+
+// resourceCustomizationKeyPrefixes are the prefixes of the per group/kind resource customization keys in argocd-cm.
+var resourceCustomizationKeyPrefixes = []string{
+	common.ArgoCDKeyResourceCustomizationsActions,
+	common.ArgoCDKeyResourceCustomizationsHealth,
+	common.ArgoCDKeyResourceCustomizationsIgnoreDifferences,
+	common.ArgoCDKeyResourceCustomizationsKnownTypeFields,
+	common.ArgoCDKeyResourceCustomizationsUseOpenLibs,
+}
+
+// validateResourceCustomizations will return the list of problems found in the resource customizations for the
+// given ArgoCD.
+func validateResourceCustomizations(cr *argoprojv1a1.ArgoCD) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if cr.Spec.ResourceCustomizations != "" {
+		legacy := map[string]interface{}{}
+		if err := yaml.Unmarshal([]byte(cr.Spec.ResourceCustomizations), &legacy); err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "resourceCustomizations"), "", err.Error()))
+		}
+	}
+
+	seen := map[string]bool{}
+	for i, override := range cr.Spec.ResourceOverrides {
+		path := field.NewPath("spec", "resourceOverrides").Index(i)
+
+		if override.Kind == "" {
+			allErrs = append(allErrs, field.Required(path.Child("kind"), "kind is required"))
+		}
+
+		key := resourceGroupKindKey(override)
+		if seen[key] {
+			allErrs = append(allErrs, field.Duplicate(path, key))
+		}
+		seen[key] = true
+
+		if override.Health != nil && strings.TrimSpace(override.Health.Lua) == "" {
+			allErrs = append(allErrs, field.Required(path.Child("health", "lua"), "health check script is required"))
+		}
+
+		if override.Actions != nil {
+			actions := map[string]bool{}
+			for j, action := range override.Actions.Definitions {
+				actionPath := path.Child("actions", "definitions").Index(j)
+				if action.Name == "" {
+					allErrs = append(allErrs, field.Required(actionPath.Child("name"), "action name is required"))
+				} else if actions[action.Name] {
+					allErrs = append(allErrs, field.Duplicate(actionPath.Child("name"), action.Name))
+				}
+				actions[action.Name] = true
+
+				if strings.TrimSpace(action.ActionLua) == "" {
+					allErrs = append(allErrs, field.Required(actionPath.Child("actionLua"), "action script is required"))
+				}
+			}
+		}
+
+		for j, ktf := range override.KnownTypeFields {
+			ktfPath := path.Child("knownTypeFields").Index(j)
+			if ktf.Field == "" {
+				allErrs = append(allErrs, field.Required(ktfPath.Child("field"), "field is required"))
+			}
+			if ktf.Type == "" {
+				allErrs = append(allErrs, field.Required(ktfPath.Child("type"), "type is required"))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// resourceGroupKindKey will return the argocd-cm key suffix for the group/kind of the given resource override.
+func resourceGroupKindKey(override argoprojv1a1.ResourceOverride) string {
+	if override.Group == "" {
+		return override.Kind
+	}
+	return fmt.Sprintf("%s_%s", override.Group, override.Kind)
+}
+
+// getResourceCustomizationKeys will return the per group/kind resource customization keys for the given ArgoCD.
+func getResourceCustomizationKeys(cr *argoprojv1a1.ArgoCD) (map[string]string, error) {
+	data := make(map[string]string)
+	for _, override := range cr.Spec.ResourceOverrides {
+		key := resourceGroupKindKey(override)
+
+		if override.Health != nil {
+			data[common.ArgoCDKeyResourceCustomizationsHealth+"."+key] = override.Health.Lua
+			if override.Health.UseOpenLibs {
+				data[common.ArgoCDKeyResourceCustomizationsUseOpenLibs+"."+key] = "true"
+			}
+		}
+
+		if override.Actions != nil {
+			actions := resourceActions{DiscoveryLua: override.Actions.DiscoveryLua}
+			for _, d := range override.Actions.Definitions {
+				actions.Definitions = append(actions.Definitions, resourceActionDefinition{Name: d.Name, ActionLua: d.ActionLua})
+			}
+			out, err := yaml.Marshal(actions)
+			if err != nil {
+				return nil, err
+			}
+			data[common.ArgoCDKeyResourceCustomizationsActions+"."+key] = string(out)
+		}
+
+		if override.IgnoreDifferences != nil {
+			out, err := yaml.Marshal(resourceIgnoreDifferences{
+				JSONPointers:          override.IgnoreDifferences.JSONPointers,
+				JQPathExpressions:     override.IgnoreDifferences.JQPathExpressions,
+				ManagedFieldsManagers: override.IgnoreDifferences.ManagedFieldsManagers,
+			})
+			if err != nil {
+				return nil, err
+			}
+			data[common.ArgoCDKeyResourceCustomizationsIgnoreDifferences+"."+key] = string(out)
+		}
+
+		if len(override.KnownTypeFields) > 0 {
+			fields := make([]knownTypeField, 0, len(override.KnownTypeFields))
+			for _, ktf := range override.KnownTypeFields {
+				fields = append(fields, knownTypeField{Field: ktf.Field, Type: ktf.Type})
+			}
+			out, err := yaml.Marshal(fields)
+			if err != nil {
+				return nil, err
+			}
+			data[common.ArgoCDKeyResourceCustomizationsKnownTypeFields+"."+key] = string(out)
+		}
+	}
+	return data, nil
+}
+
+// isResourceCustomizationKey returns true if the given argocd-cm key holds a per group/kind resource customization.
+func isResourceCustomizationKey(key string) bool {
+	for _, prefix := range resourceCustomizationKeyPrefixes {
+		if strings.HasPrefix(key, prefix+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// reconcileResourceCustomizations will ensure that the resource customizations in the given argocd-cm ConfigMap match
+// the given ArgoCD. Invalid customizations are reported in the ResourceCustomizationsValid condition and leave the
+// ConfigMap untouched. Returns true if the ConfigMap data was changed.
+func (r *ReconcileArgoCD) reconcileResourceCustomizations(cm *corev1.ConfigMap, cr *argoprojv1a1.ArgoCD) (bool, error) {
+	if errs := validateResourceCustomizations(cr); len(errs) > 0 {
+		log.Info(fmt.Sprintf("invalid resource customizations for ArgoCD %s in namespace %s: %s", cr.Name, cr.Namespace, errs.ToAggregate()))
+		return false, r.reconcileStatusResourceCustomizations(cr, errs.ToAggregate())
+	}
+
+	desired, err := getResourceCustomizationKeys(cr)
+	if err != nil {
+		return false, r.reconcileStatusResourceCustomizations(cr, err)
+	}
+	if c := getResourceCustomizations(cr); c != "" {
+		desired[common.ArgoCDKeyResourceCustomizations] = c
+	}
+
+	changed := false
+	for key := range cm.Data {
+		if key == common.ArgoCDKeyResourceCustomizations || isResourceCustomizationKey(key) {
+			if _, ok := desired[key]; !ok {
+				delete(cm.Data, key)
+				changed = true
+			}
+		}
+	}
+	for key, value := range desired {
+		if cm.Data[key] != value {
+			cm.Data[key] = value
+			changed = true
+		}
+	}
+
+	return changed, r.reconcileStatusResourceCustomizations(cr, nil)
+}
@@ -75,6 +75,10 @@ func newRouteWithSuffix(suffix string, cr *argoprojv1a1.ArgoCD) *routev1.Route {
 
 // reconcileRoutes will ensure that all ArgoCD Routes are present.
 func (r *ReconcileArgoCD) reconcileRoutes(cr *argoprojv1a1.ArgoCD) error {
+	if err := r.reconcileApplicationSetWebhookRoute(cr); err != nil {
+		return err
+	}
+
 	if err := r.reconcileGrafanaRoute(cr); err != nil {
 		return err
 	}
@@ -89,6 +93,80 @@ func (r *ReconcileArgoCD) reconcileRoutes(cr *argoprojv1a1.ArgoCD) error {
 	return nil
 }
 
+// reconcileApplicationSetWebhookRoute will ensure that the ApplicationSet controller webhook server Route is present.
+func (r *ReconcileArgoCD) reconcileApplicationSetWebhookRoute(cr *argoprojv1a1.ArgoCD) error {
+	enabled := cr.Spec.ApplicationSet != nil && cr.Spec.ApplicationSet.WebhookServer.Route.Enabled
+
+	route := newRouteWithSuffix("applicationset-controller", cr)
+	found := argoutil.IsObjectFound(r.Client, cr.Namespace, route.Name, route)
+	if found {
+		if !enabled {
+			// Route exists but enabled flag has been set to false, delete the Route
+			return r.Client.Delete(context.TODO(), route)
+		}
+	}
+
+	if !enabled {
+		return nil // ApplicationSet controller or Route not enabled, move along...
+	}
+
+	webhookServer := cr.Spec.ApplicationSet.WebhookServer
+
+	// Allow override of the Annotations for the Route.
+	if len(webhookServer.Route.Annotations) > 0 {
+		route.Annotations = webhookServer.Route.Annotations
+	}
+
+	// Allow override of the Labels for the Route.
+	if len(webhookServer.Route.Labels) > 0 {
+		labels := route.Labels
+		for key, val := range webhookServer.Route.Labels {
+			labels[key] = val
+		}
+		route.Labels = labels
+	}
+
+	// Allow override of the Host for the Route.
+	if len(webhookServer.Host) > 0 {
+		route.Spec.Host = webhookServer.Host
+	}
+
+	// Allow override of the Path for the Route
+	if len(webhookServer.Route.Path) > 0 {
+		route.Spec.Path = webhookServer.Route.Path
+	}
+
+	// The webhook server does not serve TLS, terminate it at the router.
+	route.Spec.Port = &routev1.RoutePort{
+		TargetPort: intstr.FromString("webhook"),
+	}
+	route.Spec.TLS = &routev1.TLSConfig{
+		InsecureEdgeTerminationPolicy: routev1.InsecureEdgeTerminationPolicyRedirect,
+		Termination:                   routev1.TLSTerminationEdge,
+	}
+
+	// Allow override of TLS options for the Route
+	if webhookServer.Route.TLS != nil {
+		route.Spec.TLS = webhookServer.Route.TLS
+	}
+
+	route.Spec.To.Kind = "Service"
+	route.Spec.To.Name = nameWithSuffix("applicationset-controller", cr)
+
+	// Allow override of the WildcardPolicy for the Route
+	if webhookServer.Route.WildcardPolicy != nil && len(*webhookServer.Route.WildcardPolicy) > 0 {
+		route.Spec.WildcardPolicy = *webhookServer.Route.WildcardPolicy
+	}
+
+	if err := controllerutil.SetControllerReference(cr, route, r.Scheme); err != nil {
+		return err
+	}
+	if !found {
+		return r.Client.Create(context.TODO(), route)
+	}
+	return r.Client.Update(context.TODO(), route)
+}
+
 // reconcileGrafanaRoute will ensure that the ArgoCD Grafana Route is present.
 func (r *ReconcileArgoCD) reconcileGrafanaRoute(cr *argoprojv1a1.ArgoCD) error {
 	route := newRouteWithSuffix("grafana", cr)
@@ -22,6 +22,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"reflect"
 	"sort"
 	"strings"
 	"time"
@@ -35,6 +36,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -149,6 +151,75 @@ func newCertificateSecret(suffix string, caCert *x509.Certificate, caKey *rsa.Pr
 	return secret, nil
 }
 
+// newRedisTLSSecret creates a new argocd-operator-redis-tls secret for the given ArgoCD, signed by the given CA.
+// The certificate is valid for the Redis Services with and without HA, so that it does not need to be re-issued
+// when HA is toggled.
+func newRedisTLSSecret(caCert *x509.Certificate, caKey *rsa.PrivateKey, cr *argoprojv1a1.ArgoCD) (*corev1.Secret, error) {
+	secret := argoutil.NewSecretWithName(cr, common.ArgoCDRedisServerTLSSecretName)
+	secret.Type = corev1.SecretTypeTLS
+
+	key, err := argoutil.NewPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &tlsutil.CertConfig{
+		CertName:     secret.Name,
+		CertType:     tlsutil.ClientAndServingCert,
+		CommonName:   nameWithSuffix(common.ArgoCDDefaultRedisSuffix, cr),
+		Organization: []string{cr.ObjectMeta.Namespace},
+	}
+
+	dnsNames := []string{}
+	for _, service := range []string{nameWithSuffix(common.ArgoCDDefaultRedisSuffix, cr), nameWithSuffix("redis-ha-haproxy", cr), nameWithSuffix("redis-ha", cr)} {
+		dnsNames = append(dnsNames, getServiceDNSNames(service, cr)...)
+	}
+
+	cert, err := argoutil.NewSignedCertificate(cfg, dnsNames, key, caCert, caKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// Redis requires the CA to verify its peers, the Argo CD components use it to verify Redis.
+	secret.Data = map[string][]byte{
+		corev1.TLSCertKey:              argoutil.EncodeCertificatePEM(cert),
+		corev1.TLSPrivateKeyKey:        argoutil.EncodePrivateKeyPEM(key),
+		corev1.ServiceAccountRootCAKey: argoutil.EncodeCertificatePEM(caCert),
+	}
+
+	return secret, nil
+}
+
+// newIngressTLSSecret returns a new TLS Secret with the given name for the given Ingress hosts, signed by the given CA.
+func newIngressTLSSecret(name string, hosts []string, caCert *x509.Certificate, caKey *rsa.PrivateKey, cr *argoprojv1a1.ArgoCD) (*corev1.Secret, error) {
+	secret := argoutil.NewSecretWithName(cr, name)
+	secret.Type = corev1.SecretTypeTLS
+
+	key, err := argoutil.NewPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &tlsutil.CertConfig{
+		CertName:     secret.Name,
+		CertType:     tlsutil.ServingCert,
+		CommonName:   hosts[0],
+		Organization: []string{cr.ObjectMeta.Namespace},
+	}
+
+	cert, err := argoutil.NewSignedCertificate(cfg, hosts, key, caCert, caKey)
+	if err != nil {
+		return nil, err
+	}
+
+	secret.Data = map[string][]byte{
+		corev1.TLSCertKey:       argoutil.EncodeCertificatePEM(cert),
+		corev1.TLSPrivateKeyKey: argoutil.EncodePrivateKeyPEM(key),
+	}
+
+	return secret, nil
+}
+
 // reconcileArgoSecret will ensure that the Argo CD Secret is present.
 func (r *ReconcileArgoCD) reconcileArgoSecret(cr *argoprojv1a1.ArgoCD) error {
 	clusterSecret := argoutil.NewSecretWithSuffix(cr, "cluster")
@@ -188,6 +259,14 @@ func (r *ReconcileArgoCD) reconcileArgoSecret(cr *argoprojv1a1.ArgoCD) error {
 		common.ArgoCDKeyTLSPrivateKey:      tlsSecret.Data[common.ArgoCDKeyTLSPrivateKey],
 	}
 
+	if _, err := r.reconcileLocalUsers(cr, secret); err != nil {
+		return err
+	}
+
+	if _, err := r.reconcileApplicationSetWebhookSecrets(cr, secret); err != nil {
+		return err
+	}
+
 	if err := controllerutil.SetControllerReference(cr, secret, r.Scheme); err != nil {
 		return err
 	}
@@ -216,27 +295,27 @@ func (r *ReconcileArgoCD) reconcileClusterMainSecret(cr *argoprojv1a1.ArgoCD) er
 	return r.Client.Create(context.TODO(), secret)
 }
 
-// reconcileClusterTLSSecret ensures the TLS Secret is created for the ArgoCD cluster.
+// reconcileClusterTLSSecret ensures the TLS Secret is created for the ArgoCD cluster, and re-issued when the
+// certificate signed by the cluster CA is due for renewal.
 func (r *ReconcileArgoCD) reconcileClusterTLSSecret(cr *argoprojv1a1.ArgoCD) error {
-	secret := argoutil.NewTLSSecret(cr, "tls")
-	if argoutil.IsObjectFound(r.Client, cr.Namespace, secret.Name, secret) {
-		return nil // Secret found, do nothing
-	}
-
 	caSecret := argoutil.NewSecretWithSuffix(cr, "ca")
 	caSecret, err := argoutil.FetchSecret(r.Client, cr.ObjectMeta, caSecret.Name)
 	if err != nil {
 		return err
 	}
 
-	caCert, err := argoutil.ParsePEMEncodedCert(caSecret.Data[corev1.TLSCertKey])
+	caCert, caKey, err := parseSecretCA(caSecret)
 	if err != nil {
 		return err
 	}
 
-	caKey, err := argoutil.ParsePEMEncodedPrivateKey(caSecret.Data[corev1.TLSPrivateKeyKey])
-	if err != nil {
-		return err
+	secret := argoutil.NewTLSSecret(cr, "tls")
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, secret.Name, secret) {
+		cert, err := parseSecretCertificate(secret)
+		if err != nil || cert.CheckSignatureFrom(caCert) != nil || !isCertificateDueForRenewal(cert, cr) {
+			return nil // Certificate not issued by the operator or not due for renewal, do nothing.
+		}
+		return r.renewClusterTLSSecret(secret, caCert, caKey, cr)
 	}
 
 	secret, err = newCertificateSecret("tls", caCert, caKey, cr)
@@ -251,11 +330,16 @@ func (r *ReconcileArgoCD) reconcileClusterTLSSecret(cr *argoprojv1a1.ArgoCD) err
 	return r.Client.Create(context.TODO(), secret)
 }
 
-// reconcileClusterCASecret ensures the CA Secret is created for the ArgoCD cluster.
+// reconcileClusterCASecret ensures the CA Secret is created for the ArgoCD cluster, and re-issued when the
+// self-signed CA is due for renewal, along with the TLS Secret signed by it.
 func (r *ReconcileArgoCD) reconcileClusterCASecret(cr *argoprojv1a1.ArgoCD) error {
 	secret := argoutil.NewSecretWithSuffix(cr, "ca")
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, secret.Name, secret) {
-		return nil // Secret found, do nothing
+		previousCA, err := parseSecretCertificate(secret)
+		if err != nil || !isSelfSignedCertificate(previousCA) || !isCertificateDueForRenewal(previousCA, cr) {
+			return nil // CA not generated by the operator or not due for renewal, do nothing.
+		}
+		return r.renewClusterCASecret(secret, previousCA, cr)
 	}
 
 	secret, err := newCASecret(cr)
@@ -269,6 +353,165 @@ func (r *ReconcileArgoCD) reconcileClusterCASecret(cr *argoprojv1a1.ArgoCD) erro
 	return r.Client.Create(context.TODO(), secret)
 }
 
+// renewClusterCASecret will re-issue the CA in the given Secret, and the TLS Secrets signed by the previous CA.
+func (r *ReconcileArgoCD) renewClusterCASecret(secret *corev1.Secret, previousCA *x509.Certificate, cr *argoprojv1a1.ArgoCD) error {
+	renewed, err := newCASecret(cr)
+	if err != nil {
+		return err
+	}
+
+	log.Info(fmt.Sprintf("renewing ca secret [%s]", secret.Name))
+	secret.Data = renewed.Data
+	if err := r.Client.Update(context.TODO(), secret); err != nil {
+		return err
+	}
+
+	caCert, caKey, err := parseSecretCA(secret)
+	if err != nil {
+		return err
+	}
+
+	// Certificates not signed by the previous CA are left alone.
+	tlsSecret := argoutil.NewTLSSecret(cr, "tls")
+	if r.isSecretSignedBy(tlsSecret, previousCA) {
+		if err := r.renewClusterTLSSecret(tlsSecret, caCert, caKey, cr); err != nil {
+			return err
+		}
+	}
+
+	redisTLSSecret := argoutil.NewSecretWithName(cr, common.ArgoCDRedisServerTLSSecretName)
+	if r.isSecretSignedBy(redisTLSSecret, previousCA) {
+		return r.renewRedisTLSSecret(redisTLSSecret, caCert, caKey, cr)
+	}
+	return nil
+}
+
+// reconcileRedisAuthSecret will ensure that the Secret containing the Redis password is present when password
+// authentication is enabled for the given ArgoCD, and removed otherwise.
+func (r *ReconcileArgoCD) reconcileRedisAuthSecret(cr *argoprojv1a1.ArgoCD) error {
+	secret := argoutil.NewSecretWithName(cr, getRedisAuthSecretName(cr))
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, secret.Name, secret) {
+		if !cr.Spec.Redis.AuthEnabled || isExternalRedis(cr) {
+			// Secret exists but authentication has been disabled or an external Redis is used, delete the Secret
+			return r.Client.Delete(context.TODO(), secret)
+		}
+		return nil // Secret found, do nothing
+	}
+
+	if !cr.Spec.Redis.AuthEnabled || isExternalRedis(cr) {
+		return nil // Authentication not enabled or external Redis used, do nothing.
+	}
+
+	redisPassword, err := generateRedisPassword()
+	if err != nil {
+		return err
+	}
+
+	secret.Data = map[string][]byte{
+		common.ArgoCDKeyRedisAuth: redisPassword,
+	}
+
+	if err := controllerutil.SetControllerReference(cr, secret, r.Scheme); err != nil {
+		return err
+	}
+	return r.Client.Create(context.TODO(), secret)
+}
+
+// reconcileRedisTLSSecret ensures the Redis TLS Secret is created when TLS is enabled for Redis and the certificate
+// is not issued by cert-manager, and re-issued when the certificate signed by the cluster CA is due for renewal.
+func (r *ReconcileArgoCD) reconcileRedisTLSSecret(cr *argoprojv1a1.ArgoCD) error {
+	if !cr.Spec.Redis.TLSEnabled || isExternalRedis(cr) || isCertManagerEnabled(cr) {
+		return nil // Certificate not needed or issued by cert-manager, do nothing.
+	}
+
+	caSecret, err := argoutil.FetchSecret(r.Client, cr.ObjectMeta, nameWithSuffix(common.ArgoCDCASuffix, cr))
+	if err != nil {
+		return err
+	}
+
+	caCert, caKey, err := parseSecretCA(caSecret)
+	if err != nil {
+		return err
+	}
+
+	secret := argoutil.NewSecretWithName(cr, common.ArgoCDRedisServerTLSSecretName)
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, secret.Name, secret) {
+		cert, err := parseSecretCertificate(secret)
+		if err != nil || cert.CheckSignatureFrom(caCert) != nil || !isCertificateDueForRenewal(cert, cr) {
+			return nil // Certificate not issued by the operator or not due for renewal, do nothing.
+		}
+		return r.renewRedisTLSSecret(secret, caCert, caKey, cr)
+	}
+
+	secret, err = newRedisTLSSecret(caCert, caKey, cr)
+	if err != nil {
+		return err
+	}
+
+	if err := controllerutil.SetControllerReference(cr, secret, r.Scheme); err != nil {
+		return err
+	}
+	return r.Client.Create(context.TODO(), secret)
+}
+
+// reconcileIngressTLSSecret will ensure that the TLS Secret with the given name generated for an Ingress is present
+// and valid for the given hosts. The certificate is re-issued when the hosts change, when it is due for renewal or
+// when it is not signed by the current CA. Secrets not created by the operator are left alone.
+func (r *ReconcileArgoCD) reconcileIngressTLSSecret(name string, hosts []string, cr *argoprojv1a1.ArgoCD) error {
+	caSecret, err := argoutil.FetchSecret(r.Client, cr.ObjectMeta, nameWithSuffix(common.ArgoCDCASuffix, cr))
+	if err != nil {
+		return err
+	}
+
+	caCert, caKey, err := parseSecretCA(caSecret)
+	if err != nil {
+		return err
+	}
+
+	secret := argoutil.NewSecretWithName(cr, name)
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, secret.Name, secret) {
+		if !metav1.IsControlledBy(secret, cr) {
+			return nil // Secret provided by the user, do nothing.
+		}
+
+		cert, err := parseSecretCertificate(secret)
+		if err == nil && cert.CheckSignatureFrom(caCert) == nil && !isCertificateDueForRenewal(cert, cr) && reflect.DeepEqual(cert.DNSNames, hosts) {
+			return nil // Certificate valid for the hosts and not due for renewal, do nothing.
+		}
+
+		renewed, err := newIngressTLSSecret(name, hosts, caCert, caKey, cr)
+		if err != nil {
+			return err
+		}
+
+		log.Info(fmt.Sprintf("renewing ingress tls secret [%s]", secret.Name))
+		secret.Data = renewed.Data
+		return r.Client.Update(context.TODO(), secret)
+	}
+
+	secret, err = newIngressTLSSecret(name, hosts, caCert, caKey, cr)
+	if err != nil {
+		return err
+	}
+
+	if err := controllerutil.SetControllerReference(cr, secret, r.Scheme); err != nil {
+		return err
+	}
+	return r.Client.Create(context.TODO(), secret)
+}
+
+// deleteIngressTLSSecret will delete the TLS Secret with the given name generated for an Ingress that no longer uses it.
+// Secrets not created by the operator are left alone.
+func (r *ReconcileArgoCD) deleteIngressTLSSecret(name string, cr *argoprojv1a1.ArgoCD) error {
+	secret := argoutil.NewSecretWithName(cr, name)
+	if !argoutil.IsObjectFound(r.Client, cr.Namespace, secret.Name, secret) || !metav1.IsControlledBy(secret, cr) {
+		return nil // Secret not found or provided by the user, do nothing.
+	}
+
+	log.Info(fmt.Sprintf("deleting unused ingress tls secret [%s]", secret.Name))
+	return r.Client.Delete(context.TODO(), secret)
+}
+
 // reconcileClusterSecrets will reconcile all Secret resources for the ArgoCD cluster.
 func (r *ReconcileArgoCD) reconcileClusterSecrets(cr *argoprojv1a1.ArgoCD) error {
 	if err := r.reconcileClusterMainSecret(cr); err != nil {
@@ -283,6 +526,14 @@ func (r *ReconcileArgoCD) reconcileClusterSecrets(cr *argoprojv1a1.ArgoCD) error
 		return err
 	}
 
+	if err := r.reconcileRedisTLSSecret(cr); err != nil {
+		return err
+	}
+
+	if err := r.reconcileRedisAuthSecret(cr); err != nil {
+		return err
+	}
+
 	if err := r.reconcileClusterPermissionsSecret(cr); err != nil {
 		return err
 	}
@@ -318,6 +569,18 @@ func (r *ReconcileArgoCD) reconcileExistingArgoSecret(cr *argoprojv1a1.ArgoCD, s
 		changed = true
 	}
 
+	localUsersChanged, err := r.reconcileLocalUsers(cr, secret)
+	if err != nil {
+		return err
+	}
+	changed = changed || localUsersChanged
+
+	webhookSecretsChanged, err := r.reconcileApplicationSetWebhookSecrets(cr, secret)
+	if err != nil {
+		return err
+	}
+	changed = changed || webhookSecretsChanged
+
 	if changed {
 		log.Info("updating argo secret")
 		if err := r.Client.Update(context.TODO(), secret); err != nil {
@@ -430,15 +693,8 @@ func (r *ReconcileArgoCD) reconcileClusterPermissionsSecret(cr *argoprojv1a1.Arg
 		clusterConfigInstance = true
 	}
 
-	clusterSecrets := &corev1.SecretList{}
-	opts := &client.ListOptions{
-		LabelSelector: labels.SelectorFromSet(map[string]string{
-			common.ArgoCDSecretTypeLabel: "cluster",
-		}),
-		Namespace: cr.Namespace,
-	}
-
-	if err := r.Client.List(context.TODO(), clusterSecrets, opts); err != nil {
+	clusterSecrets, err := r.getClusterSecrets(cr)
+	if err != nil {
 		return err
 	}
 	for _, s := range clusterSecrets.Items {
@@ -474,6 +730,21 @@ func (r *ReconcileArgoCD) reconcileClusterPermissionsSecret(cr *argoprojv1a1.Arg
 	return r.Client.Create(context.TODO(), secret)
 }
 
+// getTLSSecretChecksum returns the SHA256 checksum of tls.crt and tls.key in the given secret, or an empty string
+// when either is missing.
+func getTLSSecretChecksum(secret *corev1.Secret) string {
+	// We do the checksum over a concatenated byte stream of cert + key
+	crt, crtOk := secret.Data[corev1.TLSCertKey]
+	key, keyOk := secret.Data[corev1.TLSPrivateKeyKey]
+	if !crtOk || !keyOk {
+		return ""
+	}
+	var sumBytes []byte
+	sumBytes = append(sumBytes, crt...)
+	sumBytes = append(sumBytes, key...)
+	return fmt.Sprintf("%x", sha256.Sum256(sumBytes))
+}
+
 // reconcileRepoServerTLSSecret checks whether the argocd-repo-server-tls secret
 // has changed since our last reconciliation loop. It does so by comparing the
 // checksum of tls.crt and tls.key in the status of the ArgoCD CR against the
@@ -494,15 +765,7 @@ func (r *ReconcileArgoCD) reconcileRepoServerTLSSecret(cr *argoprojv1a1.ArgoCD)
 		// We only process secrets of type kubernetes.io/tls
 		return nil
 	} else {
-		// We do the checksum over a concatenated byte stream of cert + key
-		crt, crtOk := tlsSecretObj.Data[corev1.TLSCertKey]
-		key, keyOk := tlsSecretObj.Data[corev1.TLSPrivateKeyKey]
-		if crtOk && keyOk {
-			var sumBytes []byte
-			sumBytes = append(sumBytes, crt...)
-			sumBytes = append(sumBytes, key...)
-			sha256sum = fmt.Sprintf("%x", sha256.Sum256(sumBytes))
-		}
+		sha256sum = getTLSSecretChecksum(&tlsSecretObj)
 	}
 
 	// The content of the TLS secret has changed since we last looked if the
@@ -542,6 +805,50 @@ func (r *ReconcileArgoCD) reconcileRepoServerTLSSecret(cr *argoprojv1a1.ArgoCD)
 	return nil
 }
 
+// reconcileRedisTLSChecksum checks whether the argocd-operator-redis-tls secret has changed since our last
+// reconciliation loop, and triggers a rollout of Redis and the Argo CD components connecting to it when it has.
+// Redis and the Argo CD components only read the certificate on startup.
+func (r *ReconcileArgoCD) reconcileRedisTLSChecksum(cr *argoprojv1a1.ArgoCD) error {
+	var sha256sum string
+	if cr.Spec.Redis.TLSEnabled && !isExternalRedis(cr) {
+		secret := argoutil.NewSecretWithName(cr, common.ArgoCDRedisServerTLSSecretName)
+		if argoutil.IsObjectFound(r.Client, cr.Namespace, secret.Name, secret) && secret.Type == corev1.SecretTypeTLS {
+			sha256sum = getTLSSecretChecksum(secret)
+		}
+	}
+
+	if cr.Status.RedisTLSChecksum == sha256sum {
+		return nil
+	}
+
+	// We store the value early to prevent a possible restart loop, for the
+	// cost of a possibly missed restart when we cannot update the status
+	// field of the resource.
+	cr.Status.RedisTLSChecksum = sha256sum
+	if err := r.Client.Status().Update(context.TODO(), cr); err != nil {
+		return err
+	}
+
+	rollouts := []interface{}{
+		newDeploymentWithSuffix("server", "server", cr),
+		newDeploymentWithSuffix("repo-server", "repo-server", cr),
+		newStatefulSetWithSuffix("application-controller", "application-controller", cr),
+	}
+	if isRedisHAEnabled(cr) {
+		rollouts = append(rollouts,
+			newStatefulSetWithSuffix("redis-ha-server", "redis", cr),
+			newDeploymentWithSuffix("redis-ha-haproxy", "redis", cr))
+	} else if isRedisDeploymentEnabled(cr) {
+		rollouts = append(rollouts, newDeploymentWithSuffix("redis", "redis", cr))
+	}
+	for _, obj := range rollouts {
+		if err := r.triggerRollout(obj, "redis.tls.cert.changed"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // reconcileSecrets will reconcile all ArgoCD Secret resources.
 func (r *ReconcileArgoCD) reconcileSecrets(cr *argoprojv1a1.ArgoCD) error {
 	if err := r.reconcileClusterSecrets(cr); err != nil {
@@ -554,3 +861,19 @@ func (r *ReconcileArgoCD) reconcileSecrets(cr *argoprojv1a1.ArgoCD) error {
 
 	return nil
 }
+
+// getClusterSecrets will return the cluster secrets registered with Argo CD in the namespace of the given ArgoCD.
+func (r *ReconcileArgoCD) getClusterSecrets(cr *argoprojv1a1.ArgoCD) (*corev1.SecretList, error) {
+	clusterSecrets := &corev1.SecretList{}
+	opts := &client.ListOptions{
+		LabelSelector: labels.SelectorFromSet(map[string]string{
+			common.ArgoCDSecretTypeLabel: "cluster",
+		}),
+		Namespace: cr.Namespace,
+	}
+
+	if err := r.Client.List(context.TODO(), clusterSecrets, opts); err != nil {
+		return nil, err
+	}
+	return clusterSecrets, nil
+}
@@ -243,3 +243,76 @@ func Test_ReconcileArgoCD_ClusterPermissionsSecret(t *testing.T) {
 	assert.NilError(t, r.reconcileClusterPermissionsSecret(a))
 	assert.ErrorContains(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: testSecret.Name, Namespace: testSecret.Namespace}, testSecret), "not found")
 }
+
+func Test_ReconcileArgoCD_reconcileRedisAuthSecret(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	r := makeTestReconciler(t, a)
+
+	assert.NilError(t, r.reconcileRedisAuthSecret(a))
+	secret := &corev1.Secret{}
+	assert.ErrorContains(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis", Namespace: testNamespace}, secret), "not found")
+
+	a.Spec.Redis.AuthEnabled = true
+	assert.NilError(t, r.reconcileRedisAuthSecret(a))
+	assert.NilError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis", Namespace: testNamespace}, secret))
+	password := string(secret.Data["auth"])
+	assert.Equal(t, len(password), 32)
+	assert.Equal(t, secret.OwnerReferences[0].Name, a.Name)
+
+	// The generated password is kept on later reconciliations.
+	assert.NilError(t, r.reconcileRedisAuthSecret(a))
+	assert.NilError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis", Namespace: testNamespace}, secret))
+	assert.Equal(t, string(secret.Data["auth"]), password)
+
+	a.Spec.Redis.AuthEnabled = false
+	assert.NilError(t, r.reconcileRedisAuthSecret(a))
+	assert.ErrorContains(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-redis", Namespace: testNamespace}, secret), "not found")
+}
+
+func Test_ReconcileArgoCD_reconcileRedisTLSSecret(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Redis.TLSEnabled = true
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NilError(t, r.reconcileClusterCASecret(a))
+	assert.NilError(t, r.reconcileRedisTLSSecret(a))
+
+	caSecret := &corev1.Secret{}
+	assert.NilError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-ca", Namespace: testNamespace}, caSecret))
+	secret := &corev1.Secret{}
+	assert.NilError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-operator-redis-tls", Namespace: testNamespace}, secret))
+	assert.Equal(t, secret.Type, corev1.SecretTypeTLS)
+	assert.DeepEqual(t, secret.Data[corev1.ServiceAccountRootCAKey], caSecret.Data[corev1.TLSCertKey])
+
+	cert, err := parseSecretCertificate(secret)
+	assert.NilError(t, err)
+	caCert, err := parseSecretCertificate(caSecret)
+	assert.NilError(t, err)
+	assert.NilError(t, cert.CheckSignatureFrom(caCert))
+	for _, name := range []string{"argocd-redis.argocd.svc", "argocd-redis-ha-haproxy.argocd.svc.cluster.local"} {
+		assert.NilError(t, cert.VerifyHostname(name))
+	}
+}
+
+func Test_ReconcileArgoCD_reconcileRedisTLSSecret_certManager(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	certManagerAPIFound = true
+	defer func() {
+		certManagerAPIFound = false
+	}()
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Redis.TLSEnabled = true
+		a.Spec.TLS.CertManager = &argoprojv1alpha1.ArgoCDCertManagerSpec{
+			IssuerRef: argoprojv1alpha1.ArgoCDCertManagerIssuerRef{Name: "corporate-ca"},
+		}
+	})
+	r := makeTestReconciler(t, a)
+
+	// The certificate is left to cert-manager.
+	assert.NilError(t, r.reconcileRedisTLSSecret(a))
+	secret := &corev1.Secret{}
+	assert.ErrorContains(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-operator-redis-tls", Namespace: testNamespace}, secret), "not found")
+}
@@ -65,6 +65,46 @@ func newServiceWithSuffix(suffix string, component string, cr *argoprojv1a1.Argo
 	return newServiceWithName(fmt.Sprintf("%s-%s", cr.Name, suffix), component, cr)
 }
 
+// reconcileApplicationSetService will ensure that the Service for the ApplicationSet controller webhook server is present.
+func (r *ReconcileArgoCD) reconcileApplicationSetService(cr *argoprojv1a1.ArgoCD) error {
+	svc := newServiceWithSuffix("applicationset-controller", "controller", cr)
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, svc.Name, svc) {
+		if cr.Spec.ApplicationSet == nil {
+			// Service exists but the ApplicationSet controller has been removed, delete the Service
+			return r.Client.Delete(context.TODO(), svc)
+		}
+		return nil // Service found, do nothing
+	}
+
+	if cr.Spec.ApplicationSet == nil {
+		return nil // ApplicationSet controller not enabled, do nothing.
+	}
+
+	svc.Spec.Selector = map[string]string{
+		common.ArgoCDKeyName: nameWithSuffix("applicationset-controller", cr),
+	}
+
+	svc.Spec.Ports = []corev1.ServicePort{
+		{
+			Name:       "webhook",
+			Port:       common.ArgoCDDefaultApplicationSetWebhookPort,
+			Protocol:   corev1.ProtocolTCP,
+			TargetPort: intstr.FromInt(common.ArgoCDDefaultApplicationSetWebhookPort),
+		},
+		{
+			Name:       "metrics",
+			Port:       common.ArgoCDDefaultApplicationSetMetricsPort,
+			Protocol:   corev1.ProtocolTCP,
+			TargetPort: intstr.FromInt(common.ArgoCDDefaultApplicationSetMetricsPort),
+		},
+	}
+
+	if err := controllerutil.SetControllerReference(cr, svc, r.Scheme); err != nil {
+		return err
+	}
+	return r.Client.Create(context.TODO(), svc)
+}
+
 // reconcileDexService will ensure that the Service for Dex is present.
 func (r *ReconcileArgoCD) reconcileDexService(cr *argoprojv1a1.ArgoCD) error {
 	svc := newServiceWithSuffix("dex-server", "dex-server", cr)
@@ -167,10 +207,10 @@ func (r *ReconcileArgoCD) reconcileMetricsService(cr *argoprojv1a1.ArgoCD) error
 
 // reconcileRedisHAAnnounceServices will ensure that the announce Services are present for Redis when running in HA mode.
 func (r *ReconcileArgoCD) reconcileRedisHAAnnounceServices(cr *argoprojv1a1.ArgoCD) error {
-	for i := int32(0); i < common.ArgoCDDefaultRedisHAReplicas; i++ {
+	for i := int32(0); i < *getRedisHAReplicas(cr); i++ {
 		svc := newServiceWithSuffix(fmt.Sprintf("redis-ha-announce-%d", i), "redis", cr)
 		if argoutil.IsObjectFound(r.Client, cr.Namespace, svc.Name, svc) {
-			return nil // Service found, do nothing
+			continue // Service found, do nothing
 		}
 
 		svc.ObjectMeta.Annotations = map[string]string{
@@ -451,7 +491,12 @@ func (r *ReconcileArgoCD) reconcileServerService(cr *argoprojv1a1.ArgoCD) error
 
 // reconcileServices will ensure that all Services are present for the given ArgoCD.
 func (r *ReconcileArgoCD) reconcileServices(cr *argoprojv1a1.ArgoCD) error {
-	err := r.reconcileDexService(cr)
+	err := r.reconcileApplicationSetService(cr)
+	if err != nil {
+		return err
+	}
+
+	err = r.reconcileDexService(cr)
 	if err != nil {
 		return err
 	}
@@ -466,12 +511,12 @@ func (r *ReconcileArgoCD) reconcileServices(cr *argoprojv1a1.ArgoCD) error {
 		return err
 	}
 
-	if cr.Spec.HA.Enabled {
+	if isRedisHAEnabled(cr) {
 		err = r.reconcileRedisHAServices(cr)
 		if err != nil {
 			return err
 		}
-	} else {
+	} else if isRedisDeploymentEnabled(cr) {
 		err = r.reconcileRedisService(cr)
 		if err != nil {
 			return err
@@ -2,9 +2,11 @@ package argocd
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"testing"
 
+	argoprojv1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
 	"github.com/argoproj-labs/argocd-operator/common"
 	"gotest.tools/assert"
 	"k8s.io/apimachinery/pkg/types"
@@ -47,6 +49,24 @@ func TestReconcileArgoCD_reconcileDexService_Dex_Disabled(t *testing.T) {
 	assert.ErrorContains(t, r.Client.Get(context.TODO(), types.NamespacedName{Namespace: s.Namespace, Name: s.Name}, s), "not found")
 }
 
+func TestReconcileArgoCD_reconcileRedisHAAnnounceServices(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.HA.Enabled = true
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NilError(t, r.reconcileRedisHAAnnounceServices(a))
+
+	// Scaling up Redis adds the announce Services of the new pods.
+	a.Spec.HA.RedisReplicas = int32Ptr(5)
+	assert.NilError(t, r.reconcileRedisHAAnnounceServices(a))
+	for i := 0; i < 5; i++ {
+		s := newServiceWithSuffix(fmt.Sprintf("redis-ha-announce-%d", i), "redis", a)
+		assert.NilError(t, r.Client.Get(context.TODO(), types.NamespacedName{Namespace: s.Namespace, Name: s.Name}, s))
+	}
+}
+
 func TestEnsureAutoTLSAnnotation(t *testing.T) {
 	a := makeTestArgoCD()
 	t.Run("Ensure annotation will be set for OpenShift", func(t *testing.T) {
@@ -0,0 +1,133 @@
+// Copyright 2021 ArgoCD Operator Developers
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package argocd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+
+	argoprojv1a1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+// isBalancedShardAssignmentEnabled returns whether the operator assigns the shard of the cluster secrets for the given ArgoCD.
+func isBalancedShardAssignmentEnabled(cr *argoprojv1a1.ArgoCD) bool {
+	sharding := cr.Spec.Controller.Sharding
+	return sharding.BalancedAssignment && (sharding.Enabled || sharding.DynamicScalingEnabled)
+}
+
+// getClusterShardWeight will return the weight of the given cluster secret, read from the weight annotation of the given ArgoCD.
+func getClusterShardWeight(cr *argoprojv1a1.ArgoCD, secret *corev1.Secret) int64 {
+	annotation := common.ArgoCDShardWeightAnnotation
+	if cr.Spec.Controller.Sharding.WeightAnnotation != "" {
+		annotation = cr.Spec.Controller.Sharding.WeightAnnotation
+	}
+
+	weight, err := strconv.ParseInt(secret.Annotations[annotation], 10, 64)
+	if err != nil || weight < 1 {
+		return 1
+	}
+	return weight
+}
+
+// assignClusterShards will return the shard of each of the given cluster secrets by name.
+// Clusters are assigned from the heaviest to the lightest to the shard with the lowest total weight,
+// ordering clusters of the same weight by name so that the assignment is stable across reconciliations.
+func assignClusterShards(cr *argoprojv1a1.ArgoCD, clusters []corev1.Secret, shards int32) map[string]int32 {
+	weights := make(map[string]int64, len(clusters))
+	names := make([]string, 0, len(clusters))
+	for i := range clusters {
+		weights[clusters[i].Name] = getClusterShardWeight(cr, &clusters[i])
+		names = append(names, clusters[i].Name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if weights[names[i]] != weights[names[j]] {
+			return weights[names[i]] > weights[names[j]]
+		}
+		return names[i] < names[j]
+	})
+
+	if shards < 1 {
+		shards = 1 // An invalid replica count runs a single controller, which handles all clusters.
+	}
+	loads := make([]int64, shards)
+	assignment := make(map[string]int32, len(clusters))
+	for _, name := range names {
+		shard := int32(0)
+		for i := range loads {
+			if loads[i] < loads[shard] {
+				shard = int32(i)
+			}
+		}
+		loads[shard] += weights[name]
+		assignment[name] = shard
+	}
+	return assignment
+}
+
+// reconcileClusterShards will ensure that the shard of each cluster secret is assigned by the operator when balanced
+// assignment is enabled, and that the shards previously assigned by the operator are removed otherwise.
+func (r *ReconcileArgoCD) reconcileClusterShards(cr *argoprojv1a1.ArgoCD) error {
+	clusters, err := r.getClusterSecrets(cr)
+	if err != nil {
+		return err
+	}
+
+	if !isBalancedShardAssignmentEnabled(cr) {
+		for i := range clusters.Items {
+			secret := &clusters.Items[i]
+			if _, ok := secret.Annotations[common.ArgoCDShardAssignedAnnotation]; !ok {
+				continue // Shard not assigned by the operator, leave it alone.
+			}
+			delete(secret.Annotations, common.ArgoCDShardAssignedAnnotation)
+			delete(secret.Data, "shard")
+			if err := r.Client.Update(context.TODO(), secret); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	replicas, err := r.getArgoControllerReplicas(cr)
+	if err != nil {
+		return err
+	}
+
+	assignment := assignClusterShards(cr, clusters.Items, replicas)
+	for i := range clusters.Items {
+		secret := &clusters.Items[i]
+		shard := fmt.Sprint(assignment[secret.Name])
+		if _, ok := secret.Annotations[common.ArgoCDShardAssignedAnnotation]; ok && string(secret.Data["shard"]) == shard {
+			continue // Shard already assigned, move along...
+		}
+
+		if secret.Annotations == nil {
+			secret.Annotations = make(map[string]string)
+		}
+		secret.Annotations[common.ArgoCDShardAssignedAnnotation] = "true"
+		if secret.Data == nil {
+			secret.Data = make(map[string][]byte)
+		}
+		secret.Data["shard"] = []byte(shard)
+		if err := r.Client.Update(context.TODO(), secret); err != nil {
+			return err
+		}
+	}
+	return nil
+}
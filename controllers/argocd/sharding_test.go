@@ -0,0 +1,122 @@
+package argocd
+
+import (
+	"context"
+	"testing"
+
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoprojv1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
+)
+
+func makeTestClusterSecret(name, weight string) *corev1.Secret {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespace,
+			Labels:    map[string]string{common.ArgoCDSecretTypeLabel: "cluster"},
+		},
+		Data: map[string][]byte{"name": []byte(name)},
+	}
+	if weight != "" {
+		secret.Annotations = map[string]string{common.ArgoCDShardWeightAnnotation: weight}
+	}
+	return secret
+}
+
+func getTestClusterShard(t *testing.T, r *ReconcileArgoCD, name string) string {
+	t.Helper()
+	secret := &corev1.Secret{}
+	assert.NilError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: testNamespace}, secret))
+	return string(secret.Data["shard"])
+}
+
+func TestAssignClusterShards(t *testing.T) {
+	a := makeTestArgoCD()
+	clusters := []corev1.Secret{
+		*makeTestClusterSecret("a", "10"),
+		*makeTestClusterSecret("b", "6"),
+		*makeTestClusterSecret("c", "5"),
+		*makeTestClusterSecret("d", ""),
+		*makeTestClusterSecret("e", "invalid"),
+	}
+
+	// Heaviest clusters are placed first on the least loaded shard: a(10) -> 0, b(6) -> 1, c(5) -> 1, d(1) -> 0, e(1) -> 0.
+	assert.DeepEqual(t, assignClusterShards(a, clusters, 2), map[string]int32{"a": 0, "b": 1, "c": 1, "d": 0, "e": 0})
+	assert.DeepEqual(t, assignClusterShards(a, clusters, 3), map[string]int32{"a": 0, "b": 1, "c": 2, "d": 2, "e": 1})
+
+	// An invalid number of shards assigns all clusters to the first shard.
+	assert.DeepEqual(t, assignClusterShards(a, clusters, -1), map[string]int32{"a": 0, "b": 0, "c": 0, "d": 0, "e": 0})
+	assert.DeepEqual(t, assignClusterShards(a, clusters, 0), map[string]int32{"a": 0, "b": 0, "c": 0, "d": 0, "e": 0})
+}
+
+func TestReconcileArgoCD_reconcileClusterShards(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Controller.Sharding = argoprojv1alpha1.ArgoCDApplicationControllerShardSpec{
+			Enabled:            true,
+			Replicas:           2,
+			BalancedAssignment: true,
+			WeightAnnotation:   "example.com/applications",
+		}
+	})
+	prod := makeTestClusterSecret("prod", "")
+	prod.Annotations = map[string]string{"example.com/applications": "40"}
+	staging := makeTestClusterSecret("staging", "")
+	staging.Annotations = map[string]string{"example.com/applications": "25"}
+	dev := makeTestClusterSecret("dev", "")
+	dev.Annotations = map[string]string{"example.com/applications": "20"}
+	r := makeTestReconciler(t, a, prod, staging, dev)
+
+	assert.NilError(t, r.reconcileClusterShards(a))
+	assert.Equal(t, getTestClusterShard(t, r, "prod"), "0")
+	assert.Equal(t, getTestClusterShard(t, r, "staging"), "1")
+	assert.Equal(t, getTestClusterShard(t, r, "dev"), "1")
+
+	assert.NilError(t, r.reconcileStatusShards(a))
+	assert.DeepEqual(t, a.Status.Shards, []argoprojv1alpha1.ArgoCDShardAssignment{
+		{Shard: 0, Clusters: []string{"prod"}, Weight: 40},
+		{Shard: 1, Clusters: []string{"dev", "staging"}, Weight: 45},
+	})
+
+	// Changing the number of replicas rebalances the clusters.
+	a.Spec.Controller.Sharding.Replicas = 3
+	assert.NilError(t, r.reconcileClusterShards(a))
+	assert.Equal(t, getTestClusterShard(t, r, "prod"), "0")
+	assert.Equal(t, getTestClusterShard(t, r, "staging"), "1")
+	assert.Equal(t, getTestClusterShard(t, r, "dev"), "2")
+
+	assert.NilError(t, r.reconcileStatusShards(a))
+	assert.Equal(t, len(a.Status.Shards), 3)
+
+	// Disabling the balanced assignment removes the assigned shards and the status.
+	a.Spec.Controller.Sharding.BalancedAssignment = false
+	assert.NilError(t, r.reconcileClusterShards(a))
+
+	secret := &corev1.Secret{}
+	assert.NilError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "prod", Namespace: testNamespace}, secret))
+	_, found := secret.Data["shard"]
+	assert.Assert(t, !found)
+	_, found = secret.Annotations[common.ArgoCDShardAssignedAnnotation]
+	assert.Assert(t, !found)
+
+	assert.NilError(t, r.reconcileStatusShards(a))
+	assert.Assert(t, a.Status.Shards == nil)
+}
+
+func TestReconcileArgoCD_reconcileClusterShards_keepsUserShards(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	prod := makeTestClusterSecret("prod", "")
+	prod.Data["shard"] = []byte("1")
+	r := makeTestReconciler(t, a, prod)
+
+	// Shards that were not assigned by the operator are left alone.
+	assert.NilError(t, r.reconcileClusterShards(a))
+	assert.Equal(t, getTestClusterShard(t, r, "prod"), "1")
+}
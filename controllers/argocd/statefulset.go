@@ -16,6 +16,7 @@ package argocd
 
 import (
 	"context"
+	"crypto/sha1"
 	"fmt"
 	"reflect"
 	"time"
@@ -31,9 +32,33 @@ import (
 	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
 )
 
+// redisSentinelIDs are the IDs of the Sentinels of the first Redis HA pods.
+var redisSentinelIDs = []string{
+	"25b71bd9d0e4a51945d8422cab53f27027397c12",
+	"896627000a81c7bdad8dbdcffd39728c9c17b309",
+	"3acbca861108bc47379b71b1d87d1c137dce591f",
+}
+
+// getRedisSentinelIDEnv will return the environment variables with the IDs of the Sentinels of all Redis HA pods for
+// the given ArgoCD. The IDs of the pods beyond the first ones are derived from their index.
+func getRedisSentinelIDEnv(cr *argoprojv1a1.ArgoCD) []corev1.EnvVar {
+	env := []corev1.EnvVar{}
+	for i := int32(0); i < *getRedisHAReplicas(cr); i++ {
+		id := fmt.Sprintf("%x", sha1.Sum([]byte(fmt.Sprintf("argocd-redis-ha-sentinel-%d", i))))
+		if int(i) < len(redisSentinelIDs) {
+			id = redisSentinelIDs[i]
+		}
+		env = append(env, corev1.EnvVar{Name: fmt.Sprintf("SENTINEL_ID_%d", i), Value: id})
+	}
+	return env
+}
+
+// getRedisHAReplicas will return the number of Redis and Sentinel pods for the given ArgoCD when HA is enabled.
 func getRedisHAReplicas(cr *argoprojv1a1.ArgoCD) *int32 {
 	replicas := common.ArgoCDDefaultRedisHAReplicas
-	// TODO: Allow override of this value through CR?
+	if cr.Spec.HA.RedisReplicas != nil {
+		replicas = *cr.Spec.HA.RedisReplicas
+	}
 	return &replicas
 }
 
@@ -89,35 +114,6 @@ func newStatefulSetWithSuffix(suffix string, component string, cr *argoprojv1a1.
 func (r *ReconcileArgoCD) reconcileRedisStatefulSet(cr *argoprojv1a1.ArgoCD) error {
 	ss := newStatefulSetWithSuffix("redis-ha-server", "redis", cr)
 
-	existing := newStatefulSetWithSuffix("redis-ha-server", "redis", cr)
-	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
-		if !cr.Spec.HA.Enabled {
-			// StatefulSet exists but HA enabled flag has been set to false, delete the StatefulSet
-			return r.Client.Delete(context.TODO(), existing)
-		}
-
-		desiredImage := getRedisHAContainerImage(cr)
-		changed := false
-		updateNodePlacementStateful(existing, ss, &changed)
-		for i, container := range existing.Spec.Template.Spec.Containers {
-			if container.Image != desiredImage {
-				existing.Spec.Template.Spec.Containers[i].Image = getRedisHAContainerImage(cr)
-				existing.Spec.Template.ObjectMeta.Labels["image.upgraded"] = time.Now().UTC().Format("01022006-150406-MST")
-				changed = true
-			}
-		}
-
-		if changed {
-			return r.Client.Update(context.TODO(), existing)
-		}
-
-		return nil // StatefulSet found, do nothing
-	}
-
-	if !cr.Spec.HA.Enabled {
-		return nil // HA not enabled, do nothing.
-	}
-
 	ss.Spec.PodManagementPolicy = appsv1.OrderedReadyPodManagement
 	ss.Spec.Replicas = getRedisHAReplicas(cr)
 	ss.Spec.Selector = &metav1.LabelSelector{
@@ -130,7 +126,7 @@ func (r *ReconcileArgoCD) reconcileRedisStatefulSet(cr *argoprojv1a1.ArgoCD) err
 
 	ss.Spec.Template.ObjectMeta = metav1.ObjectMeta{
 		Annotations: map[string]string{
-			"checksum/init-config": "552ee3bec8fe5d9d865e371f7b615c6d472253649eb65d53ed4ae874f782647c", // TODO: Should this be hard-coded?
+			common.ArgoCDRedisHAConfigChecksumAnnotation: getRedisHAConfigChecksum(cr),
 		},
 		Labels: map[string]string{
 			common.ArgoCDKeyName: nameWithSuffix("redis-ha", cr),
@@ -185,12 +181,12 @@ func (r *ReconcileArgoCD) reconcileRedisStatefulSet(cr *argoprojv1a1.ArgoCD) err
 				Name:          "redis",
 			}},
 			Resources: getRedisResources(cr),
-			VolumeMounts: []corev1.VolumeMount{
+			VolumeMounts: append([]corev1.VolumeMount{
 				{
 					MountPath: "/data",
 					Name:      "data",
 				},
-			},
+			}, getRedisTLSVolumeMounts(cr)...),
 		},
 		{
 			Args: []string{
@@ -215,12 +211,12 @@ func (r *ReconcileArgoCD) reconcileRedisStatefulSet(cr *argoprojv1a1.ArgoCD) err
 				Name:          "sentinel",
 			}},
 			Resources: getRedisResources(cr),
-			VolumeMounts: []corev1.VolumeMount{
+			VolumeMounts: append([]corev1.VolumeMount{
 				{
 					MountPath: "/data",
 					Name:      "data",
 				},
-			},
+			}, getRedisTLSVolumeMounts(cr)...),
 		},
 	}
 
@@ -231,20 +227,7 @@ func (r *ReconcileArgoCD) reconcileRedisStatefulSet(cr *argoprojv1a1.ArgoCD) err
 		Command: []string{
 			"sh",
 		},
-		Env: []corev1.EnvVar{
-			{
-				Name:  "SENTINEL_ID_0",
-				Value: "25b71bd9d0e4a51945d8422cab53f27027397c12", // TODO: Should this be hard-coded?
-			},
-			{
-				Name:  "SENTINEL_ID_1",
-				Value: "896627000a81c7bdad8dbdcffd39728c9c17b309", // TODO: Should this be hard-coded?
-			},
-			{
-				Name:  "SENTINEL_ID_2",
-				Value: "3acbca861108bc47379b71b1d87d1c137dce591f", // TODO: Should this be hard-coded?
-			},
-		},
+		Env:             append(getRedisSentinelIDEnv(cr), getRedisAuthEnv("AUTH", cr)...),
 		Image:           getRedisHAContainerImage(cr),
 		ImagePullPolicy: corev1.PullIfNotPresent,
 		Name:            "config-init",
@@ -284,13 +267,24 @@ func (r *ReconcileArgoCD) reconcileRedisStatefulSet(cr *argoprojv1a1.ArgoCD) err
 					},
 				},
 			},
-		}, {
+		},
+	}
+	if cr.Spec.HA.RedisStorage != nil {
+		ss.Spec.VolumeClaimTemplates = []corev1.PersistentVolumeClaim{{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "data",
+			},
+			Spec: *cr.Spec.HA.RedisStorage,
+		}}
+	} else {
+		ss.Spec.Template.Spec.Volumes = append(ss.Spec.Template.Spec.Volumes, corev1.Volume{
 			Name: "data",
 			VolumeSource: corev1.VolumeSource{
 				EmptyDir: &corev1.EmptyDirVolumeSource{},
 			},
-		},
+		})
 	}
+	ss.Spec.Template.Spec.Volumes = append(ss.Spec.Template.Spec.Volumes, getRedisTLSVolumes(cr)...)
 
 	ss.Spec.UpdateStrategy = appsv1.StatefulSetUpdateStrategy{
 		Type: appsv1.RollingUpdateStatefulSetStrategyType,
@@ -300,42 +294,158 @@ func (r *ReconcileArgoCD) reconcileRedisStatefulSet(cr *argoprojv1a1.ArgoCD) err
 		return err
 	}
 
+	applyPodScheduling(&ss.Spec.Template.Spec, cr.Spec.Redis.Affinity, cr.Spec.Redis.TopologySpreadConstraints, cr.Spec.Redis.PriorityClassName)
+
+	if err := applyPodTemplateOverride(&ss.Spec.Template, cr.Spec.Redis.PodTemplateOverride); err != nil {
+		return err
+	}
+
+	existing := newStatefulSetWithSuffix("redis-ha-server", "redis", cr)
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
+		if !isRedisHAEnabled(cr) {
+			// StatefulSet exists but HA has been disabled or an external Redis is used, delete the StatefulSet
+			return r.Client.Delete(context.TODO(), existing)
+		}
+		if len(existing.Spec.VolumeClaimTemplates) != len(ss.Spec.VolumeClaimTemplates) {
+			// The volume claim templates of a StatefulSet cannot be updated, delete the StatefulSet to recreate it
+			// with or without persistent storage.
+			return r.Client.Delete(context.TODO(), existing)
+		}
+
+		changed := false
+		if !reflect.DeepEqual(existing.Spec.Replicas, ss.Spec.Replicas) {
+			existing.Spec.Replicas = ss.Spec.Replicas
+			changed = true
+		}
+		checksum := ss.Spec.Template.Annotations[common.ArgoCDRedisHAConfigChecksumAnnotation]
+		if existing.Spec.Template.Annotations[common.ArgoCDRedisHAConfigChecksumAnnotation] != checksum {
+			if existing.Spec.Template.Annotations == nil {
+				existing.Spec.Template.Annotations = map[string]string{}
+			}
+			existing.Spec.Template.Annotations[common.ArgoCDRedisHAConfigChecksumAnnotation] = checksum
+			changed = true
+		}
+		updateNodePlacementStateful(existing, ss, &changed)
+		for i, container := range existing.Spec.Template.Spec.Containers {
+			desiredImage := getRedisHAContainerImage(cr)
+			if i < len(ss.Spec.Template.Spec.Containers) {
+				desiredImage = ss.Spec.Template.Spec.Containers[i].Image
+			}
+			if container.Image != desiredImage {
+				existing.Spec.Template.Spec.Containers[i].Image = desiredImage
+				existing.Spec.Template.ObjectMeta.Labels["image.upgraded"] = time.Now().UTC().Format("01022006-150406-MST")
+				changed = true
+			}
+			if i < len(ss.Spec.Template.Spec.Containers) &&
+				!reflect.DeepEqual(container.VolumeMounts, ss.Spec.Template.Spec.Containers[i].VolumeMounts) {
+				existing.Spec.Template.Spec.Containers[i].VolumeMounts = ss.Spec.Template.Spec.Containers[i].VolumeMounts
+				changed = true
+			}
+		}
+		if len(existing.Spec.Template.Spec.InitContainers) > 0 &&
+			!reflect.DeepEqual(existing.Spec.Template.Spec.InitContainers[0].Env, ss.Spec.Template.Spec.InitContainers[0].Env) {
+			existing.Spec.Template.Spec.InitContainers[0].Env = ss.Spec.Template.Spec.InitContainers[0].Env
+			changed = true
+		}
+		if !reflect.DeepEqual(ss.Spec.Template.Spec.Volumes, existing.Spec.Template.Spec.Volumes) {
+			existing.Spec.Template.Spec.Volumes = ss.Spec.Template.Spec.Volumes
+			changed = true
+		}
+
+		if err := updatePodTemplateOverride(&existing.Spec.Template, &ss.Spec.Template, cr.Spec.Redis.PodTemplateOverride, &changed); err != nil {
+			return err
+		}
+		if changed {
+			return r.Client.Update(context.TODO(), existing)
+		}
+
+		return nil // StatefulSet found, do nothing
+	}
+
+	if !isRedisHAEnabled(cr) {
+		return nil // HA not enabled or external Redis used, do nothing.
+	}
+
 	if err := controllerutil.SetControllerReference(cr, ss, r.Scheme); err != nil {
 		return err
 	}
 	return r.Client.Create(context.TODO(), ss)
 }
 
-func getArgoControllerContainerEnv(cr *argoprojv1a1.ArgoCD) []corev1.EnvVar {
+func getArgoControllerContainerEnv(cr *argoprojv1a1.ArgoCD, replicas int32) []corev1.EnvVar {
 	env := make([]corev1.EnvVar, 0)
 
-	if cr.Spec.Controller.Sharding.Enabled {
+	if cr.Spec.Controller.Sharding.Enabled || cr.Spec.Controller.Sharding.DynamicScalingEnabled {
 		env = append(env, corev1.EnvVar{
 			Name:  "ARGOCD_CONTROLLER_REPLICAS",
-			Value: fmt.Sprint(cr.Spec.Controller.Sharding.Replicas),
+			Value: fmt.Sprint(replicas),
 		})
 	}
 
 	return env
 }
 
-func (r *ReconcileArgoCD) reconcileApplicationControllerStatefulSet(cr *argoprojv1a1.ArgoCD) error {
-	var replicas int32 = common.ArgocdApplicationControllerDefaultReplicas
+// getArgoControllerReplicas will return the number of Application Controller shards for the given ArgoCD.
+// With dynamic scaling enabled, the number of shards follows the number of cluster secrets in the ArgoCD namespace.
+func (r *ReconcileArgoCD) getArgoControllerReplicas(cr *argoprojv1a1.ArgoCD) (int32, error) {
+	sharding := cr.Spec.Controller.Sharding
 
-	if cr.Spec.Controller.Sharding.Replicas != 0 && cr.Spec.Controller.Sharding.Enabled {
-		replicas = cr.Spec.Controller.Sharding.Replicas
+	if sharding.DynamicScalingEnabled {
+		clusters, err := r.getClusterSecrets(cr)
+		if err != nil {
+			return 0, err
+		}
+		return getDynamicShardCount(int32(len(clusters.Items)), sharding), nil
+	}
+
+	if sharding.Replicas != 0 && sharding.Enabled {
+		return sharding.Replicas, nil
+	}
+	return common.ArgocdApplicationControllerDefaultReplicas, nil
+}
+
+// getDynamicShardCount will return the number of shards needed for the given number of clusters, bounded by the sharding options.
+func getDynamicShardCount(clusters int32, sharding argoprojv1a1.ArgoCDApplicationControllerShardSpec) int32 {
+	clustersPerShard := sharding.ClustersPerShard
+	if clustersPerShard < 1 {
+		clustersPerShard = common.ArgoCDDefaultClustersPerShard
+	}
+	minShards := sharding.MinShards
+	if minShards < 1 {
+		minShards = common.ArgoCDDefaultMinShards
+	}
+
+	shards := (clusters + clustersPerShard - 1) / clustersPerShard
+	if shards < minShards {
+		shards = minShards
+	}
+	if sharding.MaxShards > 0 && shards > sharding.MaxShards {
+		shards = sharding.MaxShards
+	}
+	return shards
+}
+
+func (r *ReconcileArgoCD) reconcileApplicationControllerStatefulSet(cr *argoprojv1a1.ArgoCD) error {
+	replicas, err := r.getArgoControllerReplicas(cr)
+	if err != nil {
+		return err
 	}
 
 	ss := newStatefulSetWithSuffix("application-controller", "application-controller", cr)
 	ss.Spec.Replicas = &replicas
 	controllerEnv := cr.Spec.Controller.Env
 	// Sharding setting explicitly overrides a value set in the env
-	controllerEnv = argoutil.EnvMerge(controllerEnv, getArgoControllerContainerEnv(cr), true)
+	controllerEnv = argoutil.EnvMerge(controllerEnv, getArgoControllerContainerEnv(cr, replicas), true)
 	// Let user specify their own environment first
 	controllerEnv = argoutil.EnvMerge(controllerEnv, proxyEnvVars(), false)
+	controllerEnv = argoutil.EnvMerge(controllerEnv, getRedisAuthEnv("REDIS_PASSWORD", cr), true)
+	controllerCommand := getArgoApplicationControllerCommand(cr)
+	if isRepoServerTLSVerificationRequested(cr) {
+		controllerCommand = append(controllerCommand, "--repo-server-strict-tls")
+	}
 	podSpec := &ss.Spec.Template.Spec
 	podSpec.Containers = []corev1.Container{{
-		Command:         getArgoApplicationControllerCommand(cr),
+		Command:         controllerCommand,
 		Image:           getArgoContainerImage(cr),
 		ImagePullPolicy: corev1.PullAlways,
 		Name:            "argocd-application-controller",
@@ -430,21 +540,27 @@ func (r *ReconcileArgoCD) reconcileApplicationControllerStatefulSet(cr *argoproj
 
 		podSpec.Volumes = getArgoImportVolumes(export)
 	}
+	podSpec.Volumes = append(podSpec.Volumes, getRedisTLSVolumes(cr)...)
+	podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, getRedisTLSVolumeMounts(cr)...)
+
+	ss.Spec.Template.Spec.TopologySpreadConstraints = getDefaultTopologySpreadConstraints(cr, ss.Spec.Selector)
+	applyPodScheduling(&ss.Spec.Template.Spec, cr.Spec.Controller.Affinity, cr.Spec.Controller.TopologySpreadConstraints, cr.Spec.Controller.PriorityClassName)
+
+	if err := applyPodTemplateOverride(&ss.Spec.Template, cr.Spec.Controller.PodTemplateOverride); err != nil {
+		return err
+	}
 
 	existing := newStatefulSetWithSuffix("application-controller", "application-controller", cr)
 	if argoutil.IsObjectFound(r.Client, cr.Namespace, existing.Name, existing) {
 		actualImage := existing.Spec.Template.Spec.Containers[0].Image
-		desiredImage := getArgoContainerImage(cr)
+		desiredImage := ss.Spec.Template.Spec.Containers[0].Image
 		changed := false
 		if actualImage != desiredImage {
 			existing.Spec.Template.Spec.Containers[0].Image = desiredImage
 			existing.Spec.Template.ObjectMeta.Labels["image.upgraded"] = time.Now().UTC().Format("01022006-150406-MST")
 			changed = true
 		}
-		desiredCommand := getArgoApplicationControllerCommand(cr)
-		if isRepoServerTLSVerificationRequested(cr) {
-			desiredCommand = append(desiredCommand, "--repo-server-strict-tls")
-		}
+		desiredCommand := ss.Spec.Template.Spec.Containers[0].Command
 		updateNodePlacementStateful(existing, ss, &changed)
 		if !reflect.DeepEqual(desiredCommand, existing.Spec.Template.Spec.Containers[0].Command) {
 			existing.Spec.Template.Spec.Containers[0].Command = desiredCommand
@@ -474,6 +590,9 @@ func (r *ReconcileArgoCD) reconcileApplicationControllerStatefulSet(cr *argoproj
 			changed = true
 		}
 
+		if err := updatePodTemplateOverride(&existing.Spec.Template, &ss.Spec.Template, cr.Spec.Controller.PodTemplateOverride, &changed); err != nil {
+			return err
+		}
 		if changed {
 			return r.Client.Update(context.TODO(), existing)
 		}
@@ -516,7 +635,7 @@ func (r *ReconcileArgoCD) triggerStatefulSetRollout(sts *appsv1.StatefulSet, key
 	return r.Client.Update(context.TODO(), sts)
 }
 
-//to update nodeSelector and tolerations in reconciler
+//to update nodeSelector, tolerations, affinity, topology spread constraints and priority class in reconciler
 func updateNodePlacementStateful(existing *appsv1.StatefulSet, ss *appsv1.StatefulSet, changed *bool) {
 	if !reflect.DeepEqual(existing.Spec.Template.Spec.NodeSelector, ss.Spec.Template.Spec.NodeSelector) {
 		existing.Spec.Template.Spec.NodeSelector = ss.Spec.Template.Spec.NodeSelector
@@ -526,4 +645,5 @@ func updateNodePlacementStateful(existing *appsv1.StatefulSet, ss *appsv1.Statef
 		existing.Spec.Template.Spec.Tolerations = ss.Spec.Template.Spec.Tolerations
 		*changed = true
 	}
+	updatePodScheduling(&existing.Spec.Template.Spec, &ss.Spec.Template.Spec, changed)
 }
@@ -3,6 +3,7 @@ package argocd
 import (
 	"context"
 	"fmt"
+	"os"
 	"testing"
 
 	resourcev1 "k8s.io/apimachinery/pkg/api/resource"
@@ -18,6 +19,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -89,6 +91,138 @@ func TestReconcileArgoCD_reconcileRedisStatefulSet_HA_enabled(t *testing.T) {
 	assert.Errorf(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: s.Name, Namespace: a.Namespace}, s), "not found")
 }
 
+func TestReconcileArgoCD_reconcileRedisStatefulSet_authAndTLS(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.HA.Enabled = true
+	})
+	r := makeTestReconciler(t, a)
+	s := newStatefulSetWithSuffix("redis-ha-server", "redis", a)
+
+	assert.NoError(t, r.reconcileRedisStatefulSet(a))
+
+	// Enabling authentication and TLS is applied to the existing StatefulSet.
+	a.Spec.Redis.AuthEnabled = true
+	a.Spec.Redis.TLSEnabled = true
+	assert.NoError(t, r.reconcileRedisStatefulSet(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: s.Name, Namespace: a.Namespace}, s))
+
+	assert.Contains(t, s.Spec.Template.Spec.InitContainers[0].Env, getRedisAuthEnv("AUTH", a)[0])
+	for _, container := range s.Spec.Template.Spec.Containers {
+		assert.Contains(t, container.VolumeMounts, getRedisTLSVolumeMounts(a)[0], container.Name)
+	}
+	assert.Contains(t, s.Spec.Template.Spec.Volumes, getRedisTLSVolumes(a)[0])
+}
+
+func TestReconcileArgoCD_reconcileRedisStatefulSet_topology(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	os.Setenv("REDIS_CONFIG_PATH", "../../build/redis")
+	defer os.Unsetenv("REDIS_CONFIG_PATH")
+
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.HA.Enabled = true
+	})
+	r := makeTestReconciler(t, a)
+	key := types.NamespacedName{Name: "argocd-redis-ha-server", Namespace: a.Namespace}
+
+	assert.NoError(t, r.reconcileRedisStatefulSet(a))
+	s := &appsv1.StatefulSet{}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, s))
+	assert.Equal(t, int32(3), *s.Spec.Replicas)
+	assert.Equal(t, getRedisHAConfigChecksum(a), s.Spec.Template.Annotations["checksum/init-config"])
+	assert.Empty(t, s.Spec.VolumeClaimTemplates)
+	assert.Contains(t, s.Spec.Template.Spec.Volumes, corev1.Volume{Name: "data", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}})
+	previousChecksum := s.Spec.Template.Annotations["checksum/init-config"]
+
+	// Changes to the replicas and the Sentinel settings are applied to the existing StatefulSet.
+	a.Spec.HA.RedisReplicas = int32Ptr(5)
+	a.Spec.HA.SentinelQuorum = int32Ptr(3)
+	assert.NoError(t, r.reconcileRedisStatefulSet(a))
+	s = &appsv1.StatefulSet{}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, s))
+	assert.Equal(t, int32(5), *s.Spec.Replicas)
+	assert.NotEqual(t, previousChecksum, s.Spec.Template.Annotations["checksum/init-config"])
+	env := s.Spec.Template.Spec.InitContainers[0].Env
+	assert.Len(t, env, 5)
+	assert.Equal(t, "SENTINEL_ID_4", env[4].Name)
+	assert.Len(t, env[4].Value, 40)
+	assert.Equal(t, "25b71bd9d0e4a51945d8422cab53f27027397c12", env[0].Value)
+
+	// Enabling persistent storage recreates the StatefulSet with a volume claim template.
+	storage := &corev1.PersistentVolumeClaimSpec{
+		AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceStorage: resourcev1.MustParse("1Gi")},
+		},
+	}
+	a.Spec.HA.RedisStorage = storage
+	assert.NoError(t, r.reconcileRedisStatefulSet(a))
+	assert.Error(t, r.Client.Get(context.TODO(), key, &appsv1.StatefulSet{}))
+	assert.NoError(t, r.reconcileRedisStatefulSet(a))
+	s = &appsv1.StatefulSet{}
+	assert.NoError(t, r.Client.Get(context.TODO(), key, s))
+	assert.Len(t, s.Spec.VolumeClaimTemplates, 1)
+	assert.Equal(t, "data", s.Spec.VolumeClaimTemplates[0].Name)
+	assert.Equal(t, *storage, s.Spec.VolumeClaimTemplates[0].Spec)
+	for _, volume := range s.Spec.Template.Spec.Volumes {
+		assert.NotEqual(t, "data", volume.Name)
+	}
+}
+
+func TestReconcileArgoCD_reconcileRedisStatefulSet_externalRedis(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.HA.Enabled = true
+	})
+	r := makeTestReconciler(t, a)
+	s := newStatefulSetWithSuffix("redis-ha-server", "redis", a)
+
+	assert.NoError(t, r.reconcileRedisStatefulSet(a))
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: s.Name, Namespace: a.Namespace}, s))
+
+	// Switching to an external Redis removes the Redis StatefulSet.
+	a.Spec.Redis.External = &argoprojv1alpha1.ArgoCDRedisExternalSpec{Address: "redis.cache.example.com:6379"}
+	assert.NoError(t, r.reconcileRedisStatefulSet(a))
+	assert.Error(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: s.Name, Namespace: a.Namespace}, s))
+}
+
+func TestReconcileArgoCD_reconcileApplicationController_externalRedis(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Redis.External = &argoprojv1alpha1.ArgoCDRedisExternalSpec{Address: "redis.cache.example.com:6379", TLSEnabled: true}
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NoError(t, r.reconcileApplicationControllerStatefulSet(a))
+
+	ss := &appsv1.StatefulSet{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-application-controller", Namespace: a.Namespace}, ss))
+	container := ss.Spec.Template.Spec.Containers[0]
+	assert.Subset(t, container.Command, []string{"--redis", "redis.cache.example.com:6379", "--redis-use-tls"})
+	assert.NotContains(t, container.Command, "--redis-ca-certificate")
+	assert.Equal(t, controllerDefaultVolumes(), ss.Spec.Template.Spec.Volumes)
+}
+
+func TestReconcileArgoCD_reconcileApplicationController_redisAuthAndTLS(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Redis.AuthEnabled = true
+		a.Spec.Redis.TLSEnabled = true
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NoError(t, r.reconcileApplicationControllerStatefulSet(a))
+
+	ss := &appsv1.StatefulSet{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-application-controller", Namespace: a.Namespace}, ss))
+	container := ss.Spec.Template.Spec.Containers[0]
+	assert.Subset(t, container.Command, []string{"--redis-use-tls", "--redis-ca-certificate", "/app/config/redis/tls/ca.crt"})
+	assert.Contains(t, container.Env, getRedisAuthEnv("REDIS_PASSWORD", a)[0])
+	assert.Equal(t, append(controllerDefaultVolumeMounts(), getRedisTLSVolumeMounts(a)...), container.VolumeMounts)
+	assert.Equal(t, append(controllerDefaultVolumes(), getRedisTLSVolumes(a)...), ss.Spec.Template.Spec.Volumes)
+}
+
 func TestReconcileArgoCD_reconcileApplicationController(t *testing.T) {
 	logf.SetLogger(ZapLogger(true))
 	a := makeTestArgoCD()
@@ -173,6 +307,66 @@ func TestReconcileArgoCD_reconcileApplicationController_withUpgrade(t *testing.T
 	assert.Errorf(t, err, "not found")
 }
 
+func TestReconcileArgoCD_reconcileApplicationController_withPodTemplateOverride(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Controller.PodTemplateOverride = &runtime.RawExtension{Raw: []byte(`{"spec": {
+			"dnsConfig": {"options": [{"name": "ndots", "value": "1"}]},
+			"containers": [{"name": "argocd-application-controller", "image": "registry.example.com/argocd@sha256:abc"}]
+		}}`)}
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NoError(t, r.reconcileApplicationControllerStatefulSet(a))
+
+	ss := &appsv1.StatefulSet{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-application-controller", Namespace: a.Namespace}, ss))
+	assert.Equal(t, "registry.example.com/argocd@sha256:abc", ss.Spec.Template.Spec.Containers[0].Image)
+	assert.Equal(t, "ndots", ss.Spec.Template.Spec.DNSConfig.Options[0].Name)
+
+	// The overridden image is not reverted, so no rollout is triggered.
+	assert.NoError(t, r.reconcileApplicationControllerStatefulSet(a))
+	updated := &appsv1.StatefulSet{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-application-controller", Namespace: a.Namespace}, updated))
+	assert.Equal(t, ss.ResourceVersion, updated.ResourceVersion)
+	assert.NotContains(t, updated.Spec.Template.Labels, "image.upgraded")
+}
+
+func TestReconcileArgoCD_reconcileApplicationController_withScheduling(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.HA.Enabled = true
+		a.Spec.Controller.PriorityClassName = "system-cluster-critical"
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NoError(t, r.reconcileApplicationControllerStatefulSet(a))
+
+	ss := &appsv1.StatefulSet{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-application-controller", Namespace: a.Namespace}, ss))
+	assert.Equal(t, "system-cluster-critical", ss.Spec.Template.Spec.PriorityClassName)
+	assert.Len(t, ss.Spec.Template.Spec.TopologySpreadConstraints, 1)
+	assert.Equal(t, common.ArgoCDKeyFailureDomainZone, ss.Spec.Template.Spec.TopologySpreadConstraints[0].TopologyKey)
+	assert.Equal(t, ss.Spec.Selector, ss.Spec.Template.Spec.TopologySpreadConstraints[0].LabelSelector)
+	// The operator anti-affinity is kept unless an affinity is configured.
+	assert.NotNil(t, ss.Spec.Template.Spec.Affinity.PodAntiAffinity)
+
+	affinity := &corev1.Affinity{
+		PodAntiAffinity: &corev1.PodAntiAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{{
+				LabelSelector: ss.Spec.Selector,
+				TopologyKey:   common.ArgoCDKeyHostname,
+			}},
+		},
+	}
+	a.Spec.Controller.Affinity = affinity
+	assert.NoError(t, r.reconcileApplicationControllerStatefulSet(a))
+
+	ss = &appsv1.StatefulSet{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-application-controller", Namespace: a.Namespace}, ss))
+	assert.Equal(t, affinity, ss.Spec.Template.Spec.Affinity)
+}
+
 func TestReconcileArgoCD_reconcileApplicationController_withResources(t *testing.T) {
 	logf.SetLogger(ZapLogger(true))
 	a := makeTestArgoCDWithResources(func(a *argoprojv1alpha1.ArgoCD) {
@@ -364,3 +558,102 @@ func Test_UpdateNodePlacementStateful(t *testing.T) {
 		t.Fatalf("updateNodePlacement failed, value of changed: %t", actualChange)
 	}
 }
+
+func TestReconcileArgoCD_reconcileApplicationController_withDynamicSharding(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+
+	clusterSecret := func(name string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: testNamespace,
+				Labels:    map[string]string{common.ArgoCDSecretTypeLabel: "cluster"},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		sharding argoprojv1alpha1.ArgoCDApplicationControllerShardSpec
+		clusters int
+		replicas int32
+	}{
+		{
+			name:     "no clusters uses the minimum",
+			sharding: argoprojv1alpha1.ArgoCDApplicationControllerShardSpec{DynamicScalingEnabled: true, MinShards: 2},
+			clusters: 0,
+			replicas: 2,
+		},
+		{
+			name:     "one shard per cluster by default",
+			sharding: argoprojv1alpha1.ArgoCDApplicationControllerShardSpec{DynamicScalingEnabled: true},
+			clusters: 3,
+			replicas: 3,
+		},
+		{
+			name:     "partial shards are rounded up",
+			sharding: argoprojv1alpha1.ArgoCDApplicationControllerShardSpec{DynamicScalingEnabled: true, ClustersPerShard: 2},
+			clusters: 5,
+			replicas: 3,
+		},
+		{
+			name:     "limited to the maximum",
+			sharding: argoprojv1alpha1.ArgoCDApplicationControllerShardSpec{DynamicScalingEnabled: true, MaxShards: 2},
+			clusters: 5,
+			replicas: 2,
+		},
+		{
+			name:     "static replicas are ignored",
+			sharding: argoprojv1alpha1.ArgoCDApplicationControllerShardSpec{Enabled: true, Replicas: 5, DynamicScalingEnabled: true},
+			clusters: 1,
+			replicas: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+				a.Spec.Controller.Sharding = test.sharding
+			})
+			objs := []runtime.Object{a}
+			for i := 0; i < test.clusters; i++ {
+				objs = append(objs, clusterSecret(fmt.Sprintf("cluster-%d", i)))
+			}
+			r := makeTestReconciler(t, objs...)
+
+			assert.NoError(t, r.reconcileApplicationControllerStatefulSet(a))
+
+			ss := &appsv1.StatefulSet{}
+			assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-application-controller", Namespace: testNamespace}, ss))
+			assert.Equal(t, test.replicas, *ss.Spec.Replicas)
+			assert.Equal(t, []corev1.EnvVar{{Name: "ARGOCD_CONTROLLER_REPLICAS", Value: fmt.Sprint(test.replicas)}}, ss.Spec.Template.Spec.Containers[0].Env)
+		})
+	}
+}
+
+func TestReconcileArgoCD_reconcileApplicationController_dynamicShardingFollowsClusters(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Controller.Sharding.DynamicScalingEnabled = true
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NoError(t, r.reconcileApplicationControllerStatefulSet(a))
+
+	// Registering a cluster adds a shard to the existing StatefulSet.
+	for _, name := range []string{"staging", "prod"} {
+		assert.NoError(t, r.Client.Create(context.TODO(), &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: testNamespace,
+				Labels:    map[string]string{common.ArgoCDSecretTypeLabel: "cluster"},
+			},
+		}))
+	}
+	assert.NoError(t, r.reconcileApplicationControllerStatefulSet(a))
+
+	ss := &appsv1.StatefulSet{}
+	assert.NoError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-application-controller", Namespace: testNamespace}, ss))
+	assert.Equal(t, int32(2), *ss.Spec.Replicas)
+	assert.Equal(t, "2", ss.Spec.Template.Spec.Containers[0].Env[0].Value)
+}
@@ -16,9 +16,22 @@ package argocd
 
 import (
 	"context"
+	"crypto/x509"
+	"fmt"
+	"net"
 	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 
 	argoprojv1a1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	"github.com/argoproj-labs/argocd-operator/common"
 	"github.com/argoproj-labs/argocd-operator/controllers/argoutil"
 )
 
@@ -51,6 +64,22 @@ func (r *ReconcileArgoCD) reconcileStatus(cr *argoprojv1a1.ArgoCD) error {
 	if err := r.reconcileStatusServer(cr); err != nil {
 		return err
 	}
+
+	if err := r.reconcileStatusReplicas(cr); err != nil {
+		return err
+	}
+
+	if err := r.reconcileStatusShards(cr); err != nil {
+		return err
+	}
+
+	if err := r.reconcileStatusCertificates(cr); err != nil {
+		return err
+	}
+
+	if err := r.reconcileStatusConditions(cr); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -134,11 +163,105 @@ func (r *ReconcileArgoCD) reconcileStatusPhase(cr *argoprojv1a1.ArgoCD) error {
 	return nil
 }
 
+// dialRedis checks that a TCP connection can be opened to the Redis at the given address.
+var dialRedis = func(address string) error {
+	conn, err := net.DialTimeout("tcp", address, 3*time.Second)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// externalRedisProbeInterval is how long the reachability of an external Redis is cached before it is checked again.
+const externalRedisProbeInterval = time.Minute
+
+// externalRedisProbe is the last known reachability of an external Redis.
+type externalRedisProbe struct {
+	// address is the address of the external Redis.
+	address string
+	// err is the error of the last connection attempt, nil when the Redis was reachable.
+	err error
+	// checked is the time of the last connection attempt, zero when no attempt has completed yet.
+	checked time.Time
+	// probing reports whether a connection attempt is in progress.
+	probing bool
+}
+
+var (
+	externalRedisProbesMutex sync.Mutex
+	// externalRedisProbes holds the probe of the external Redis of each ArgoCD, keyed by the ArgoCD.
+	externalRedisProbes = map[types.NamespacedName]*externalRedisProbe{}
+)
+
+// getExternalRedisProbe will return the last known reachability of the external Redis of the given ArgoCD. A new
+// connection attempt is started in the background when the last one is older than externalRedisProbeInterval, so
+// that an unreachable Redis does not block the reconciliation. The probe is reset when the address changes.
+func getExternalRedisProbe(cr *argoprojv1a1.ArgoCD) externalRedisProbe {
+	externalRedisProbesMutex.Lock()
+	defer externalRedisProbesMutex.Unlock()
+
+	key := types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}
+	address := getRedisServerAddress(cr)
+	probe, found := externalRedisProbes[key]
+	if !found || probe.address != address {
+		probe = &externalRedisProbe{address: address}
+		externalRedisProbes[key] = probe
+	}
+
+	if !probe.probing && time.Since(probe.checked) >= externalRedisProbeInterval {
+		probe.probing = true
+		go func() {
+			err := dialRedis(address)
+
+			externalRedisProbesMutex.Lock()
+			defer externalRedisProbesMutex.Unlock()
+			probe.err = err
+			probe.checked = time.Now()
+			probe.probing = false
+		}()
+	}
+	return *probe
+}
+
+// deleteExternalRedisProbe will forget the reachability of the external Redis of the ArgoCD with the given name.
+func deleteExternalRedisProbe(key types.NamespacedName) {
+	externalRedisProbesMutex.Lock()
+	defer externalRedisProbesMutex.Unlock()
+	delete(externalRedisProbes, key)
+}
+
+// getExternalRedisRequeueAfter will return the time until the status of the external Redis of the given ArgoCD is
+// refreshed with the result of the next connection attempt, or zero when the ArgoCD does not use an external Redis.
+func getExternalRedisRequeueAfter(cr *argoprojv1a1.ArgoCD) time.Duration {
+	if !isExternalRedis(cr) {
+		return 0
+	}
+	if getExternalRedisProbe(cr).checked.IsZero() {
+		return 5 * time.Second // The first connection attempt is still in progress.
+	}
+	return externalRedisProbeInterval
+}
+
 // reconcileStatusRedis will ensure that the Redis status is updated for the given ArgoCD.
+// The status of an external Redis reflects whether it is reachable from the operator.
 func (r *ReconcileArgoCD) reconcileStatusRedis(cr *argoprojv1a1.ArgoCD) error {
 	status := "Unknown"
 
-	if !cr.Spec.HA.Enabled {
+	if !isExternalRedis(cr) {
+		deleteExternalRedisProbe(types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name})
+	}
+
+	if isExternalRedis(cr) {
+		probe := getExternalRedisProbe(cr)
+		if probe.checked.IsZero() {
+			status = "Pending"
+		} else if probe.err != nil {
+			log.Info(fmt.Sprintf("external redis [%s] is not reachable: %v", getRedisServerAddress(cr), probe.err))
+			status = "Failed"
+		} else {
+			status = "Running"
+		}
+	} else if !cr.Spec.HA.Enabled {
 		deploy := newDeploymentWithSuffix("redis", "redis", cr)
 		if argoutil.IsObjectFound(r.Client, cr.Namespace, deploy.Name, deploy) {
 			status = "Pending"
@@ -212,3 +335,356 @@ func (r *ReconcileArgoCD) reconcileStatusServer(cr *argoprojv1a1.ArgoCD) error {
 	}
 	return nil
 }
+
+// reconcileStatusReplicas will ensure that the desired and ready replicas of the components are updated for the given ArgoCD.
+func (r *ReconcileArgoCD) reconcileStatusReplicas(cr *argoprojv1a1.ArgoCD) error {
+	var replicas []argoprojv1a1.ArgoCDComponentReplicas
+
+	statefulSets := map[string]string{"applicationController": "application-controller"}
+	deployments := map[string]string{
+		"applicationSet": "applicationset-controller",
+		"dex":            "dex-server",
+		"notifications":  "notifications-controller",
+		"repo":           "repo-server",
+		"server":         "server",
+	}
+	if isRedisHAEnabled(cr) {
+		statefulSets["redis"] = "redis-ha-server"
+	} else if isRedisDeploymentEnabled(cr) {
+		deployments["redis"] = "redis"
+	}
+
+	for component, suffix := range statefulSets {
+		ss := newStatefulSetWithSuffix(suffix, suffix, cr)
+		if argoutil.IsObjectFound(r.Client, cr.Namespace, ss.Name, ss) {
+			replicas = append(replicas, newComponentReplicas(component, ss.Spec.Replicas, ss.Status.ReadyReplicas))
+		}
+	}
+	for component, suffix := range deployments {
+		deploy := newDeploymentWithSuffix(suffix, suffix, cr)
+		if argoutil.IsObjectFound(r.Client, cr.Namespace, deploy.Name, deploy) {
+			replicas = append(replicas, newComponentReplicas(component, deploy.Spec.Replicas, deploy.Status.ReadyReplicas))
+		}
+	}
+	sort.Slice(replicas, func(i, j int) bool {
+		return replicas[i].Component < replicas[j].Component
+	})
+
+	if !reflect.DeepEqual(cr.Status.Replicas, replicas) {
+		cr.Status.Replicas = replicas
+		return r.Client.Status().Update(context.TODO(), cr)
+	}
+	return nil
+}
+
+// newComponentReplicas returns the replicas status of a component, defaulting the desired replicas like the API server.
+func newComponentReplicas(component string, desired *int32, ready int32) argoprojv1a1.ArgoCDComponentReplicas {
+	replicas := argoprojv1a1.ArgoCDComponentReplicas{Component: component, Desired: 1, Ready: ready}
+	if desired != nil {
+		replicas.Desired = *desired
+	}
+	return replicas
+}
+
+// reconcileStatusShards will ensure that the clusters assigned to each Application Controller shard are updated for the given ArgoCD.
+func (r *ReconcileArgoCD) reconcileStatusShards(cr *argoprojv1a1.ArgoCD) error {
+	var shards []argoprojv1a1.ArgoCDShardAssignment
+
+	if isBalancedShardAssignmentEnabled(cr) {
+		replicas, err := r.getArgoControllerReplicas(cr)
+		if err != nil {
+			return err
+		}
+		clusters, err := r.getClusterSecrets(cr)
+		if err != nil {
+			return err
+		}
+
+		shards = make([]argoprojv1a1.ArgoCDShardAssignment, replicas)
+		for i := range shards {
+			shards[i].Shard = int32(i)
+		}
+		for i := range clusters.Items {
+			secret := &clusters.Items[i]
+			if _, ok := secret.Annotations[common.ArgoCDShardAssignedAnnotation]; !ok {
+				continue
+			}
+			shard, err := strconv.Atoi(string(secret.Data["shard"]))
+			if err != nil || shard < 0 || shard >= len(shards) {
+				continue // Assignment is out of date, it will be updated on the next reconciliation.
+			}
+			shards[shard].Clusters = append(shards[shard].Clusters, secret.Name)
+			shards[shard].Weight += getClusterShardWeight(cr, secret)
+		}
+		for i := range shards {
+			sort.Strings(shards[i].Clusters)
+		}
+	}
+
+	if !reflect.DeepEqual(cr.Status.Shards, shards) {
+		cr.Status.Shards = shards
+		return r.Client.Status().Update(context.TODO(), cr)
+	}
+	return nil
+}
+
+// reconcileStatusCertificates will ensure that the expiry of the CA and certificates generated by the operator is
+// reported in the Status and metrics for the given ArgoCD.
+func (r *ReconcileArgoCD) reconcileStatusCertificates(cr *argoprojv1a1.ArgoCD) error {
+	var certificates []argoprojv1a1.ArgoCDCertificateStatus
+
+	caSecret := argoutil.NewSecretWithSuffix(cr, common.ArgoCDCASuffix)
+	var caCert *x509.Certificate
+	if argoutil.IsObjectFound(r.Client, cr.Namespace, caSecret.Name, caSecret) {
+		if cert, err := parseSecretCertificate(caSecret); err == nil {
+			caCert = cert
+			certificates = append(certificates, getCertificateStatus(caSecret.Name, cert, isSelfSignedCertificate(cert), cr))
+		}
+	}
+
+	ingressSecrets := map[string]bool{}
+	for _, name := range getIngressTLSSecretNames(cr) {
+		ingressSecrets[name] = true
+	}
+
+	for _, name := range getCertificateSecretNames(cr)[1:] {
+		secret := argoutil.NewSecretWithName(cr, name)
+		if !argoutil.IsObjectFound(r.Client, cr.Namespace, secret.Name, secret) {
+			continue
+		}
+		if ingressSecrets[name] && !metav1.IsControlledBy(secret, cr) {
+			continue // Ingress TLS Secret provided by the user.
+		}
+		if cert, err := parseSecretCertificate(secret); err == nil {
+			renewed := caCert != nil && cert.CheckSignatureFrom(caCert) == nil
+			certificates = append(certificates, getCertificateStatus(secret.Name, cert, renewed, cr))
+		}
+	}
+
+	deleteCertificateExpiryMetrics(cr)
+	for _, certificate := range certificates {
+		certificateExpiryGauge.WithLabelValues(cr.Namespace, cr.Name, certificate.SecretName).Set(float64(certificate.NotAfter.Unix()))
+	}
+
+	if !equality.Semantic.DeepEqual(cr.Status.Certificates, certificates) {
+		cr.Status.Certificates = certificates
+		return r.Client.Status().Update(context.TODO(), cr)
+	}
+	return nil
+}
+
+// reconcileStatusConditions will ensure that the component and summary Conditions are updated for the given ArgoCD.
+// The component conditions are derived from the component status values, so this must run after those are updated.
+func (r *ReconcileArgoCD) reconcileStatusConditions(cr *argoprojv1a1.ArgoCD) error {
+	conditions := cloneConditions(cr.Status.Conditions)
+
+	setComponentCondition(cr, argoprojv1a1.ArgoCDConditionApplicationControllerReady, "application controller", cr.Status.ApplicationController)
+	if isExternalRedis(cr) {
+		setExternalRedisCondition(cr)
+	} else {
+		setComponentCondition(cr, argoprojv1a1.ArgoCDConditionRedisReady, "redis", cr.Status.Redis)
+	}
+	setComponentCondition(cr, argoprojv1a1.ArgoCDConditionRepoReady, "repo server", cr.Status.Repo)
+	setComponentCondition(cr, argoprojv1a1.ArgoCDConditionServerReady, "server", cr.Status.Server)
+	if isDexDisabled() {
+		meta.RemoveStatusCondition(&cr.Status.Conditions, argoprojv1a1.ArgoCDConditionDexReady)
+	} else {
+		setComponentCondition(cr, argoprojv1a1.ArgoCDConditionDexReady, "dex", cr.Status.Dex)
+	}
+	setSummaryConditions(cr)
+
+	if !reflect.DeepEqual(conditions, cr.Status.Conditions) {
+		return r.Client.Status().Update(context.TODO(), cr)
+	}
+	return nil
+}
+
+// reconcileStatusReconcileResult will ensure that the ReconcileSucceeded condition reflects the outcome of the
+// last reconciliation of the given ArgoCD.
+func (r *ReconcileArgoCD) reconcileStatusReconcileResult(cr *argoprojv1a1.ArgoCD, reconcileErr error) error {
+	conditions := cloneConditions(cr.Status.Conditions)
+
+	condition := metav1.Condition{
+		Type:               argoprojv1a1.ArgoCDConditionReconcileSucceeded,
+		Status:             metav1.ConditionTrue,
+		Reason:             argoprojv1a1.ArgoCDReasonReconcileSucceeded,
+		Message:            "All resources have been reconciled",
+		ObservedGeneration: cr.Generation,
+	}
+	if reconcileErr != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = argoprojv1a1.ArgoCDReasonReconcileFailed
+		condition.Message = reconcileErr.Error()
+	}
+	meta.SetStatusCondition(&cr.Status.Conditions, condition)
+	setSummaryConditions(cr)
+
+	if !reflect.DeepEqual(conditions, cr.Status.Conditions) {
+		return r.Client.Status().Update(context.TODO(), cr)
+	}
+	return nil
+}
+
+// reconcileStatusResourceCustomizations will ensure that the ResourceCustomizationsValid condition reflects the
+// outcome of applying the resource customizations for the given ArgoCD.
+func (r *ReconcileArgoCD) reconcileStatusResourceCustomizations(cr *argoprojv1a1.ArgoCD, invalidErr error) error {
+	conditions := cloneConditions(cr.Status.Conditions)
+
+	condition := metav1.Condition{
+		Type:               argoprojv1a1.ArgoCDConditionResourceCustomizationsValid,
+		Status:             metav1.ConditionTrue,
+		Reason:             argoprojv1a1.ArgoCDReasonResourceCustomizationsApplied,
+		Message:            "Resource customizations have been applied",
+		ObservedGeneration: cr.Generation,
+	}
+	if invalidErr != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = argoprojv1a1.ArgoCDReasonInvalidResourceCustomizations
+		condition.Message = invalidErr.Error()
+	}
+	meta.SetStatusCondition(&cr.Status.Conditions, condition)
+
+	if !reflect.DeepEqual(conditions, cr.Status.Conditions) {
+		return r.Client.Status().Update(context.TODO(), cr)
+	}
+	return nil
+}
+
+// reconcileStatusRBACPolicy will ensure that the RBACPolicyValid condition reflects the given RBAC policy validation
+// error, if any.
+func (r *ReconcileArgoCD) reconcileStatusRBACPolicy(cr *argoprojv1a1.ArgoCD, invalidErr error) error {
+	conditions := cloneConditions(cr.Status.Conditions)
+
+	condition := metav1.Condition{
+		Type:               argoprojv1a1.ArgoCDConditionRBACPolicyValid,
+		Status:             metav1.ConditionTrue,
+		Reason:             argoprojv1a1.ArgoCDReasonRBACPolicyApplied,
+		Message:            "RBAC policy has been applied",
+		ObservedGeneration: cr.Generation,
+	}
+	if invalidErr != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = argoprojv1a1.ArgoCDReasonInvalidRBACPolicy
+		condition.Message = invalidErr.Error()
+	}
+	meta.SetStatusCondition(&cr.Status.Conditions, condition)
+
+	if !reflect.DeepEqual(conditions, cr.Status.Conditions) {
+		return r.Client.Status().Update(context.TODO(), cr)
+	}
+	return nil
+}
+
+// setComponentCondition will set the Ready condition of the given type based on the component status value.
+func setComponentCondition(cr *argoprojv1a1.ArgoCD, conditionType string, component string, status string) {
+	condition := metav1.Condition{
+		Type:               conditionType,
+		Status:             metav1.ConditionUnknown,
+		Reason:             "Unknown",
+		Message:            fmt.Sprintf("The state of the %s component could not be obtained", component),
+		ObservedGeneration: cr.Generation,
+	}
+
+	switch status {
+	case "Running":
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = status
+		condition.Message = fmt.Sprintf("All %s pods are ready", component)
+	case "Pending":
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = status
+		condition.Message = fmt.Sprintf("Waiting for the %s pods to become ready", component)
+	case "Failed":
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = status
+		condition.Message = fmt.Sprintf("At least one of the %s pods has failed", component)
+	}
+
+	meta.SetStatusCondition(&cr.Status.Conditions, condition)
+}
+
+// setExternalRedisCondition will set the RedisReady condition based on the reachability of the external Redis.
+func setExternalRedisCondition(cr *argoprojv1a1.ArgoCD) {
+	condition := metav1.Condition{
+		Type:               argoprojv1a1.ArgoCDConditionRedisReady,
+		Status:             metav1.ConditionFalse,
+		Reason:             "Unreachable",
+		Message:            fmt.Sprintf("The external redis at %s is not reachable", getRedisServerAddress(cr)),
+		ObservedGeneration: cr.Generation,
+	}
+	switch cr.Status.Redis {
+	case "Running":
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "Reachable"
+		condition.Message = fmt.Sprintf("The external redis at %s is reachable", getRedisServerAddress(cr))
+	case "Pending":
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = "Pending"
+		condition.Message = fmt.Sprintf("Checking whether the external redis at %s is reachable", getRedisServerAddress(cr))
+	}
+	meta.SetStatusCondition(&cr.Status.Conditions, condition)
+}
+
+// setSummaryConditions will set the Available, Progressing and Degraded conditions based on the component status
+// values and the outcome of the last reconciliation.
+func setSummaryConditions(cr *argoprojv1a1.ArgoCD) {
+	components := []string{cr.Status.ApplicationController, cr.Status.Redis, cr.Status.Repo, cr.Status.Server}
+
+	available := metav1.Condition{
+		Type:               argoprojv1a1.ArgoCDConditionAvailable,
+		Status:             metav1.ConditionFalse,
+		Reason:             argoprojv1a1.ArgoCDReasonComponentsNotReady,
+		Message:            "One or more components are not ready",
+		ObservedGeneration: cr.Generation,
+	}
+	if cr.Status.Phase == "Available" {
+		available.Status = metav1.ConditionTrue
+		available.Reason = argoprojv1a1.ArgoCDReasonAvailable
+		available.Message = "All components are ready"
+	}
+	meta.SetStatusCondition(&cr.Status.Conditions, available)
+
+	progressing := metav1.Condition{
+		Type:               argoprojv1a1.ArgoCDConditionProgressing,
+		Status:             metav1.ConditionFalse,
+		Reason:             argoprojv1a1.ArgoCDReasonRolloutComplete,
+		Message:            "No components are being rolled out",
+		ObservedGeneration: cr.Generation,
+	}
+	if containsString(components, "Pending") {
+		progressing.Status = metav1.ConditionTrue
+		progressing.Reason = argoprojv1a1.ArgoCDReasonComponentsNotReady
+		progressing.Message = "One or more components are being rolled out"
+	}
+	meta.SetStatusCondition(&cr.Status.Conditions, progressing)
+
+	degraded := metav1.Condition{
+		Type:               argoprojv1a1.ArgoCDConditionDegraded,
+		Status:             metav1.ConditionFalse,
+		Reason:             argoprojv1a1.ArgoCDReasonReconcileSucceeded,
+		Message:            "No failures have been observed",
+		ObservedGeneration: cr.Generation,
+	}
+	if c := meta.FindStatusCondition(cr.Status.Conditions, argoprojv1a1.ArgoCDConditionReconcileSucceeded); c != nil && c.Status == metav1.ConditionFalse {
+		degraded.Status = metav1.ConditionTrue
+		degraded.Reason = argoprojv1a1.ArgoCDReasonReconcileFailed
+		degraded.Message = c.Message
+	} else if containsString(components, "Failed") {
+		degraded.Status = metav1.ConditionTrue
+		degraded.Reason = argoprojv1a1.ArgoCDReasonComponentFailed
+		degraded.Message = "One or more components have failed"
+	}
+	meta.SetStatusCondition(&cr.Status.Conditions, degraded)
+}
+
+// cloneConditions will return a deep copy of the given conditions.
+func cloneConditions(conditions []metav1.Condition) []metav1.Condition {
+	if conditions == nil {
+		return nil
+	}
+	clone := make([]metav1.Condition, len(conditions))
+	for i := range conditions {
+		conditions[i].DeepCopyInto(&clone[i])
+	}
+	return clone
+}
@@ -1,10 +1,19 @@
 package argocd
 
 import (
+	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"gotest.tools/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	argoprojv1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
 )
 
 func TestReconcileArgoCD_reconcileStatusSSOConfig_multi_sso_configured(t *testing.T) {
@@ -43,3 +52,180 @@ func TestReconcileArgoCD_reconcileStatusSSOConfig_no_sso_configured(t *testing.T
 	assert.NilError(t, r.reconcileStatusSSOConfig(a))
 	assert.Equal(t, a.Status.SSOConfig, "Unknown")
 }
+
+func TestReconcileArgoCD_reconcileStatusConditions(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+	a.Generation = 3
+
+	r := makeTestReconciler(t, a)
+	assert.NilError(t, r.reconcileStatus(a))
+
+	available := meta.FindStatusCondition(a.Status.Conditions, argoprojv1alpha1.ArgoCDConditionAvailable)
+	assert.Assert(t, available != nil)
+	assert.Equal(t, available.Status, metav1.ConditionFalse)
+	assert.Equal(t, available.ObservedGeneration, int64(3))
+
+	server := meta.FindStatusCondition(a.Status.Conditions, argoprojv1alpha1.ArgoCDConditionServerReady)
+	assert.Assert(t, server != nil)
+	assert.Equal(t, server.Status, metav1.ConditionUnknown)
+
+	a.Status.ApplicationController = "Running"
+	a.Status.Redis = "Running"
+	a.Status.Repo = "Running"
+	a.Status.Server = "Running"
+	a.Status.Phase = "Available"
+	assert.NilError(t, r.reconcileStatusConditions(a))
+
+	assert.Assert(t, meta.IsStatusConditionTrue(a.Status.Conditions, argoprojv1alpha1.ArgoCDConditionAvailable))
+	assert.Assert(t, meta.IsStatusConditionTrue(a.Status.Conditions, argoprojv1alpha1.ArgoCDConditionServerReady))
+	assert.Assert(t, meta.IsStatusConditionFalse(a.Status.Conditions, argoprojv1alpha1.ArgoCDConditionProgressing))
+}
+
+func TestReconcileArgoCD_reconcileStatusReconcileResult(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD()
+
+	r := makeTestReconciler(t, a)
+	assert.NilError(t, r.reconcileStatusReconcileResult(a, errors.New("multiple SSO configuration")))
+
+	reconciled := meta.FindStatusCondition(a.Status.Conditions, argoprojv1alpha1.ArgoCDConditionReconcileSucceeded)
+	assert.Assert(t, reconciled != nil)
+	assert.Equal(t, reconciled.Status, metav1.ConditionFalse)
+	assert.Equal(t, reconciled.Reason, argoprojv1alpha1.ArgoCDReasonReconcileFailed)
+	assert.Equal(t, reconciled.Message, "multiple SSO configuration")
+	assert.Assert(t, meta.IsStatusConditionTrue(a.Status.Conditions, argoprojv1alpha1.ArgoCDConditionDegraded))
+
+	assert.NilError(t, r.reconcileStatusReconcileResult(a, nil))
+	assert.Assert(t, meta.IsStatusConditionTrue(a.Status.Conditions, argoprojv1alpha1.ArgoCDConditionReconcileSucceeded))
+	assert.Assert(t, meta.IsStatusConditionFalse(a.Status.Conditions, argoprojv1alpha1.ArgoCDConditionDegraded))
+}
+
+func TestReconcileArgoCD_reconcileStatusReplicas(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Server.Replicas = int32Ptr(3)
+	})
+	r := makeTestReconciler(t, a)
+
+	assert.NilError(t, r.reconcileServerDeployment(a))
+	assert.NilError(t, r.reconcileRepoDeployment(a))
+	assert.NilError(t, r.reconcileApplicationControllerStatefulSet(a))
+
+	deployment := &appsv1.Deployment{}
+	assert.NilError(t, r.Client.Get(context.TODO(), types.NamespacedName{Name: "argocd-server", Namespace: testNamespace}, deployment))
+	deployment.Status.ReadyReplicas = 2
+	assert.NilError(t, r.Client.Status().Update(context.TODO(), deployment))
+
+	assert.NilError(t, r.reconcileStatusReplicas(a))
+	assert.DeepEqual(t, a.Status.Replicas, []argoprojv1alpha1.ArgoCDComponentReplicas{
+		{Component: "applicationController", Desired: 1, Ready: 0},
+		{Component: "repo", Desired: 1, Ready: 0},
+		{Component: "server", Desired: 3, Ready: 2},
+	})
+}
+
+// waitForTestExternalRedisProbe waits for the connection attempt to the external Redis of the given ArgoCD to complete.
+func waitForTestExternalRedisProbe(t *testing.T, cr *argoprojv1alpha1.ArgoCD) {
+	t.Helper()
+	address := getRedisServerAddress(cr)
+	for i := 0; i < 100; i++ {
+		externalRedisProbesMutex.Lock()
+		probe := externalRedisProbes[types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name}]
+		done := probe != nil && probe.address == address && !probe.probing && !probe.checked.IsZero()
+		externalRedisProbesMutex.Unlock()
+		if done {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("connection attempt to %s did not complete", address)
+}
+
+func TestReconcileArgoCD_reconcileStatusRedis_external(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Redis.External = &argoprojv1alpha1.ArgoCDRedisExternalSpec{Address: "redis.cache.example.com:6379"}
+	})
+	r := makeTestReconciler(t, a)
+
+	defer func(dial func(string) error) {
+		dialRedis = dial
+		externalRedisProbes = map[types.NamespacedName]*externalRedisProbe{}
+	}(dialRedis)
+	dialed := make(chan string, 1)
+	dialRedis = func(address string) error {
+		dialed <- address
+		return errors.New("connection refused")
+	}
+
+	// The reachability is checked in the background and pending until the first connection attempt completes.
+	assert.NilError(t, r.reconcileStatusRedis(a))
+	assert.Equal(t, a.Status.Redis, "Pending")
+	assert.Equal(t, <-dialed, "redis.cache.example.com:6379")
+	waitForTestExternalRedisProbe(t, a)
+	assert.Equal(t, getExternalRedisRequeueAfter(a), externalRedisProbeInterval)
+
+	assert.NilError(t, r.reconcileStatusRedis(a))
+	assert.Equal(t, a.Status.Redis, "Failed")
+	assert.NilError(t, r.reconcileStatusConditions(a))
+	redis := meta.FindStatusCondition(a.Status.Conditions, argoprojv1alpha1.ArgoCDConditionRedisReady)
+	assert.Assert(t, redis != nil)
+	assert.Equal(t, redis.Status, metav1.ConditionFalse)
+	assert.Equal(t, redis.Reason, "Unreachable")
+
+	// The result is cached until the next check is due.
+	dialRedis = func(string) error { return nil }
+	externalRedisProbesMutex.Lock()
+	externalRedisProbes[types.NamespacedName{Namespace: a.Namespace, Name: a.Name}].checked = time.Now().Add(-externalRedisProbeInterval)
+	externalRedisProbesMutex.Unlock()
+	assert.NilError(t, r.reconcileStatusRedis(a))
+	assert.Equal(t, a.Status.Redis, "Failed")
+	waitForTestExternalRedisProbe(t, a)
+
+	assert.NilError(t, r.reconcileStatusRedis(a))
+	assert.Equal(t, a.Status.Redis, "Running")
+	assert.NilError(t, r.reconcileStatusConditions(a))
+	assert.Assert(t, meta.IsStatusConditionTrue(a.Status.Conditions, argoprojv1alpha1.ArgoCDConditionRedisReady))
+}
+
+func TestReconcileArgoCD_reconcileStatusRedis_externalProbeCleanup(t *testing.T) {
+	logf.SetLogger(ZapLogger(true))
+	a := makeTestArgoCD(func(a *argoprojv1alpha1.ArgoCD) {
+		a.Spec.Redis.External = &argoprojv1alpha1.ArgoCDRedisExternalSpec{Address: "redis.cache.example.com:6379"}
+	})
+	r := makeTestReconciler(t, a)
+
+	defer func(dial func(string) error) {
+		dialRedis = dial
+		externalRedisProbes = map[types.NamespacedName]*externalRedisProbe{}
+	}(dialRedis)
+	dialRedis = func(address string) error {
+		if address == "redis.cache.example.com:6379" {
+			return errors.New("connection refused")
+		}
+		return nil
+	}
+
+	assert.NilError(t, r.reconcileStatusRedis(a))
+	waitForTestExternalRedisProbe(t, a)
+	assert.NilError(t, r.reconcileStatusRedis(a))
+	assert.Equal(t, a.Status.Redis, "Failed")
+
+	// The result for the previous address is dropped when the address changes.
+	a.Spec.Redis.External.Address = "redis.example.com:6379"
+	assert.NilError(t, r.reconcileStatusRedis(a))
+	assert.Equal(t, a.Status.Redis, "Pending")
+	waitForTestExternalRedisProbe(t, a)
+	assert.NilError(t, r.reconcileStatusRedis(a))
+	assert.Equal(t, a.Status.Redis, "Running")
+	assert.Equal(t, len(externalRedisProbes), 1)
+
+	// The probe is removed when the ArgoCD no longer uses an external Redis.
+	a.Spec.Redis.External = nil
+	assert.NilError(t, r.reconcileStatusRedis(a))
+	externalRedisProbesMutex.Lock()
+	_, found := externalRedisProbes[types.NamespacedName{Namespace: a.Namespace, Name: a.Name}]
+	externalRedisProbesMutex.Unlock()
+	assert.Assert(t, !found)
+}
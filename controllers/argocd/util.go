@@ -25,6 +25,7 @@ import (
 	"strconv"
 	"strings"
 	"text/template"
+	"time"
 
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 
@@ -41,9 +42,11 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	v1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/types"
@@ -86,6 +89,18 @@ func generateArgoServerSessionKey() ([]byte, error) {
 	return []byte(pass), err
 }
 
+// generateRedisPassword will generate and return the Redis password for Argo CD.
+// The password contains no symbols, as it is substituted into the Redis and HAProxy configuration.
+func generateRedisPassword() ([]byte, error) {
+	pass, err := password.Generate(
+		common.ArgoCDDefaultAdminPasswordLength,
+		common.ArgoCDDefaultAdminPasswordNumDigits,
+		0,
+		false, false)
+
+	return []byte(pass), err
+}
+
 // getArgoApplicationControllerResources will return the ResourceRequirements for the Argo CD application controller container.
 func getArgoApplicationControllerResources(cr *argoprojv1a1.ArgoCD) corev1.ResourceRequirements {
 	resources := corev1.ResourceRequirements{}
@@ -103,11 +118,13 @@ func getArgoApplicationControllerCommand(cr *argoprojv1a1.ArgoCD) []string {
 	cmd := []string{
 		"argocd-application-controller",
 		"--operation-processors", fmt.Sprint(getArgoServerOperationProcessors(cr)),
-		"--redis", getRedisServerAddress(cr),
+	}
+	cmd = append(cmd, getRedisClientArgs(cr)...)
+	cmd = append(cmd,
 		"--repo-server", getRepoServerAddress(cr),
 		"--status-processors", fmt.Sprint(getArgoServerStatusProcessors(cr)),
-		"--kubectl-parallelism-limit", fmt.Sprint(getArgoControllerParellismLimit(cr)),
-	}
+		"--kubectl-parallelism-limit", fmt.Sprint(getArgoControllerParellismLimit(cr)))
+
 	if cr.Spec.Controller.AppSync != nil {
 		cmd = append(cmd, "--app-resync", strconv.FormatInt(int64(cr.Spec.Controller.AppSync.Seconds()), 10))
 	}
@@ -172,6 +189,15 @@ func getRepoServerContainerImage(cr *argoprojv1a1.ArgoCD) string {
 	return argoutil.CombineImageTag(img, tag)
 }
 
+// getArgoRepoReplicas will return the replica count for the Argo CD Repo server Deployment,
+// or nil when the replica count is not managed by the operator.
+func getArgoRepoReplicas(cr *argoprojv1a1.ArgoCD) *int32 {
+	if isDeploymentAutoscaled(cr.Spec.Repo.Autoscale) {
+		return nil // The replica count is owned by the HorizontalPodAutoscaler.
+	}
+	return cr.Spec.Repo.Replicas
+}
+
 // getArgoRepoResources will return the ResourceRequirements for the Argo CD Repo server container.
 func getArgoRepoResources(cr *argoprojv1a1.ArgoCD) corev1.ResourceRequirements {
 	resources := corev1.ResourceRequirements{}
@@ -211,6 +237,15 @@ func getArgoServerHost(cr *argoprojv1a1.ArgoCD) string {
 	return host
 }
 
+// getArgoServerReplicas will return the replica count for the Argo CD server Deployment,
+// or nil when the replica count is not managed by the operator.
+func getArgoServerReplicas(cr *argoprojv1a1.ArgoCD) *int32 {
+	if cr.Spec.Server.Autoscale.Enabled {
+		return nil // The replica count is owned by the HorizontalPodAutoscaler.
+	}
+	return cr.Spec.Server.Replicas
+}
+
 // getArgoServerResources will return the ResourceRequirements for the Argo CD server container.
 func getArgoServerResources(cr *argoprojv1a1.ArgoCD) corev1.ResourceRequirements {
 	resources := corev1.ResourceRequirements{}
@@ -254,6 +289,11 @@ func (r *ReconcileArgoCD) getArgoServerURI(cr *argoprojv1a1.ArgoCD) string {
 		}
 	}
 
+	// Use the first Gateway API route hostname if enabled
+	if cr.Spec.Server.Gateway.Enabled && cr.Spec.Server.Insecure && IsGatewayAPIAvailable() && len(cr.Spec.Server.Gateway.Hostnames) > 0 {
+		host = cr.Spec.Server.Gateway.Hostnames[0]
+	}
+
 	// Use Route host if available, override Ingress if both exist
 	if IsRouteAPIAvailable() {
 		route := newRouteWithSuffix("server", cr)
@@ -445,7 +485,7 @@ func getRedisConfigPath() string {
 // If an error occurs, an empty string value will be returned.
 func getRedisConf(cr *argoprojv1a1.ArgoCD) string {
 	path := fmt.Sprintf("%s/redis.conf.tpl", getRedisConfigPath())
-	conf, err := loadTemplateFile(path, map[string]string{})
+	conf, err := loadTemplateFile(path, getRedisTemplateVars(cr))
 	if err != nil {
 		log.Error(err, "unable to load redis configuration")
 		return ""
@@ -522,11 +562,7 @@ func getRedisHAProxyContainerImage(cr *argoprojv1a1.ArgoCD) string {
 // If an error occurs, an empty string value will be returned.
 func getRedisInitScript(cr *argoprojv1a1.ArgoCD) string {
 	path := fmt.Sprintf("%s/init.sh.tpl", getRedisConfigPath())
-	vars := map[string]string{
-		"ServiceName": nameWithSuffix("redis-ha", cr),
-	}
-
-	script, err := loadTemplateFile(path, vars)
+	script, err := loadTemplateFile(path, getRedisTemplateVars(cr))
 	if err != nil {
 		log.Error(err, "unable to load redis init-script")
 		return ""
@@ -538,11 +574,7 @@ func getRedisInitScript(cr *argoprojv1a1.ArgoCD) string {
 // If an error occurs, an empty string value will be returned.
 func getRedisHAProxyConfig(cr *argoprojv1a1.ArgoCD) string {
 	path := fmt.Sprintf("%s/haproxy.cfg.tpl", getRedisConfigPath())
-	vars := map[string]string{
-		"ServiceName": nameWithSuffix("redis-ha", cr),
-	}
-
-	script, err := loadTemplateFile(path, vars)
+	script, err := loadTemplateFile(path, getRedisTemplateVars(cr))
 	if err != nil {
 		log.Error(err, "unable to load redis haproxy configuration")
 		return ""
@@ -554,11 +586,7 @@ func getRedisHAProxyConfig(cr *argoprojv1a1.ArgoCD) string {
 // If an error occurs, an empty string value will be returned.
 func getRedisHAProxyScript(cr *argoprojv1a1.ArgoCD) string {
 	path := fmt.Sprintf("%s/haproxy_init.sh.tpl", getRedisConfigPath())
-	vars := map[string]string{
-		"ServiceName": nameWithSuffix("redis-ha", cr),
-	}
-
-	script, err := loadTemplateFile(path, vars)
+	script, err := loadTemplateFile(path, getRedisTemplateVars(cr))
 	if err != nil {
 		log.Error(err, "unable to load redis haproxy init script")
 		return ""
@@ -594,7 +622,7 @@ func getRedisHAProxyResources(cr *argoprojv1a1.ArgoCD) corev1.ResourceRequiremen
 // If an error occurs, an empty string value will be returned.
 func getRedisSentinelConf(cr *argoprojv1a1.ArgoCD) string {
 	path := fmt.Sprintf("%s/sentinel.conf.tpl", getRedisConfigPath())
-	conf, err := loadTemplateFile(path, map[string]string{})
+	conf, err := loadTemplateFile(path, getRedisTemplateVars(cr))
 	if err != nil {
 		log.Error(err, "unable to load redis sentinel configuration")
 		return ""
@@ -604,14 +632,210 @@ func getRedisSentinelConf(cr *argoprojv1a1.ArgoCD) string {
 
 // getRedisServerAddress will return the Redis service address for the given ArgoCD.
 func getRedisServerAddress(cr *argoprojv1a1.ArgoCD) string {
+	if isExternalRedis(cr) {
+		return cr.Spec.Redis.External.Address
+	}
 	if cr.Spec.HA.Enabled {
 		return getRedisHAProxyAddress(cr)
 	}
 	return fqdnServiceRef(common.ArgoCDDefaultRedisSuffix, common.ArgoCDDefaultRedisPort, cr)
 }
 
+// getRedisSentinelQuorum will return the number of Sentinels that need to agree a Redis master is down for the given ArgoCD.
+func getRedisSentinelQuorum(cr *argoprojv1a1.ArgoCD) int32 {
+	if cr.Spec.HA.SentinelQuorum != nil {
+		return *cr.Spec.HA.SentinelQuorum
+	}
+	return common.ArgoCDDefaultRedisSentinelQuorum
+}
+
+// getRedisSentinelDownAfter will return the time a Redis master is unreachable before a Sentinel considers it down
+// for the given ArgoCD.
+func getRedisSentinelDownAfter(cr *argoprojv1a1.ArgoCD) time.Duration {
+	if cr.Spec.HA.SentinelDownAfter != nil {
+		return cr.Spec.HA.SentinelDownAfter.Duration
+	}
+	return common.ArgoCDDefaultRedisSentinelDownAfter
+}
+
+// getRedisSentinelFailoverTimeout will return the failover timeout of the Redis Sentinels for the given ArgoCD.
+func getRedisSentinelFailoverTimeout(cr *argoprojv1a1.ArgoCD) time.Duration {
+	if cr.Spec.HA.SentinelFailoverTimeout != nil {
+		return cr.Spec.HA.SentinelFailoverTimeout.Duration
+	}
+	return common.ArgoCDDefaultRedisSentinelFailoverTimeout
+}
+
+// getRedisTemplateVars will return the variables of the Redis HA configuration templates for the given ArgoCD.
+func getRedisTemplateVars(cr *argoprojv1a1.ArgoCD) map[string]interface{} {
+	indexes := []int32{}
+	for i := int32(0); i < *getRedisHAReplicas(cr); i++ {
+		indexes = append(indexes, i)
+	}
+
+	return map[string]interface{}{
+		"ServiceName":                 nameWithSuffix("redis-ha", cr),
+		"AuthEnabled":                 strconv.FormatBool(cr.Spec.Redis.AuthEnabled),
+		"UseTLS":                      strconv.FormatBool(cr.Spec.Redis.TLSEnabled),
+		"TLSPath":                     common.ArgoCDDefaultRedisTLSPath,
+		"Indexes":                     indexes,
+		"Quorum":                      fmt.Sprint(getRedisSentinelQuorum(cr)),
+		"DownAfterMilliseconds":       fmt.Sprint(getRedisSentinelDownAfter(cr).Milliseconds()),
+		"FailoverTimeoutMilliseconds": fmt.Sprint(getRedisSentinelFailoverTimeout(cr).Milliseconds()),
+		"PersistenceEnabled":          strconv.FormatBool(cr.Spec.HA.RedisStorage != nil),
+	}
+}
+
+// getRedisArgs will return the arguments of the Redis server for the given ArgoCD when HA is not enabled.
+func getRedisArgs(cr *argoprojv1a1.ArgoCD) []string {
+	args := []string{
+		"--save",
+		"",
+		"--appendonly",
+		"no",
+	}
+
+	if cr.Spec.Redis.AuthEnabled {
+		// The password is expanded from the environment of the container by the kubelet.
+		args = append(args, "--requirepass", "$(REDIS_PASSWORD)")
+	}
+
+	if cr.Spec.Redis.TLSEnabled {
+		args = append(args,
+			"--port", "0",
+			"--tls-port", fmt.Sprint(common.ArgoCDDefaultRedisPort),
+			"--tls-cert-file", fmt.Sprintf("%s/%s", common.ArgoCDDefaultRedisTLSPath, corev1.TLSCertKey),
+			"--tls-key-file", fmt.Sprintf("%s/%s", common.ArgoCDDefaultRedisTLSPath, corev1.TLSPrivateKeyKey),
+			"--tls-ca-cert-file", fmt.Sprintf("%s/%s", common.ArgoCDDefaultRedisTLSPath, corev1.ServiceAccountRootCAKey),
+			"--tls-auth-clients", "no")
+	}
+
+	return args
+}
+
+// getRedisAuthSecretName will return the name of the Secret containing the Redis password for the given ArgoCD.
+func getRedisAuthSecretName(cr *argoprojv1a1.ArgoCD) string {
+	return nameWithSuffix(common.ArgoCDDefaultRedisSuffix, cr)
+}
+
+// getRedisAuthEnv will return the environment variable with the given name providing the Redis password, when
+// password authentication is enabled for the given ArgoCD or the external Redis has a password.
+func getRedisAuthEnv(name string, cr *argoprojv1a1.ArgoCD) []corev1.EnvVar {
+	var ref *corev1.SecretKeySelector
+	if isExternalRedis(cr) {
+		ref = cr.Spec.Redis.External.PasswordSecretRef
+	} else if cr.Spec.Redis.AuthEnabled {
+		ref = &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{
+				Name: getRedisAuthSecretName(cr),
+			},
+			Key: common.ArgoCDKeyRedisAuth,
+		}
+	}
+	if ref == nil {
+		return nil
+	}
+	return []corev1.EnvVar{{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: ref,
+		},
+	}}
+}
+
+// getRedisClientArgs will return the arguments of the Argo CD components for connecting to Redis for the given ArgoCD.
+func getRedisClientArgs(cr *argoprojv1a1.ArgoCD) []string {
+	caCertificate := fmt.Sprintf("%s/%s", common.ArgoCDDefaultRedisTLSPath, corev1.ServiceAccountRootCAKey)
+
+	if !isExternalRedis(cr) {
+		args := []string{"--redis", getRedisServerAddress(cr)}
+		if cr.Spec.Redis.TLSEnabled {
+			args = append(args, "--redis-use-tls", "--redis-ca-certificate", caCertificate)
+		}
+		return args
+	}
+
+	external := cr.Spec.Redis.External
+	args := []string{"--redis", external.Address}
+	if external.SentinelMasterName != "" {
+		args = []string{"--sentinel", external.Address, "--sentinelmaster", external.SentinelMasterName}
+	}
+	if external.TLSEnabled {
+		args = append(args, "--redis-use-tls")
+		if external.CASecretRef != nil {
+			args = append(args, "--redis-ca-certificate", caCertificate)
+		}
+	}
+	return args
+}
+
+// getRedisTLSVolumes will return the volume of the Redis TLS certificate, when TLS is enabled for the given ArgoCD,
+// or of the CA certificate of the external Redis, when it has one.
+func getRedisTLSVolumes(cr *argoprojv1a1.ArgoCD) []corev1.Volume {
+	if isExternalRedis(cr) {
+		ref := cr.Spec.Redis.External.CASecretRef
+		if !cr.Spec.Redis.External.TLSEnabled || ref == nil {
+			return nil
+		}
+		return []corev1.Volume{{
+			Name: common.ArgoCDRedisExternalCAVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: ref.Name,
+					Items: []corev1.KeyToPath{{
+						Key:  ref.Key,
+						Path: corev1.ServiceAccountRootCAKey,
+					}},
+					Optional: ref.Optional,
+				},
+			},
+		}}
+	}
+	if !cr.Spec.Redis.TLSEnabled {
+		return nil
+	}
+	return []corev1.Volume{{
+		Name: common.ArgoCDRedisServerTLSSecretName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: common.ArgoCDRedisServerTLSSecretName,
+				Optional:   boolPtr(true),
+			},
+		},
+	}}
+}
+
+// getRedisTLSVolumeMounts will return the volume mount of the volume returned by getRedisTLSVolumes for the given
+// ArgoCD, if any.
+func getRedisTLSVolumeMounts(cr *argoprojv1a1.ArgoCD) []corev1.VolumeMount {
+	volumes := getRedisTLSVolumes(cr)
+	if len(volumes) == 0 {
+		return nil
+	}
+	return []corev1.VolumeMount{{
+		Name:      volumes[0].Name,
+		MountPath: common.ArgoCDDefaultRedisTLSPath,
+		ReadOnly:  true,
+	}}
+}
+
+// isExternalRedis returns whether the given ArgoCD uses an external Redis instead of the Redis managed by the operator.
+func isExternalRedis(cr *argoprojv1a1.ArgoCD) bool {
+	return cr.Spec.Redis.External != nil
+}
+
+// isRedisHAEnabled returns whether the Redis HA workloads are managed by the operator for the given ArgoCD.
+func isRedisHAEnabled(cr *argoprojv1a1.ArgoCD) bool {
+	return cr.Spec.HA.Enabled && !isExternalRedis(cr)
+}
+
+// isRedisDeploymentEnabled returns whether the Redis Deployment is managed by the operator for the given ArgoCD.
+func isRedisDeploymentEnabled(cr *argoprojv1a1.ArgoCD) bool {
+	return !cr.Spec.HA.Enabled && !isExternalRedis(cr)
+}
+
 // loadTemplateFile will parse a template with the given path and execute it with the given params.
-func loadTemplateFile(path string, params map[string]string) (string, error) {
+func loadTemplateFile(path string, params map[string]interface{}) (string, error) {
 	tmpl, err := template.ParseFiles(path)
 	if err != nil {
 		log.Error(err, "unable to parse template")
@@ -640,8 +864,8 @@ func fqdnServiceRef(service string, port int, cr *argoprojv1a1.ArgoCD) string {
 	return fmt.Sprintf("%s.%s.svc.cluster.local:%d", nameWithSuffix(service, cr), cr.Namespace, port)
 }
 
-// InspectCluster will verify the availability of extra features available to the cluster, such as Prometheus and
-// OpenShift Routes.
+// InspectCluster will verify the availability of extra features available to the cluster, such as Prometheus,
+// OpenShift Routes and the Gateway API.
 func InspectCluster() error {
 	if err := verifyPrometheusAPI(); err != nil {
 		return err
@@ -654,6 +878,18 @@ func InspectCluster() error {
 	if err := verifyTemplateAPI(); err != nil {
 		return err
 	}
+
+	if err := verifyCertManagerAPI(); err != nil {
+		return err
+	}
+
+	if err := verifyGatewayAPI(); err != nil {
+		return err
+	}
+
+	if err := verifyAutoscalingV2beta2API(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -698,6 +934,11 @@ func (r *ReconcileArgoCD) reconcileResources(cr *argoprojv1a1.ArgoCD) error {
 		return err
 	}
 
+	log.Info("reconciling certificates")
+	if err := r.reconcileCertificates(cr); err != nil {
+		return err
+	}
+
 	log.Info("reconciling secrets")
 	if err := r.reconcileSecrets(cr); err != nil {
 		return err
@@ -723,6 +964,21 @@ func (r *ReconcileArgoCD) reconcileResources(cr *argoprojv1a1.ArgoCD) error {
 		return err
 	}
 
+	log.Info("reconciling cluster shards")
+	if err := r.reconcileClusterShards(cr); err != nil {
+		return err
+	}
+
+	log.Info("reconciling pod disruption budgets")
+	if err := r.reconcilePodDisruptionBudgets(cr); err != nil {
+		return err
+	}
+
+	log.Info("reconciling network policies")
+	if err := r.reconcileNetworkPolicies(cr); err != nil {
+		return err
+	}
+
 	log.Info("reconciling autoscalers")
 	if err := r.reconcileAutoscalers(cr); err != nil {
 		return err
@@ -740,6 +996,13 @@ func (r *ReconcileArgoCD) reconcileResources(cr *argoprojv1a1.ArgoCD) error {
 		}
 	}
 
+	if IsGatewayAPIAvailable() {
+		log.Info("reconciling gateway routes")
+		if err := r.reconcileGatewayRoutes(cr); err != nil {
+			return err
+		}
+	}
+
 	if IsPrometheusAPIAvailable() {
 		log.Info("reconciling prometheus")
 		if err := r.reconcilePrometheus(cr); err != nil {
@@ -766,10 +1029,21 @@ func (r *ReconcileArgoCD) reconcileResources(cr *argoprojv1a1.ArgoCD) error {
 		}
 	}
 
+	if cr.Spec.Notifications != nil {
+		log.Info("reconciling Notifications controller")
+		if err := r.reconcileNotificationsController(cr); err != nil {
+			return err
+		}
+	}
+
 	if err := r.reconcileRepoServerTLSSecret(cr); err != nil {
 		return err
 	}
 
+	if err := r.reconcileRedisTLSChecksum(cr); err != nil {
+		return err
+	}
+
 	if cr.Spec.SSO != nil {
 		log.Info("reconciling SSO")
 		if err := r.reconcileSSO(cr); err != nil {
@@ -879,7 +1153,7 @@ func removeString(slice []string, s string) []string {
 }
 
 // setResourceWatches will register Watches for each of the supported Resources.
-func setResourceWatches(bldr *builder.Builder, clusterResourceMapper, tlsSecretMapper, namespaceResourceMapper handler.MapFunc) *builder.Builder {
+func setResourceWatches(bldr *builder.Builder, clusterResourceMapper, tlsSecretMapper, namespaceResourceMapper, clusterSecretMapper, appSetWebhookSecretMapper handler.MapFunc) *builder.Builder {
 
 	deploymentConfigPred := predicate.Funcs{
 		UpdateFunc: func(e event.UpdateEvent) bool {
@@ -952,6 +1226,9 @@ func setResourceWatches(bldr *builder.Builder, clusterResourceMapper, tlsSecretM
 	// Watch for changes to Ingress sub-resources owned by ArgoCD instances.
 	bldr.Owns(&networkingv1.Ingress{})
 
+	// Watch for changes to NetworkPolicy sub-resources owned by ArgoCD instances.
+	bldr.Owns(&networkingv1.NetworkPolicy{})
+
 	bldr.Owns(&v1.Role{})
 
 	bldr.Owns(&v1.RoleBinding{})
@@ -967,9 +1244,18 @@ func setResourceWatches(bldr *builder.Builder, clusterResourceMapper, tlsSecretM
 	// Watch for secrets of type TLS that might be created by external processes
 	bldr.Watches(&source.Kind{Type: &corev1.Secret{Type: corev1.SecretTypeTLS}}, tlsSecretHandler)
 
+	// Watch for cluster secrets that determine the number of Application Controller shards
+	bldr.Watches(&source.Kind{Type: &corev1.Secret{}}, handler.EnqueueRequestsFromMapFunc(clusterSecretMapper))
+
+	// Watch for the referenced webhook secrets of the ApplicationSet controller
+	bldr.Watches(&source.Kind{Type: &corev1.Secret{}}, handler.EnqueueRequestsFromMapFunc(appSetWebhookSecretMapper))
+
 	// Watch for changes to Secret sub-resources owned by ArgoCD instances.
 	bldr.Owns(&appsv1.StatefulSet{})
 
+	// Watch for changes to PodDisruptionBudget sub-resources owned by ArgoCD instances.
+	bldr.Owns(&policyv1.PodDisruptionBudget{})
+
 	// Inspect cluster to verify availability of extra features
 	// This sets the flags that are used in subsequent checks
 	if err := InspectCluster(); err != nil {
@@ -989,6 +1275,26 @@ func setResourceWatches(bldr *builder.Builder, clusterResourceMapper, tlsSecretM
 		bldr.Owns(&monitoringv1.ServiceMonitor{})
 	}
 
+	if IsCertManagerAPIAvailable() {
+		// Watch cert-manager Certificate sub-resources owned by ArgoCD instances.
+		certificate := &unstructured.Unstructured{}
+		certificate.SetGroupVersionKind(certificateGVK)
+		bldr.Owns(certificate)
+	}
+
+	if IsGatewayAPIAvailable() {
+		// Watch Gateway API route sub-resources owned by ArgoCD instances.
+		httpRoute := &unstructured.Unstructured{}
+		httpRoute.SetGroupVersionKind(httpRouteGVK)
+		bldr.Owns(httpRoute)
+
+		if IsGRPCRouteAPIAvailable() {
+			grpcRoute := &unstructured.Unstructured{}
+			grpcRoute.SetGroupVersionKind(grpcRouteGVK)
+			bldr.Owns(grpcRoute)
+		}
+	}
+
 	if IsTemplateAPIAvailable() {
 		// Watch for the changes to Deployment Config
 		bldr.Watches(&source.Kind{Type: &oappsv1.DeploymentConfig{}}, &handler.EnqueueRequestForOwner{
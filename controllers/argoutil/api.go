@@ -50,3 +50,37 @@ func VerifyAPI(group string, version string) (bool, error) {
 	log.Info(fmt.Sprintf("%s/%s API verified", group, version))
 	return true, nil
 }
+
+// VerifyAPIResource will verify that the given kind is served by the given group/version in the cluster.
+func VerifyAPIResource(group string, version string, kind string) (bool, error) {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		log.Error(err, "unable to get k8s config")
+		return false, err
+	}
+
+	k8s, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		log.Error(err, "unable to create k8s client")
+		return false, err
+	}
+
+	gv := schema.GroupVersion{
+		Group:   group,
+		Version: version,
+	}
+
+	resources, err := k8s.Discovery().ServerResourcesForGroupVersion(gv.String())
+	if err != nil {
+		// error, API not available
+		return false, nil
+	}
+
+	for _, resource := range resources.APIResources {
+		if resource.Kind == kind {
+			log.Info(fmt.Sprintf("%s/%s %s API verified", group, version, kind))
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -49,6 +49,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	argoprojiov1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
+	argoprojiov1beta1 "github.com/argoproj-labs/argocd-operator/api/v1beta1"
 	"github.com/argoproj-labs/argocd-operator/version"
 	//+kubebuilder:scaffold:imports
 )
@@ -62,6 +63,7 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(argoprojiov1alpha1.AddToScheme(scheme))
+	utilruntime.Must(argoprojiov1beta1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
 
@@ -135,6 +137,10 @@ func main() {
 		setupLog.Error(err, "")
 		os.Exit(1)
 	}
+	if err := argoprojiov1beta1.AddToScheme(mgr.GetScheme()); err != nil {
+		setupLog.Error(err, "")
+		os.Exit(1)
+	}
 
 	// Setup Scheme for Prometheus if available.
 	if argocd.IsPrometheusAPIAvailable() {
@@ -182,6 +188,22 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "ArgoCDExport")
 		os.Exit(1)
 	}
+
+	// Webhooks can be disabled when running the operator locally, where no serving certificates are available.
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err = (&argoprojiov1alpha1.ArgoCD{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ArgoCD")
+			os.Exit(1)
+		}
+		if err = (&argoprojiov1alpha1.ArgoCDExport{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ArgoCDExport")
+			os.Exit(1)
+		}
+		if err = (&argoprojiov1beta1.ArgoCD{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ArgoCD")
+			os.Exit(1)
+		}
+	}
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {